@@ -53,6 +53,8 @@ func init() {
 
 	CreateApiDeploymentCmd.Flags().StringVar(&CreateApiDeploymentInput.ApiDeploymentId, "api_deployment_id", "", "Required. The ID to use for the deployment, which...")
 
+	CreateApiDeploymentCmd.Flags().StringVar(&CreateApiDeploymentInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateApiDeploymentCmd.Flags().StringVar(&CreateApiDeploymentFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -49,6 +49,8 @@ func init() {
 
 	CreateApiSpecCmd.Flags().StringVar(&CreateApiSpecInput.ApiSpecId, "api_spec_id", "", "Required. The ID to use for the spec, which will...")
 
+	CreateApiSpecCmd.Flags().StringVar(&CreateApiSpecInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateApiSpecCmd.Flags().StringVar(&CreateApiSpecFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
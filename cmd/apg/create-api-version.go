@@ -45,6 +45,8 @@ func init() {
 
 	CreateApiVersionCmd.Flags().StringVar(&CreateApiVersionInput.ApiVersionId, "api_version_id", "", "Required. The ID to use for the version, which...")
 
+	CreateApiVersionCmd.Flags().StringVar(&CreateApiVersionInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateApiVersionCmd.Flags().StringVar(&CreateApiVersionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -49,6 +49,8 @@ func init() {
 
 	CreateApiCmd.Flags().StringVar(&CreateApiInput.ApiId, "api_id", "", "Required. The ID to use for the api, which will...")
 
+	CreateApiCmd.Flags().StringVar(&CreateApiInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateApiCmd.Flags().StringVar(&CreateApiFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
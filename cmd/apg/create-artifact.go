@@ -33,6 +33,8 @@ func init() {
 
 	CreateArtifactCmd.Flags().StringVar(&CreateArtifactInput.ArtifactId, "artifact_id", "", "Required. The ID to use for the artifact, which...")
 
+	CreateArtifactCmd.Flags().StringVar(&CreateArtifactInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateArtifactCmd.Flags().StringVar(&CreateArtifactFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
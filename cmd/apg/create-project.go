@@ -31,6 +31,8 @@ func init() {
 
 	CreateProjectCmd.Flags().StringVar(&CreateProjectInput.ProjectId, "project_id", "", "The ID to use for the project, which will become...")
 
+	CreateProjectCmd.Flags().StringVar(&CreateProjectInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	CreateProjectCmd.Flags().StringVar(&CreateProjectFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -23,6 +23,8 @@ func init() {
 
 	DeleteApiDeploymentRevisionCmd.Flags().StringVar(&DeleteApiDeploymentRevisionInput.Name, "name", "", "Required. The name of the deployment revision to...")
 
+	DeleteApiDeploymentRevisionCmd.Flags().BoolVar(&DeleteApiDeploymentRevisionInput.Force, "force", false, "If set, a revision with protected tags can be...")
+
 	DeleteApiDeploymentRevisionCmd.Flags().StringVar(&DeleteApiDeploymentRevisionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
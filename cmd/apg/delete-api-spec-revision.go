@@ -23,6 +23,8 @@ func init() {
 
 	DeleteApiSpecRevisionCmd.Flags().StringVar(&DeleteApiSpecRevisionInput.Name, "name", "", "Required. The name of the spec revision to be...")
 
+	DeleteApiSpecRevisionCmd.Flags().BoolVar(&DeleteApiSpecRevisionInput.Force, "force", false, "If set, a revision with protected tags can be...")
+
 	DeleteApiSpecRevisionCmd.Flags().StringVar(&DeleteApiSpecRevisionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetApiDeploymentFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(GetApiDeploymentCmd)
 
+	GetApiDeploymentInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetApiDeploymentCmd.Flags().StringVar(&GetApiDeploymentInput.Name, "name", "", "Required. The name of the deployment to retrieve....")
 
+	GetApiDeploymentCmd.Flags().StringSliceVar(&GetApiDeploymentInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetApiDeploymentCmd.Flags().StringVar(&GetApiDeploymentFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
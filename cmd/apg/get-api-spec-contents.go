@@ -11,6 +11,8 @@ import (
 
 	"os"
 
+	"strings"
+
 	rpcpb "github.com/apigee/registry/rpc"
 )
 
@@ -18,11 +20,17 @@ var GetApiSpecContentsInput rpcpb.GetApiSpecContentsRequest
 
 var GetApiSpecContentsFromFile string
 
+var GetApiSpecContentsInputFormat string
+
 func init() {
 	RegistryServiceCmd.AddCommand(GetApiSpecContentsCmd)
 
 	GetApiSpecContentsCmd.Flags().StringVar(&GetApiSpecContentsInput.Name, "name", "", "Required. The name of the spec whose contents...")
 
+	GetApiSpecContentsCmd.Flags().StringVar(&GetApiSpecContentsInputFormat, "format", "", "Optional. The format in which the contents should...")
+
+	GetApiSpecContentsCmd.Flags().StringVar(&GetApiSpecContentsInput.Path, "path", "", "Optional. The path of a single file to return from a...")
+
 	GetApiSpecContentsCmd.Flags().StringVar(&GetApiSpecContentsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -55,6 +63,10 @@ var GetApiSpecContentsCmd = &cobra.Command{
 				return err
 			}
 
+		} else {
+
+			GetApiSpecContentsInput.Format = rpcpb.GetApiSpecContentsRequest_ContentsFormat(rpcpb.GetApiSpecContentsRequest_ContentsFormat_value[strings.ToUpper(GetApiSpecContentsInputFormat)])
+
 		}
 
 		if Verbose {
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetApiSpecFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(GetApiSpecCmd)
 
+	GetApiSpecInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetApiSpecCmd.Flags().StringVar(&GetApiSpecInput.Name, "name", "", "Required. The name of the spec to retrieve. ...")
 
+	GetApiSpecCmd.Flags().StringSliceVar(&GetApiSpecInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetApiSpecCmd.Flags().StringVar(&GetApiSpecFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
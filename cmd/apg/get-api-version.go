@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetApiVersionFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(GetApiVersionCmd)
 
+	GetApiVersionInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetApiVersionCmd.Flags().StringVar(&GetApiVersionInput.Name, "name", "", "Required. The name of the version to retrieve. ...")
 
+	GetApiVersionCmd.Flags().StringSliceVar(&GetApiVersionInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetApiVersionCmd.Flags().StringVar(&GetApiVersionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetApiFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(GetApiCmd)
 
+	GetApiInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetApiCmd.Flags().StringVar(&GetApiInput.Name, "name", "", "Required. The name of the API to retrieve. ...")
 
+	GetApiCmd.Flags().StringSliceVar(&GetApiInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetApiCmd.Flags().StringVar(&GetApiFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetArtifactFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(GetArtifactCmd)
 
+	GetArtifactInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetArtifactCmd.Flags().StringVar(&GetArtifactInput.Name, "name", "", "Required. The name of the artifact to retrieve. ...")
 
+	GetArtifactCmd.Flags().StringSliceVar(&GetArtifactInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetArtifactCmd.Flags().StringVar(&GetArtifactFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -21,8 +23,12 @@ var GetProjectFromFile string
 func init() {
 	AdminServiceCmd.AddCommand(GetProjectCmd)
 
+	GetProjectInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	GetProjectCmd.Flags().StringVar(&GetProjectInput.Name, "name", "", "Required. The name of the project to retrieve.  Format:...")
 
+	GetProjectCmd.Flags().StringSliceVar(&GetProjectInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	GetProjectCmd.Flags().StringVar(&GetProjectFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,12 +25,16 @@ var ListApiDeploymentRevisionsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApiDeploymentRevisionsCmd)
 
+	ListApiDeploymentRevisionsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApiDeploymentRevisionsCmd.Flags().StringVar(&ListApiDeploymentRevisionsInput.Name, "name", "", "Required. The name of the deployment to list...")
 
 	ListApiDeploymentRevisionsCmd.Flags().Int32Var(&ListApiDeploymentRevisionsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of revisions to return per...")
 
 	ListApiDeploymentRevisionsCmd.Flags().StringVar(&ListApiDeploymentRevisionsInput.PageToken, "page_token", "", "The page token, received from a previous...")
 
+	ListApiDeploymentRevisionsCmd.Flags().StringSliceVar(&ListApiDeploymentRevisionsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApiDeploymentRevisionsCmd.Flags().StringVar(&ListApiDeploymentRevisionsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
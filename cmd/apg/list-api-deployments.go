@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,6 +25,8 @@ var ListApiDeploymentsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApiDeploymentsCmd)
 
+	ListApiDeploymentsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApiDeploymentsCmd.Flags().StringVar(&ListApiDeploymentsInput.Parent, "parent", "", "Required. The parent, which owns this collection...")
 
 	ListApiDeploymentsCmd.Flags().Int32Var(&ListApiDeploymentsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of deployments to return.  The...")
@@ -31,6 +35,8 @@ func init() {
 
 	ListApiDeploymentsCmd.Flags().StringVar(&ListApiDeploymentsInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListApiDeploymentsCmd.Flags().StringSliceVar(&ListApiDeploymentsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApiDeploymentsCmd.Flags().StringVar(&ListApiDeploymentsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,12 +25,16 @@ var ListApiSpecRevisionsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApiSpecRevisionsCmd)
 
+	ListApiSpecRevisionsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApiSpecRevisionsCmd.Flags().StringVar(&ListApiSpecRevisionsInput.Name, "name", "", "Required. The name of the spec to list revisions...")
 
 	ListApiSpecRevisionsCmd.Flags().Int32Var(&ListApiSpecRevisionsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of revisions to return per...")
 
 	ListApiSpecRevisionsCmd.Flags().StringVar(&ListApiSpecRevisionsInput.PageToken, "page_token", "", "The page token, received from a previous...")
 
+	ListApiSpecRevisionsCmd.Flags().StringSliceVar(&ListApiSpecRevisionsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApiSpecRevisionsCmd.Flags().StringVar(&ListApiSpecRevisionsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
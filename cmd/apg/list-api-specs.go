@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,6 +25,8 @@ var ListApiSpecsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApiSpecsCmd)
 
+	ListApiSpecsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApiSpecsCmd.Flags().StringVar(&ListApiSpecsInput.Parent, "parent", "", "Required. The parent, which owns this collection...")
 
 	ListApiSpecsCmd.Flags().Int32Var(&ListApiSpecsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of specs to return.  The...")
@@ -31,6 +35,8 @@ func init() {
 
 	ListApiSpecsCmd.Flags().StringVar(&ListApiSpecsInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListApiSpecsCmd.Flags().StringSliceVar(&ListApiSpecsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApiSpecsCmd.Flags().StringVar(&ListApiSpecsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
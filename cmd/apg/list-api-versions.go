@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,6 +25,8 @@ var ListApiVersionsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApiVersionsCmd)
 
+	ListApiVersionsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApiVersionsCmd.Flags().StringVar(&ListApiVersionsInput.Parent, "parent", "", "Required. The parent, which owns this collection...")
 
 	ListApiVersionsCmd.Flags().Int32Var(&ListApiVersionsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of versions to return.  The...")
@@ -31,6 +35,8 @@ func init() {
 
 	ListApiVersionsCmd.Flags().StringVar(&ListApiVersionsInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListApiVersionsCmd.Flags().StringSliceVar(&ListApiVersionsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApiVersionsCmd.Flags().StringVar(&ListApiVersionsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,6 +25,8 @@ var ListApisFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListApisCmd)
 
+	ListApisInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListApisCmd.Flags().StringVar(&ListApisInput.Parent, "parent", "", "Required. The parent, which owns this collection...")
 
 	ListApisCmd.Flags().Int32Var(&ListApisInput.PageSize, "page_size", 10, "Default is 10. The maximum number of APIs to return.  The...")
@@ -31,6 +35,8 @@ func init() {
 
 	ListApisCmd.Flags().StringVar(&ListApisInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListApisCmd.Flags().StringSliceVar(&ListApisInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListApisCmd.Flags().StringVar(&ListApisFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,6 +25,8 @@ var ListArtifactsFromFile string
 func init() {
 	RegistryServiceCmd.AddCommand(ListArtifactsCmd)
 
+	ListArtifactsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListArtifactsCmd.Flags().StringVar(&ListArtifactsInput.Parent, "parent", "", "Required. The parent, which owns this collection...")
 
 	ListArtifactsCmd.Flags().Int32Var(&ListArtifactsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of artifacts to return.  The...")
@@ -31,6 +35,8 @@ func init() {
 
 	ListArtifactsCmd.Flags().StringVar(&ListArtifactsInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListArtifactsCmd.Flags().StringSliceVar(&ListArtifactsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListArtifactsCmd.Flags().StringVar(&ListArtifactsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
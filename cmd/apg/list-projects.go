@@ -5,6 +5,8 @@ package main
 import (
 	"github.com/spf13/cobra"
 
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+
 	"fmt"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -23,12 +25,16 @@ var ListProjectsFromFile string
 func init() {
 	AdminServiceCmd.AddCommand(ListProjectsCmd)
 
+	ListProjectsInput.ReadMask = new(fieldmaskpb.FieldMask)
+
 	ListProjectsCmd.Flags().Int32Var(&ListProjectsInput.PageSize, "page_size", 10, "Default is 10. The maximum number of projects to return.  The...")
 
 	ListProjectsCmd.Flags().StringVar(&ListProjectsInput.PageToken, "page_token", "", "A page token, received from a previous...")
 
 	ListProjectsCmd.Flags().StringVar(&ListProjectsInput.Filter, "filter", "", "An expression that can be used to filter the...")
 
+	ListProjectsCmd.Flags().StringSliceVar(&ListProjectsInput.ReadMask.Paths, "read_mask.paths", []string{}, "The set of field mask paths.")
+
 	ListProjectsCmd.Flags().StringVar(&ListProjectsFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
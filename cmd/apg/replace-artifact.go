@@ -29,6 +29,8 @@ func init() {
 
 	ReplaceArtifactCmd.Flags().BytesHexVar(&ReplaceArtifactInput.Artifact.Contents, "artifact.contents", []byte{}, "Input only. The contents of the artifact. ...")
 
+	ReplaceArtifactCmd.Flags().StringVar(&ReplaceArtifactInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	ReplaceArtifactCmd.Flags().StringVar(&ReplaceArtifactFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
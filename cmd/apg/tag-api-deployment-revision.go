@@ -25,6 +25,12 @@ func init() {
 
 	TagApiDeploymentRevisionCmd.Flags().StringVar(&TagApiDeploymentRevisionInput.Tag, "tag", "", "Required. The tag to apply.  The tag should be at...")
 
+	TagApiDeploymentRevisionCmd.Flags().StringVar(&TagApiDeploymentRevisionInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
+	TagApiDeploymentRevisionCmd.Flags().BoolVar(&TagApiDeploymentRevisionInput.Protected, "protected", false, "If set, the tag is protected. Protected tags...")
+
+	TagApiDeploymentRevisionCmd.Flags().BoolVar(&TagApiDeploymentRevisionInput.Force, "force", false, "If set, a protected tag can be moved or...")
+
 	TagApiDeploymentRevisionCmd.Flags().StringVar(&TagApiDeploymentRevisionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -32,7 +38,7 @@ func init() {
 var TagApiDeploymentRevisionCmd = &cobra.Command{
 	Use:   "tag-api-deployment-revision",
 	Short: "TagApiDeploymentRevision adds a tag to a...",
-	Long:  "TagApiDeploymentRevision adds a tag to a specified revision of a  deployment.",
+	Long:  "TagApiDeploymentRevision adds a tag to a specified revision of a  deployment. If the tag is on another revision of the deployment, it is  moved.",
 	PreRun: func(cmd *cobra.Command, args []string) {
 
 		if TagApiDeploymentRevisionFromFile == "" {
@@ -25,6 +25,12 @@ func init() {
 
 	TagApiSpecRevisionCmd.Flags().StringVar(&TagApiSpecRevisionInput.Tag, "tag", "", "Required. The tag to apply.  The tag should be at...")
 
+	TagApiSpecRevisionCmd.Flags().StringVar(&TagApiSpecRevisionInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
+	TagApiSpecRevisionCmd.Flags().BoolVar(&TagApiSpecRevisionInput.Protected, "protected", false, "If set, the tag is protected. Protected tags...")
+
+	TagApiSpecRevisionCmd.Flags().BoolVar(&TagApiSpecRevisionInput.Force, "force", false, "If set, a protected tag can be moved or...")
+
 	TagApiSpecRevisionCmd.Flags().StringVar(&TagApiSpecRevisionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -32,7 +38,7 @@ func init() {
 var TagApiSpecRevisionCmd = &cobra.Command{
 	Use:   "tag-api-spec-revision",
 	Short: "TagApiSpecRevision adds a tag to a specified...",
-	Long:  "TagApiSpecRevision adds a tag to a specified revision of a spec.",
+	Long:  "TagApiSpecRevision adds a tag to a specified revision of a spec.  If the tag is on another revision of the spec, it is moved.",
 	PreRun: func(cmd *cobra.Command, args []string) {
 
 		if TagApiSpecRevisionFromFile == "" {
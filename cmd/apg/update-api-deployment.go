@@ -57,6 +57,8 @@ func init() {
 
 	UpdateApiDeploymentCmd.Flags().BoolVar(&UpdateApiDeploymentInput.AllowMissing, "allow_missing", false, "If set to true, and the deployment is not found,...")
 
+	UpdateApiDeploymentCmd.Flags().StringVar(&UpdateApiDeploymentInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	UpdateApiDeploymentCmd.Flags().StringVar(&UpdateApiDeploymentFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
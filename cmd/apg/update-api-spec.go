@@ -53,6 +53,8 @@ func init() {
 
 	UpdateApiSpecCmd.Flags().BoolVar(&UpdateApiSpecInput.AllowMissing, "allow_missing", false, "If set to true, and the spec is not found, a new...")
 
+	UpdateApiSpecCmd.Flags().StringVar(&UpdateApiSpecInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	UpdateApiSpecCmd.Flags().StringVar(&UpdateApiSpecFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -49,6 +49,8 @@ func init() {
 
 	UpdateApiVersionCmd.Flags().BoolVar(&UpdateApiVersionInput.AllowMissing, "allow_missing", false, "If set to true, and the version is not found, a...")
 
+	UpdateApiVersionCmd.Flags().StringVar(&UpdateApiVersionInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	UpdateApiVersionCmd.Flags().StringVar(&UpdateApiVersionFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
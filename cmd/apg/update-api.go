@@ -53,6 +53,8 @@ func init() {
 
 	UpdateApiCmd.Flags().BoolVar(&UpdateApiInput.AllowMissing, "allow_missing", false, "If set to true, and the api is not found, a new...")
 
+	UpdateApiCmd.Flags().StringVar(&UpdateApiInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	UpdateApiCmd.Flags().StringVar(&UpdateApiFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
@@ -37,6 +37,8 @@ func init() {
 
 	UpdateProjectCmd.Flags().BoolVar(&UpdateProjectInput.AllowMissing, "allow_missing", false, "If set to true, and the project is not found, a...")
 
+	UpdateProjectCmd.Flags().StringVar(&UpdateProjectInput.RequestId, "request_id", "", "An optional identifier for this request, such as a...")
+
 	UpdateProjectCmd.Flags().StringVar(&UpdateProjectFromFile, "from_file", "", "Absolute path to JSON file containing request payload")
 
 }
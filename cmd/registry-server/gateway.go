@@ -0,0 +1,108 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/rs/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Headers sent by gRPC-Web and browser clients that are always allowed in cross-origin requests.
+var corsHeaders = []string{
+	"authorization",
+	"cache-control",
+	"content-type",
+	"content-transfer-encoding",
+	"grpc-timeout",
+	"keep-alive",
+	"user-agent",
+	"x-accept-content-transfer-encoding",
+	"x-accept-response-streaming",
+	"x-grpc-web",
+	"x-user-agent",
+}
+
+// dialGateway connects the HTTP gateway to the gRPC server at the given address.
+func dialGateway(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32)),
+	}, opts...)
+	return grpc.DialContext(ctx, address, opts...)
+}
+
+// newHTTPHandler returns a handler that serves HTTP/JSON requests using the google.api.http
+// bindings of the Registry and Admin services, and gRPC-Web requests using grpcServer.
+// HTTP/JSON requests are forwarded to the gRPC server over conn.
+func newHTTPHandler(ctx context.Context, conn *grpc.ClientConn, grpcServer *grpc.Server, conf CORSConfig) (http.Handler, error) {
+	// Bodies of google.api.HttpBody responses (like the results of :getContents) are returned as-is.
+	// All other messages are returned as JSON with the same options used by the Envoy transcoder.
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				Multiline:       true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		},
+	}))
+
+	if err := rpc.RegisterRegistryHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	if err := rpc.RegisterAdminHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+
+	allowed := func(origin string) bool {
+		for _, o := range conf.AllowedOrigins {
+			if o == "*" || o == origin {
+				return true
+			}
+		}
+		return false
+	}
+
+	headers := append(append([]string{}, corsHeaders...), conf.AllowedHeaders...)
+	web := grpcweb.WrapServer(grpcServer,
+		grpcweb.WithOriginFunc(allowed),
+		grpcweb.WithAllowedRequestHeaders(headers))
+
+	transcoder := cors.New(cors.Options{
+		AllowOriginFunc: allowed,
+		AllowedMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		AllowedHeaders:  headers,
+		ExposedHeaders:  []string{"grpc-status", "grpc-message"},
+		MaxAge:          int(conf.maxAge().Seconds()),
+	}).Handler(mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if web.IsGrpcWebRequest(r) || web.IsAcceptableGrpcCorsRequest(r) {
+			web.ServeHTTP(w, r)
+			return
+		}
+		transcoder.ServeHTTP(w, r)
+	}), nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry"
+	"google.golang.org/grpc"
+)
+
+// gatewayTestServer starts a registry server with the HTTP gateway and returns a client for the
+// gRPC server along with the URL of the HTTP server.
+func gatewayTestServer(t *testing.T, conf CORSConfig) (rpc.RegistryClient, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	registryServer, err := registry.New(registry.Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+	})
+	if err != nil {
+		t.Fatalf("Setup: Failed to create registry server: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Setup: Failed to create listener: %s", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	rpc.RegisterRegistryServer(grpcServer, registryServer)
+	rpc.RegisterAdminServer(grpcServer, registryServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := dialGateway(ctx, listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Setup: Failed to connect to gRPC server: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	handler, err := newHTTPHandler(ctx, conn, grpcServer, conf)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create HTTP handler: %s", err)
+	}
+
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+	return rpc.NewRegistryClient(conn), httpServer.URL
+}
+
+func TestGatewayJSON(t *testing.T) {
+	client, url := gatewayTestServer(t, CORSConfig{})
+
+	resp, err := http.Post(url+"/v1/projects?project_id=my-project", "application/json", strings.NewReader(`{"displayName": "My Project"}`))
+	if err != nil {
+		t.Fatalf("POST /v1/projects returned error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST /v1/projects returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	resp, err = http.Get(url + "/v1/projects/my-project")
+	if err != nil {
+		t.Fatalf("GET /v1/projects/my-project returned error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var project map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		t.Fatalf("GET /v1/projects/my-project returned invalid JSON: %s", err)
+	}
+	if project["name"] != "projects/my-project" || project["displayName"] != "My Project" {
+		t.Errorf("GET /v1/projects/my-project returned unexpected project %v", project)
+	}
+
+	resp, err = http.Get(url + "/v1/projects/missing")
+	if err != nil {
+		t.Fatalf("GET /v1/projects/missing returned error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /v1/projects/missing returned status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	ctx := context.Background()
+	if _, err := client.CreateApi(ctx, &rpc.CreateApiRequest{Parent: "projects/my-project/locations/global", ApiId: "a", Api: &rpc.Api{}}); err != nil {
+		t.Fatalf("Setup: CreateApi returned error: %s", err)
+	}
+	if _, err := client.CreateApiVersion(ctx, &rpc.CreateApiVersionRequest{Parent: "projects/my-project/locations/global/apis/a", ApiVersionId: "v", ApiVersion: &rpc.ApiVersion{}}); err != nil {
+		t.Fatalf("Setup: CreateApiVersion returned error: %s", err)
+	}
+	if _, err := client.CreateApiSpec(ctx, &rpc.CreateApiSpecRequest{
+		Parent:    "projects/my-project/locations/global/apis/a/versions/v",
+		ApiSpecId: "s",
+		ApiSpec:   &rpc.ApiSpec{MimeType: "text/plain", Contents: []byte("hello")},
+	}); err != nil {
+		t.Fatalf("Setup: CreateApiSpec returned error: %s", err)
+	}
+
+	resp, err = http.Get(url + "/v1/projects/my-project/locations/global/apis/a/versions/v/specs/s:getContents")
+	if err != nil {
+		t.Fatalf("GET :getContents returned error: %s", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "hello" {
+		t.Errorf("GET :getContents returned body %q, want %q", body, "hello")
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/plain" {
+		t.Errorf("GET :getContents returned Content-Type %q, want %q", ct, "text/plain")
+	}
+}
+
+func TestGatewayCORS(t *testing.T) {
+	_, url := gatewayTestServer(t, CORSConfig{AllowedOrigins: []string{"https://allowed.example.com"}})
+
+	tests := []struct {
+		desc   string
+		origin string
+		want   string
+	}{
+		{
+			desc:   "allowed origin",
+			origin: "https://allowed.example.com",
+			want:   "https://allowed.example.com",
+		},
+		{
+			desc:   "disallowed origin",
+			origin: "https://other.example.com",
+			want:   "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodOptions, url+"/v1/status", nil)
+			req.Header.Set("Origin", test.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("OPTIONS /v1/status returned error: %s", err)
+			}
+			defer resp.Body.Close()
+
+			if got := resp.Header.Get("Access-Control-Allow-Origin"); got != test.want {
+				t.Errorf("OPTIONS /v1/status returned Access-Control-Allow-Origin %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGatewayGRPCWeb(t *testing.T) {
+	_, url := gatewayTestServer(t, CORSConfig{})
+
+	// A gRPC-Web request body is a sequence of length-prefixed messages.
+	// Empty messages (like the GetStatus request) are encoded as a 5-byte header.
+	var frame bytes.Buffer
+	frame.WriteByte(0)
+	_ = binary.Write(&frame, binary.BigEndian, uint32(0))
+
+	req, _ := http.NewRequest(http.MethodPost, url+"/google.cloud.apigeeregistry.v1.Admin/GetStatus", &frame)
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gRPC-Web GetStatus returned error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("gRPC-Web GetStatus returned status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/grpc-web+proto" {
+		t.Errorf("gRPC-Web GetStatus returned Content-Type %q, want %q", ct, "application/grpc-web+proto")
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !bytes.Contains(body, []byte("grpc-status: 0")) {
+		t.Errorf("gRPC-Web GetStatus returned body %q, want trailers with grpc-status: 0", body)
+	}
+}
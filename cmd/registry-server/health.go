@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/metrics"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthChecker reports whether a server is able to handle requests.
+type healthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// Services whose serving status is reported by the health service.
+// The empty name represents the overall health of the server.
+var healthServices = []string{
+	"",
+	rpc.Registry_ServiceDesc.ServiceName,
+	rpc.Admin_ServiceDesc.ServiceName,
+}
+
+// checkHealth runs a single health check and updates the status of all services.
+func checkHealth(ctx context.Context, checker healthChecker, hs *health.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := checker.CheckHealth(ctx); err != nil {
+		log.FromContext(ctx).WithError(err).Warn("Health check failed.")
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range healthServices {
+		hs.SetServingStatus(service, status)
+	}
+}
+
+// watchHealth checks health at the configured interval until ctx is done.
+func watchHealth(ctx context.Context, checker healthChecker, hs *health.Server, conf HealthConfig) {
+	ticker := time.NewTicker(conf.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkHealth(ctx, checker, hs, conf.timeout())
+		}
+	}
+}
+
+// newMonitoringHandler returns a handler that serves Prometheus metrics along with
+// liveness (/healthz) and readiness (/readyz) endpoints for use by orchestrators.
+// Readiness is based on the most recent health check.
+func newMonitoringHandler(hs *health.Server, conf MetricsConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(conf.path(), metrics.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := hs.Check(r.Context(), &healthpb.HealthCheckRequest{})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/server/registry"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type fakeChecker struct {
+	err error
+}
+
+func (c fakeChecker) CheckHealth(ctx context.Context) error {
+	return c.err
+}
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		desc    string
+		checker healthChecker
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			desc:    "healthy",
+			checker: fakeChecker{},
+			want:    healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			desc:    "unhealthy",
+			checker: fakeChecker{err: errors.New("database unavailable")},
+			want:    healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			hs := health.NewServer()
+			checkHealth(ctx, test.checker, hs, time.Second)
+
+			for _, service := range healthServices {
+				req := &healthpb.HealthCheckRequest{Service: service}
+				resp, err := hs.Check(ctx, req)
+				if err != nil {
+					t.Fatalf("Check(%+v) returned error: %s", req, err)
+				}
+				if resp.GetStatus() != test.want {
+					t.Errorf("Check(%+v) returned status %s, want %s", req, resp.GetStatus(), test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckHealthDatabase(t *testing.T) {
+	ctx := context.Background()
+	registryServer, err := registry.New(registry.Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+	})
+	if err != nil {
+		t.Fatalf("Setup: Failed to create registry server: %s", err)
+	}
+
+	hs := health.NewServer()
+	checkHealth(ctx, registryServer, hs, time.Second)
+	resp, err := hs.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check() returned error: %s", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check() returned status %s, want %s", resp.GetStatus(), healthpb.HealthCheckResponse_SERVING)
+	}
+}
+
+func TestMonitoringHandler(t *testing.T) {
+	ctx := context.Background()
+	hs := health.NewServer()
+	server := httptest.NewServer(newMonitoringHandler(hs, MetricsConfig{}))
+	defer server.Close()
+
+	get := func(path string) int {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("Get(%q) returned error: %s", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	checkHealth(ctx, fakeChecker{}, hs, time.Second)
+	for _, path := range []string{"/metrics", "/healthz", "/readyz"} {
+		if code := get(path); code != http.StatusOK {
+			t.Errorf("Get(%q) returned status %d, want %d", path, code, http.StatusOK)
+		}
+	}
+
+	checkHealth(ctx, fakeChecker{err: errors.New("database unavailable")}, hs, time.Second)
+	if code := get("/healthz"); code != http.StatusOK {
+		t.Errorf("Get(%q) returned status %d, want %d", "/healthz", code, http.StatusOK)
+	}
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("Get(%q) returned status %d, want %d", "/readyz", code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// serverTLSConfig returns a TLS configuration for the server, or nil if TLS is disabled.
+// If a client CA is configured, clients must present certificates signed by it.
+func serverTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	if !conf.enabled() {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %s", err)
+	}
+	c := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if conf.ClientCAFile != "" {
+		pem, err := ioutil.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("failed to parse client CA %s", conf.ClientCAFile)
+		}
+		c.ClientCAs = pool
+		c.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return c, nil
+}
+
+// serverCredentials returns gRPC server options that enable TLS, if configured.
+func serverCredentials(conf TLSConfig) ([]grpc.ServerOption, error) {
+	c, err := serverTLSConfig(conf)
+	if err != nil || c == nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(c))}, nil
+}
+
+// serveHTTP serves HTTP requests on lis, using TLS if c is not nil.
+// Clients must present certificates if c requires them, as they must for gRPC requests.
+func serveHTTP(s *http.Server, lis net.Listener, c *tls.Config) error {
+	if c == nil {
+		return s.Serve(lis)
+	}
+	s.TLSConfig = c.Clone()
+	return s.ServeTLS(lis, "", "")
+}
+
+// listenUnix listens on a Unix domain socket at path.
+// A socket left behind by a previous server is removed first.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Stat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen("unix", path)
+}
+
+// gracefulStop stops the gRPC servers after pending RPCs complete and the HTTP servers
+// after active requests complete. HTTP servers are stopped first, since they may forward
+// requests to the gRPC servers. Any connections remaining when ctx is done are closed.
+func gracefulStop(ctx context.Context, httpServers []*http.Server, grpcServers []*grpc.Server) {
+	var wg sync.WaitGroup
+	for _, s := range httpServers {
+		wg.Add(1)
+		go func(s *http.Server) {
+			defer wg.Done()
+			if err := s.Shutdown(ctx); err != nil {
+				s.Close()
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	stopped := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, s := range grpcServers {
+			wg.Add(1)
+			go func(s *grpc.Server) {
+				defer wg.Done()
+				s.GracefulStop()
+			}(s)
+		}
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		for _, s := range grpcServers {
+			s.Stop()
+		}
+		<-stopped
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// testCert is a certificate and private key written to PEM files.
+type testCert struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+	keyFile  string
+}
+
+// newTestCert creates a certificate signed by parent, or a self-signed CA if parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Setup: Failed to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create certificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Setup: Failed to parse certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Setup: Failed to marshal key: %s", err)
+	}
+
+	dir := t.TempDir()
+	c := &testCert{
+		cert:     cert,
+		key:      key,
+		certFile: filepath.Join(dir, name+".crt"),
+		keyFile:  filepath.Join(dir, name+".key"),
+	}
+	if err := ioutil.WriteFile(c.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("Setup: Failed to write certificate: %s", err)
+	}
+	if err := ioutil.WriteFile(c.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Setup: Failed to write key: %s", err)
+	}
+	return c
+}
+
+// serveHealth serves a health service on lis using a gRPC server with the provided options.
+func serveHealth(t *testing.T, lis net.Listener, opts ...grpc.ServerOption) {
+	t.Helper()
+	s := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+}
+
+func checkServing(ctx context.Context, target string, opts ...grpc.DialOption) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, target, opts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestServerTLS(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server", ca)
+	client := newTestCert(t, "client", ca)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCert, err := tls.LoadX509KeyPair(client.certFile, client.keyFile)
+	if err != nil {
+		t.Fatalf("Setup: Failed to load client certificate: %s", err)
+	}
+
+	tests := []struct {
+		desc    string
+		conf    TLSConfig
+		client  *tls.Config
+		wantErr bool
+	}{
+		{
+			desc:   "tls",
+			conf:   TLSConfig{CertFile: server.certFile, KeyFile: server.keyFile},
+			client: &tls.Config{RootCAs: roots},
+		},
+		{
+			desc:   "mtls",
+			conf:   TLSConfig{CertFile: server.certFile, KeyFile: server.keyFile, ClientCAFile: ca.certFile},
+			client: &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+		},
+		{
+			desc:    "mtls without client certificate",
+			conf:    TLSConfig{CertFile: server.certFile, KeyFile: server.keyFile, ClientCAFile: ca.certFile},
+			client:  &tls.Config{RootCAs: roots},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			opts, err := serverCredentials(test.conf)
+			if err != nil {
+				t.Fatalf("serverCredentials(%+v) returned error: %s", test.conf, err)
+			}
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("Setup: Failed to create listener: %s", err)
+			}
+			serveHealth(t, lis, opts...)
+
+			err = checkServing(context.Background(), lis.Addr().String(),
+				grpc.WithTransportCredentials(credentials.NewTLS(test.client)))
+			if test.wantErr && err == nil {
+				t.Errorf("Check() succeeded, expected client to be rejected")
+			} else if !test.wantErr && err != nil {
+				t.Errorf("Check() returned error: %s", err)
+			}
+		})
+	}
+}
+
+func TestServerTLSDisabled(t *testing.T) {
+	opts, err := serverCredentials(TLSConfig{})
+	if err != nil {
+		t.Fatalf("serverCredentials() returned error: %s", err)
+	}
+	if len(opts) != 0 {
+		t.Errorf("serverCredentials() returned %d options, want none", len(opts))
+	}
+}
+
+func TestServeHTTPTLS(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "server", ca)
+	client := newTestCert(t, "client", ca)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCert, err := tls.LoadX509KeyPair(client.certFile, client.keyFile)
+	if err != nil {
+		t.Fatalf("Setup: Failed to load client certificate: %s", err)
+	}
+
+	conf := TLSConfig{CertFile: server.certFile, KeyFile: server.keyFile, ClientCAFile: ca.certFile}
+	c, err := serverTLSConfig(conf)
+	if err != nil {
+		t.Fatalf("serverTLSConfig(%+v) returned error: %s", conf, err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Setup: Failed to create listener: %s", err)
+	}
+	s := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	go func() {
+		_ = serveHTTP(s, lis, c)
+	}()
+	t.Cleanup(func() { s.Close() })
+
+	url := "https://" + lis.Addr().String()
+	tests := []struct {
+		desc    string
+		client  *tls.Config
+		wantErr bool
+	}{
+		{
+			desc:   "mtls",
+			client: &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCert}},
+		},
+		{
+			desc:    "mtls without client certificate",
+			client:  &tls.Config{RootCAs: roots},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			c := &http.Client{Transport: &http.Transport{TLSClientConfig: test.client}}
+			resp, err := c.Get(url)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if test.wantErr && err == nil {
+				t.Errorf("Get(%q) succeeded, expected client to be rejected", url)
+			} else if !test.wantErr && err != nil {
+				t.Errorf("Get(%q) returned error: %s", url, err)
+			}
+		})
+	}
+
+	// Plaintext requests are rejected when TLS is configured.
+	if resp, err := http.Get("http://" + lis.Addr().String()); err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Errorf("Get(%q) succeeded without TLS", "http://"+lis.Addr().String())
+		}
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "registry.sock")
+
+	// Leave behind a socket as if a previous server had crashed.
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create socket: %s", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	lis, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix(%q) returned error: %s", path, err)
+	}
+	serveHealth(t, lis)
+	if err := checkServing(context.Background(), "unix:"+path, grpc.WithInsecure()); err != nil {
+		t.Errorf("Check() returned error: %s", err)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := ioutil.WriteFile(file, nil, 0600); err != nil {
+		t.Fatalf("Setup: Failed to create file: %s", err)
+	}
+	if _, err := listenUnix(file); err == nil {
+		t.Errorf("listenUnix(%q) succeeded, expected error for existing file", file)
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("listenUnix(%q) removed an existing file", file)
+	}
+}
+
+func TestGracefulStop(t *testing.T) {
+	registryServer, err := registry.New(registry.Config{
+		Database: "sqlite3",
+		DBConfig: fmt.Sprintf("%s/registry.db", t.TempDir()),
+	})
+	if err != nil {
+		t.Fatalf("Setup: Failed to create registry server: %s", err)
+	}
+
+	// Delay each request so that it is in flight when shutdown begins.
+	started := make(chan struct{}, 1)
+	delay := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		started <- struct{}{}
+		time.Sleep(200 * time.Millisecond)
+		return handler(ctx, req)
+	}
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Setup: Failed to create listener: %s", err)
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(delay))
+	rpc.RegisterAdminServer(s, registryServer)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Setup: Failed to connect: %s", err)
+	}
+	defer conn.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := rpc.NewAdminClient(conn).GetStatus(context.Background(), &emptypb.Empty{})
+		errs <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	gracefulStop(ctx, nil, []*grpc.Server{s})
+
+	if err := <-errs; err != nil {
+		t.Errorf("GetStatus() returned error after graceful stop: %s", err)
+	}
+}
+
+func TestGracefulStopDeadline(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("Setup: Failed to create listener: %s", err)
+	}
+	s := grpc.NewServer()
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Setup: Failed to connect: %s", err)
+	}
+	defer conn.Close()
+
+	// Watch streams never complete on their own, so only the deadline can stop the server.
+	stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Setup: Watch() returned error: %s", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Setup: Recv() returned error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	gracefulStop(ctx, nil, []*grpc.Server{s})
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("gracefulStop() took %s, expected it to stop at the deadline", elapsed)
+	}
+	if _, err := stream.Recv(); err == nil {
+		t.Errorf("Recv() succeeded after forced stop, expected stream to be closed")
+	}
+}
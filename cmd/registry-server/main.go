@@ -15,30 +15,80 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/log/interceptor"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/metrics"
+	"github.com/apigee/registry/server/ratelimit"
 	"github.com/apigee/registry/server/registry"
+	"github.com/apigee/registry/tracing"
 	"github.com/spf13/pflag"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 	"gopkg.in/yaml.v2"
 )
 
 // ServerConfig is the top-level configuration structure.
 type ServerConfig struct {
 	// Server port. If unset or zero, an open port will be assigned.
-	Port     int            `yaml:"port"`
-	Database DatabaseConfig `yaml:"database"`
-	Logging  LoggingConfig  `yaml:"logging"`
-	Pubsub   PubsubConfig   `yaml:"pubsub"`
+	Port int `yaml:"port"`
+	// Path of a Unix domain socket where the server will also listen, for use by
+	// sidecars and other local clients. Connections over the socket don't use TLS.
+	Socket string `yaml:"socket"`
+	// Number of seconds to remember request IDs, during which retried requests
+	// return their original response. If unset or zero, a default of one hour is used.
+	RequestIDWindow int `yaml:"request_id_window"`
+	// Number of seconds to wait for in-flight requests to complete when shutting down.
+	// If unset or zero, a default of 30 seconds is used.
+	ShutdownTimeout int               `yaml:"shutdown_timeout"`
+	TLS             TLSConfig         `yaml:"tls"`
+	Database        DatabaseConfig    `yaml:"database"`
+	Logging         LoggingConfig     `yaml:"logging"`
+	Pubsub          PubsubConfig      `yaml:"pubsub"`
+	HTTP            HTTPConfig        `yaml:"http"`
+	Health          HealthConfig      `yaml:"health"`
+	Metrics         MetricsConfig     `yaml:"metrics"`
+	Tracing         TracingConfig     `yaml:"tracing"`
+	RateLimits      RateLimitConfig   `yaml:"rate_limits"`
+	Quotas          QuotaConfig       `yaml:"quotas"`
+	Maintenance     MaintenanceConfig `yaml:"maintenance"`
+}
+
+func (c ServerConfig) shutdownTimeout() time.Duration {
+	if c.ShutdownTimeout == 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+// TLSConfig holds configuration for serving gRPC and HTTP requests over TLS.
+type TLSConfig struct {
+	// Path of a PEM-encoded certificate chain for the server.
+	// If unset, requests are served without TLS.
+	CertFile string `yaml:"cert_file"`
+	// Path of the PEM-encoded private key for the server certificate.
+	KeyFile string `yaml:"key_file"`
+	// Path of PEM-encoded CA certificates used to verify client certificates.
+	// If set, clients must present a certificate signed by one of these CAs (mutual TLS).
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+func (c TLSConfig) enabled() bool {
+	return c.CertFile != ""
 }
 
 // DatabaseConfig holds database configuration.
@@ -72,6 +122,236 @@ type PubsubConfig struct {
 	Project string `yaml:"project"`
 }
 
+// HTTPConfig holds configuration for serving HTTP/JSON and gRPC-Web requests.
+type HTTPConfig struct {
+	// Enable serving HTTP/JSON and gRPC-Web requests on a separate port.
+	// HTTP/JSON requests are transcoded using the google.api.http annotations of each RPC.
+	// Values: [ true, false ]
+	Enable bool `yaml:"enable"`
+	// Port where HTTP requests will be served.
+	// If unset or zero, an open port will be assigned.
+	Port int `yaml:"port"`
+	// CORS configuration for requests from browser apps.
+	CORS CORSConfig `yaml:"cors"`
+}
+
+// CORSConfig holds cross-origin resource sharing configuration.
+type CORSConfig struct {
+	// Origins that are allowed to make cross-origin requests.
+	// Use "*" to allow all origins. If empty, cross-origin requests are not allowed.
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// Headers that are allowed in cross-origin requests in addition to those
+	// used by gRPC-Web clients (like authorization and content-type).
+	AllowedHeaders []string `yaml:"allowed_headers"`
+	// Number of seconds that browsers may cache the results of HTTP/JSON preflight requests.
+	// If unset or zero, a default of 10 minutes is used, which is also used for gRPC-Web.
+	MaxAge int `yaml:"max_age"`
+}
+
+func (c CORSConfig) maxAge() time.Duration {
+	if c.MaxAge == 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(c.MaxAge) * time.Second
+}
+
+// HealthConfig holds configuration for the grpc.health.v1 service.
+type HealthConfig struct {
+	// Number of seconds between database connectivity checks.
+	// If unset or zero, a default of 10 seconds is used.
+	Interval int `yaml:"interval"`
+	// Number of seconds to wait for a database connectivity check before reporting the server as not serving.
+	// If unset or zero, a default of 5 seconds is used.
+	Timeout int `yaml:"timeout"`
+}
+
+func (c HealthConfig) interval() time.Duration {
+	if c.Interval == 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Interval) * time.Second
+}
+
+func (c HealthConfig) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// MetricsConfig holds configuration for serving Prometheus metrics.
+type MetricsConfig struct {
+	// Enable collecting RPC metrics and serving metrics, liveness (/healthz) and readiness (/readyz)
+	// endpoints on a separate port.
+	// Values: [ true, false ]
+	Enable bool `yaml:"enable"`
+	// Port where metrics and health endpoints will be served.
+	// If unset or zero, an open port will be assigned.
+	Port int `yaml:"port"`
+	// Path where metrics will be served. If unset, "/metrics" is used.
+	Path string `yaml:"path"`
+}
+
+func (c MetricsConfig) path() string {
+	if c.Path == "" {
+		return "/metrics"
+	}
+	return c.Path
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration.
+type TracingConfig struct {
+	// Exporter for spans. If unset, spans are not exported,
+	// but trace context is still propagated.
+	// Values: [ none, otlp, file ]
+	Exporter string `yaml:"exporter"`
+	// Address of the OTLP collector used by the otlp exporter.
+	// If unset, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used.
+	Endpoint string `yaml:"endpoint"`
+	// Disable transport security for connections to the OTLP collector.
+	// Values: [ true, false ]
+	Insecure bool `yaml:"insecure"`
+	// Path of the file where the file exporter writes spans as JSON lines.
+	File string `yaml:"file"`
+	// Fraction of new traces to record, between 0 and 1.
+	// If unset or zero, all traces are recorded.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+func (c TracingConfig) tracingConfig() tracing.Config {
+	return tracing.Config{
+		Exporter:    c.Exporter,
+		Endpoint:    c.Endpoint,
+		Insecure:    c.Insecure,
+		File:        c.File,
+		SampleRatio: c.SampleRatio,
+		ServiceName: "registry-server",
+	}
+}
+
+// RateLimitConfig holds configuration for limiting the rate of requests from each caller.
+// Callers are identified by verified TLS client certificate ("cert:<common name>") or by
+// address ("ip:<address>"). Rejected requests
+// return RESOURCE_EXHAUSTED with the delay to wait before retrying.
+type RateLimitConfig struct {
+	// Limit applied to each caller across all methods. If unset, callers are not limited.
+	PerCaller RateLimit `yaml:"per_caller"`
+	// Limits that replace per_caller for specific callers, keyed by caller identity.
+	Callers map[string]RateLimit `yaml:"callers"`
+	// Limits applied to each caller for specific methods, keyed by method name (like "CreateApiSpec").
+	Methods map[string]RateLimit `yaml:"methods"`
+}
+
+// RateLimit is a sustained rate of requests along with the size of allowed bursts.
+type RateLimit struct {
+	// Number of requests allowed per second. If unset or zero, requests are unlimited.
+	Rate float64 `yaml:"rate"`
+	// Number of requests that can be made at once.
+	// If unset or zero, the rate (rounded up) is used.
+	Burst int `yaml:"burst"`
+}
+
+func (c RateLimitConfig) enabled() bool {
+	return c.PerCaller.Rate > 0 || len(c.Callers) > 0 || len(c.Methods) > 0
+}
+
+func (c RateLimitConfig) limiterConfig() ratelimit.Config {
+	convert := func(m map[string]RateLimit) map[string]ratelimit.Limit {
+		limits := make(map[string]ratelimit.Limit, len(m))
+		for k, v := range m {
+			limits[k] = ratelimit.Limit(v)
+		}
+		return limits
+	}
+	return ratelimit.Config{
+		PerCaller: ratelimit.Limit(c.PerCaller),
+		Callers:   convert(c.Callers),
+		Methods:   convert(c.Methods),
+	}
+}
+
+func (c RateLimitConfig) validate() error {
+	check := func(name string, l RateLimit) error {
+		if l.Rate < 0 || l.Burst < 0 {
+			return fmt.Errorf("invalid %s: rate and burst must be non-negative", name)
+		}
+		return nil
+	}
+	if err := check("rate_limits.per_caller", c.PerCaller); err != nil {
+		return err
+	}
+	for k, v := range c.Callers {
+		if err := check(fmt.Sprintf("rate_limits.callers[%q]", k), v); err != nil {
+			return err
+		}
+	}
+	for k, v := range c.Methods {
+		if err := check(fmt.Sprintf("rate_limits.methods[%q]", k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaintenanceConfig holds configuration for read-only maintenance mode.
+// The mode can also be changed while the server is running with the SetMaintenanceMode RPC.
+type MaintenanceConfig struct {
+	// Start the server in read-only mode, in which requests that would change
+	// stored resources return UNAVAILABLE.
+	ReadOnly bool `yaml:"read_only"`
+	// Message returned by requests that are rejected in read-only mode.
+	// If unset, a default message is used.
+	Message string `yaml:"message"`
+}
+
+// QuotaConfig holds configuration for limiting the resources stored in each project.
+// Requests that would exceed a quota return RESOURCE_EXHAUSTED.
+type QuotaConfig struct {
+	// Limits for projects that aren't listed in projects.
+	Default QuotaLimits `yaml:"default"`
+	// Limits that replace the default limits for specific projects, keyed by project ID.
+	Projects map[string]QuotaLimits `yaml:"projects"`
+}
+
+// QuotaLimits limit the resources stored in a project. Unset or zero values are unlimited.
+type QuotaLimits struct {
+	// Maximum number of APIs in the project.
+	MaxApis int64 `yaml:"max_apis"`
+	// Maximum number of revisions of all specs in the project.
+	MaxSpecRevisions int64 `yaml:"max_spec_revisions"`
+	// Maximum total size in bytes of all spec and artifact contents in the project.
+	MaxBlobBytes int64 `yaml:"max_blob_bytes"`
+}
+
+func (c QuotaConfig) quotas() registry.Quotas {
+	projects := make(map[string]registry.QuotaLimits, len(c.Projects))
+	for k, v := range c.Projects {
+		projects[k] = registry.QuotaLimits(v)
+	}
+	return registry.Quotas{
+		Default:  registry.QuotaLimits(c.Default),
+		Projects: projects,
+	}
+}
+
+func (c QuotaConfig) validate() error {
+	check := func(name string, l QuotaLimits) error {
+		if l.MaxApis < 0 || l.MaxSpecRevisions < 0 || l.MaxBlobBytes < 0 {
+			return fmt.Errorf("invalid %s: limits must be non-negative", name)
+		}
+		return nil
+	}
+	if err := check("quotas.default", c.Default); err != nil {
+		return err
+	}
+	for k, v := range c.Projects {
+		if err := check(fmt.Sprintf("quotas.projects[%q]", k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // default configuration
 var config = ServerConfig{
 	Port: 8080,
@@ -87,9 +367,25 @@ var config = ServerConfig{
 		Enable:  false,
 		Project: "",
 	},
+	HTTP: HTTPConfig{
+		Enable: false,
+		Port:   8081,
+	},
+	Metrics: MetricsConfig{
+		Enable: false,
+		Port:   9090,
+		Path:   "/metrics",
+	},
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate-data" {
+		if err := migrateData(os.Args[2:]); err != nil {
+			log.NewLogger().WithError(err).Fatal("Failed to migrate data")
+		}
+		return
+	}
+
 	var configPath string
 	pflag.StringVarP(&configPath, "configuration", "c", "", "The server configuration file to load.")
 	pflag.Parse()
@@ -119,8 +415,19 @@ func main() {
 		logOpts        = loggerOptions(config.Logging)
 		logger         = log.NewLogger(logOpts...)
 		logInterceptor = interceptor.CallLogger(logOpts...)
+		logStreams     = interceptor.StreamCallLogger(logOpts...)
 	)
 
+	shutdownTracing, err := tracing.Setup(context.Background(), config.Tracing.tracingConfig())
+	if err != nil {
+		logger.WithError(err).Fatalf("Failed to set up tracing")
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.WithError(err).Error("Failed to flush traces")
+		}
+	}()
+
 	logger.Infof("Configured port %d", config.Port)
 	listener, err := net.ListenTCP("tcp", &net.TCPAddr{
 		Port: config.Port,
@@ -130,32 +437,156 @@ func main() {
 	}
 	defer listener.Close()
 
+	tlsOpts, err := serverCredentials(config.TLS)
+	if err != nil {
+		logger.WithError(err).Fatalf("Failed to configure TLS")
+	}
+	tlsConfig, err := serverTLSConfig(config.TLS)
+	if err != nil {
+		logger.WithError(err).Fatalf("Failed to configure TLS")
+	}
+
 	registryServer, err := registry.New(registry.Config{
-		Database:  config.Database.Driver,
-		DBConfig:  config.Database.Config,
-		LogLevel:  config.Logging.Level,
-		LogFormat: config.Logging.Format,
-		Notify:    config.Pubsub.Enable,
-		ProjectID: config.Pubsub.Project,
+		Database:           config.Database.Driver,
+		DBConfig:           config.Database.Config,
+		LogLevel:           config.Logging.Level,
+		LogFormat:          config.Logging.Format,
+		Notify:             config.Pubsub.Enable,
+		ProjectID:          config.Pubsub.Project,
+		Quotas:             config.Quotas.quotas(),
+		RequestIDWindow:    time.Duration(config.RequestIDWindow) * time.Second,
+		ReadOnly:           config.Maintenance.ReadOnly,
+		MaintenanceMessage: config.Maintenance.Message,
 	})
 	if err != nil {
 		logger.WithError(err).Fatalf("Failed to create registry server")
 	}
 
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(logInterceptor))
-	reflection.Register(grpcServer)
-	rpc.RegisterRegistryServer(grpcServer, registryServer)
-	rpc.RegisterAdminServer(grpcServer, registryServer)
+	unaryInterceptors := []grpc.UnaryServerInterceptor{interceptor.CallTracer(), logInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{interceptor.StreamCallTracer(), logStreams}
+	if config.Metrics.Enable {
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, metrics.StreamServerInterceptor())
+	}
+	if config.RateLimits.enabled() {
+		limiter := ratelimit.New(config.RateLimits.limiterConfig())
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+	}
+	unaryInterceptors = append(unaryInterceptors, registryServer.ReadOnlyInterceptor(), registryServer.RequestIDInterceptor())
+	streamInterceptors = append(streamInterceptors, registryServer.ReadOnlyStreamInterceptor())
 
+	ctx := log.NewContext(context.Background(), logger)
+	healthServer := health.NewServer()
+	checkHealth(ctx, registryServer, healthServer, config.Health.timeout())
+	go watchHealth(ctx, registryServer, healthServer, config.Health)
+
+	// All gRPC servers handle the same services, but only the server on the
+	// configured port uses TLS. Local connections from the Unix socket and the
+	// HTTP gateway are served by a separate server without TLS. The HTTP port
+	// itself is served with the same TLS configuration as the main port.
+	newGRPCServer := func(opts ...grpc.ServerOption) *grpc.Server {
+		opts = append(opts, grpc.ChainUnaryInterceptor(unaryInterceptors...), grpc.ChainStreamInterceptor(streamInterceptors...))
+		s := grpc.NewServer(opts...)
+		reflection.Register(s)
+		rpc.RegisterRegistryServer(s, registryServer)
+		rpc.RegisterAdminServer(s, registryServer)
+		healthpb.RegisterHealthServer(s, healthServer)
+		return s
+	}
+
+	grpcServer := newGRPCServer(tlsOpts...)
 	go func() {
 		_ = grpcServer.Serve(listener)
 	}()
-	logger.Infof("Listening on %s", listener.Addr())
+	logger.Infof("Listening on %s (TLS: %t, mTLS: %t)", listener.Addr(), config.TLS.enabled(), config.TLS.ClientCAFile != "")
+	grpcServers := []*grpc.Server{grpcServer}
+
+	var localServer *grpc.Server
+	if config.Socket != "" || config.HTTP.Enable {
+		localServer = newGRPCServer()
+		grpcServers = append(grpcServers, localServer)
+	}
+
+	if config.Socket != "" {
+		socketListener, err := listenUnix(config.Socket)
+		if err != nil {
+			logger.WithError(err).Fatalf("Failed to create Unix socket listener")
+		}
+		defer socketListener.Close()
+
+		go func() {
+			_ = localServer.Serve(socketListener)
+		}()
+		logger.Infof("Listening on unix:%s", config.Socket)
+	}
+
+	var httpServers []*http.Server
+	if config.HTTP.Enable {
+		logger.Infof("Configured HTTP port %d", config.HTTP.Port)
+		httpListener, err := net.ListenTCP("tcp", &net.TCPAddr{
+			Port: config.HTTP.Port,
+		})
+		if err != nil {
+			logger.WithError(err).Fatalf("Failed to create HTTP listener")
+		}
+		defer httpListener.Close()
+
+		// The gateway connects to the local server over an in-memory listener.
+		gatewayListener := bufconn.Listen(1 << 20)
+		go func() {
+			_ = localServer.Serve(gatewayListener)
+		}()
+		conn, err := dialGateway(ctx, "bufconn", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return gatewayListener.DialContext(ctx)
+		}))
+		if err != nil {
+			logger.WithError(err).Fatalf("Failed to connect HTTP gateway")
+		}
+		defer conn.Close()
+
+		handler, err := newHTTPHandler(ctx, conn, localServer, config.HTTP.CORS)
+		if err != nil {
+			logger.WithError(err).Fatalf("Failed to create HTTP gateway")
+		}
+
+		httpServer := &http.Server{Handler: handler}
+		httpServers = append(httpServers, httpServer)
+		go func() {
+			_ = serveHTTP(httpServer, httpListener, tlsConfig)
+		}()
+		logger.Infof("Listening for HTTP on %s (TLS: %t, mTLS: %t)", httpListener.Addr(), config.TLS.enabled(), config.TLS.ClientCAFile != "")
+	}
+
+	if config.Metrics.Enable {
+		logger.Infof("Configured metrics port %d", config.Metrics.Port)
+		metricsListener, err := net.ListenTCP("tcp", &net.TCPAddr{
+			Port: config.Metrics.Port,
+		})
+		if err != nil {
+			logger.WithError(err).Fatalf("Failed to create metrics listener")
+		}
+		defer metricsListener.Close()
+
+		go func() {
+			_ = http.Serve(metricsListener, newMonitoringHandler(healthServer, config.Metrics))
+		}()
+		logger.Infof("Serving metrics on %s%s", metricsListener.Addr(), config.Metrics.path())
+	}
 
 	// Wait for an interruption signal.
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGTERM)
 	<-done
+
+	// Report that the server is no longer serving so that load balancers stop sending
+	// new requests, then let in-flight requests finish before exiting.
+	logger.Infof("Shutting down, waiting up to %s for in-flight requests", config.shutdownTimeout())
+	healthServer.Shutdown()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.shutdownTimeout())
+	defer cancel()
+	gracefulStop(shutdownCtx, httpServers, grpcServers)
+	logger.Info("Shutdown complete")
 }
 
 func validateConfig() error {
@@ -181,6 +612,56 @@ func validateConfig() error {
 		return fmt.Errorf("invalid logging format %q: must be one of [json, text]", format)
 	}
 
+	if config.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid shutdown_timeout %q: must be non-negative", config.ShutdownTimeout)
+	}
+
+	if config.RequestIDWindow < 0 {
+		return fmt.Errorf("invalid request_id_window %q: must be non-negative", config.RequestIDWindow)
+	}
+
+	if tls := config.TLS; (tls.CertFile == "") != (tls.KeyFile == "") {
+		return fmt.Errorf("invalid tls: cert_file and key_file must be set together")
+	} else if tls.ClientCAFile != "" && tls.CertFile == "" {
+		return fmt.Errorf("invalid tls.client_ca_file %q: mutual TLS requires cert_file and key_file", tls.ClientCAFile)
+	}
+
+	if config.HTTP.Port < 0 {
+		return fmt.Errorf("invalid http.port %q: must be non-negative", config.HTTP.Port)
+	}
+
+	if config.Health.Interval < 0 {
+		return fmt.Errorf("invalid health.interval %q: must be non-negative", config.Health.Interval)
+	}
+
+	if config.Health.Timeout < 0 {
+		return fmt.Errorf("invalid health.timeout %q: must be non-negative", config.Health.Timeout)
+	}
+
+	if config.Metrics.Port < 0 {
+		return fmt.Errorf("invalid metrics.port %q: must be non-negative", config.Metrics.Port)
+	}
+
+	if path := config.Metrics.Path; path != "" && !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("invalid metrics.path %q: must begin with \"/\"", path)
+	}
+
+	if err := config.Tracing.tracingConfig().Validate(); err != nil {
+		return fmt.Errorf("invalid tracing configuration: %s", err)
+	}
+
+	if err := config.RateLimits.validate(); err != nil {
+		return err
+	}
+
+	if err := config.Quotas.validate(); err != nil {
+		return err
+	}
+
+	if config.HTTP.CORS.MaxAge < 0 {
+		return fmt.Errorf("invalid http.cors.max_age %q: must be non-negative", config.HTTP.CORS.MaxAge)
+	}
+
 	if project := config.Pubsub.Project; config.Pubsub.Enable && project == "" {
 		return fmt.Errorf("invalid pubsub.project %q: pubsub cannot be enabled without GCP project ID", project)
 	}
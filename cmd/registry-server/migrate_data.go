@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry"
+	"github.com/spf13/pflag"
+)
+
+// migrateData runs the migrate-data subcommand, which copies all stored data
+// from one database to another while the server is stopped.
+func migrateData(args []string) error {
+	var (
+		from, to   string
+		checkpoint string
+		batchSize  int
+	)
+	flags := pflag.NewFlagSet("migrate-data", pflag.ContinueOnError)
+	flags.StringVar(&from, "from", "", "Source database as DRIVER:DSN, e.g. sqlite3:file:/tmp/registry.db")
+	flags.StringVar(&to, "to", "", "Destination database as DRIVER:DSN, e.g. \"postgres:host=localhost dbname=registry\"")
+	flags.StringVar(&checkpoint, "checkpoint", "migrate-data.checkpoint", "File recording progress, used to resume interrupted migrations")
+	flags.IntVar(&batchSize, "batch-size", 100, "Number of records copied at a time")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fromDriver, fromDSN, err := parseDatabase(from)
+	if err != nil {
+		return fmt.Errorf("invalid --from: %s", err)
+	}
+	toDriver, toDSN, err := parseDatabase(to)
+	if err != nil {
+		return fmt.Errorf("invalid --to: %s", err)
+	}
+
+	ctx := log.NewContext(context.Background(), log.NewLogger(loggerOptions(config.Logging)...))
+	return registry.MigrateData(ctx, registry.DataMigration{
+		FromDriver:     fromDriver,
+		FromDSN:        fromDSN,
+		ToDriver:       toDriver,
+		ToDSN:          toDSN,
+		BatchSize:      batchSize,
+		CheckpointFile: checkpoint,
+	})
+}
+
+// parseDatabase splits a database given as DRIVER:DSN.
+func parseDatabase(s string) (string, string, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("%q must have the form DRIVER:DSN", s)
+	}
+	switch parts[0] {
+	case "sqlite3", "postgres", "cloudsqlpostgres":
+		return parts[0], parts[1], nil
+	default:
+		return "", "", fmt.Errorf("unsupported driver %q, must be one of sqlite3, postgres or cloudsqlpostgres", parts[0])
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func TestParseDatabase(t *testing.T) {
+	tests := []struct {
+		in         string
+		driver     string
+		dsn        string
+		shouldFail bool
+	}{
+		{in: "sqlite3:file:/tmp/registry.db", driver: "sqlite3", dsn: "file:/tmp/registry.db"},
+		{in: "postgres:host=localhost port=5432 dbname=registry", driver: "postgres", dsn: "host=localhost port=5432 dbname=registry"},
+		{in: "cloudsqlpostgres:host=project:region:instance", driver: "cloudsqlpostgres", dsn: "host=project:region:instance"},
+		{in: "", shouldFail: true},
+		{in: "sqlite3", shouldFail: true},
+		{in: "sqlite3:", shouldFail: true},
+		{in: "mysql:registry", shouldFail: true},
+	}
+	for _, test := range tests {
+		driver, dsn, err := parseDatabase(test.in)
+		if test.shouldFail {
+			if err == nil {
+				t.Errorf("parseDatabase(%q) succeeded, want error", test.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDatabase(%q) returned error: %s", test.in, err)
+		} else if driver != test.driver || dsn != test.dsn {
+			t.Errorf("parseDatabase(%q) returned (%q, %q), want (%q, %q)", test.in, driver, dsn, test.driver, test.dsn)
+		}
+	}
+}
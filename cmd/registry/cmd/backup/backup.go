@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/spf13/cobra"
+)
+
+func Command(ctx context.Context) *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "backup PROJECT",
+		Short: "Save a project and all of its resources to an archive file",
+		Long: "Save a project, including every spec and deployment revision, tags, artifacts and contents,\n" +
+			"to an archive file that can be loaded with \"registry restore\".",
+		Example: "registry backup projects/my-project --output my-project.tgz",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			project, err := names.ParseProject(args[0])
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Invalid project")
+			}
+			if output == "" {
+				output = project.ProjectID + ".tgz"
+			}
+
+			client, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to create archive")
+			}
+			counts, err := core.DownloadProjectArchive(ctx, client, project.String(), f)
+			if err == nil {
+				err = f.Close()
+			} else {
+				f.Close()
+			}
+			if err != nil {
+				os.Remove(output)
+				log.FromContext(ctx).WithError(err).Fatal("Failed to export project")
+			}
+
+			var records int64
+			for _, n := range counts {
+				records += n
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Saved %s to %s (%d records)\n", project, output, records)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "", "Archive file to write (defaults to PROJECT_ID.tgz)")
+	return cmd
+}
@@ -16,17 +16,82 @@ package count
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
 	"github.com/spf13/cobra"
 )
 
 func Command(ctx context.Context) *cobra.Command {
+	var (
+		filter  string
+		groupBy []string
+	)
 	cmd := &cobra.Command{
-		Use:   "count",
+		Use:   "count COLLECTION",
 		Short: "Count quantities in the API Registry",
+		Long: "Count the resources in a collection, optionally grouped by field or label values.\n" +
+			"Collections can include wildcards, as in projects/my-project/locations/global/apis/-/versions/-/specs.",
+		Example: "registry count projects/my-project/locations/global/apis --group-by labels.team\n" +
+			"registry count projects/my-project/locations/global/apis/-/versions/-/specs --group-by mime_type",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := connection.NewClient(ctx)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			req, err := aggregateRequest(args[0], filter, groupBy)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Invalid collection")
+			}
+			resp, err := client.AggregateResources(ctx, req)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to count resources")
+			}
+			if err := printGroups(cmd.OutOrStdout(), groupBy, resp.GetGroups()); err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to print counts")
+			}
+		},
 	}
 
 	cmd.AddCommand(versionsCommand(ctx))
 
+	cmd.Flags().StringVar(&filter, "filter", "", "Filter selected resources")
+	cmd.Flags().StringSliceVar(&groupBy, "group-by", nil, "Fields or labels used to group counted resources, as in mime_type or labels.team")
 	return cmd
 }
+
+// aggregateRequest splits a collection name like projects/p/locations/l/apis/-/specs
+// into the parent and relative collection of an AggregateResources request.
+func aggregateRequest(name, filter string, groupBy []string) (*rpc.AggregateResourcesRequest, error) {
+	parts := strings.Split(strings.TrimSuffix(name, "/"), "/")
+	if len(parts) < 5 || parts[0] != "projects" || parts[2] != "locations" {
+		return nil, fmt.Errorf("unsupported collection %q", name)
+	}
+	return &rpc.AggregateResourcesRequest{
+		Parent:     strings.Join(parts[:4], "/"),
+		Collection: strings.Join(parts[4:], "/"),
+		Filter:     filter,
+		GroupBy:    groupBy,
+	}, nil
+}
+
+// printGroups prints a table with a row for each group.
+func printGroups(out io.Writer, groupBy []string, groups []*rpc.AggregateResourcesResponse_Group) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(append(append([]string{}, groupBy...), "count", "size_bytes"), "\t"))
+	for _, g := range groups {
+		row := make([]string, 0, len(groupBy)+2)
+		for _, field := range groupBy {
+			row = append(row, g.GetValues()[field])
+		}
+		row = append(row, fmt.Sprintf("%d", g.GetCount()), fmt.Sprintf("%d", g.GetSizeBytes()))
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package count
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestAggregateRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		want *rpc.AggregateResourcesRequest
+	}{
+		{
+			name: "projects/p/locations/global/apis",
+			want: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/p/locations/global",
+				Collection: "apis",
+				GroupBy:    []string{"labels.team"},
+			},
+		},
+		{
+			name: "projects/p/locations/global/apis/-/versions/-/specs/",
+			want: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/p/locations/global",
+				Collection: "apis/-/versions/-/specs",
+				GroupBy:    []string{"labels.team"},
+			},
+		},
+	}
+	for _, test := range tests {
+		got, err := aggregateRequest(test.name, "", []string{"labels.team"})
+		if err != nil {
+			t.Fatalf("aggregateRequest(%q) returned error: %s", test.name, err)
+		}
+		if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("aggregateRequest(%q) returned unexpected diff (-want +got):\n%s", test.name, diff)
+		}
+	}
+
+	for _, name := range []string{"projects/p", "projects/p/locations/global", "apis/a/versions"} {
+		if _, err := aggregateRequest(name, "", nil); err == nil {
+			t.Errorf("aggregateRequest(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestPrintGroups(t *testing.T) {
+	var out bytes.Buffer
+	err := printGroups(&out, []string{"mime_type"}, []*rpc.AggregateResourcesResponse_Group{
+		{Values: map[string]string{"mime_type": "application/json"}, Count: 1, SizeBytes: 3},
+		{Values: map[string]string{"mime_type": "text/plain"}, Count: 12, SizeBytes: 700},
+	})
+	if err != nil {
+		t.Fatalf("printGroups() returned error: %s", err)
+	}
+	want := "mime_type         count  size_bytes\n" +
+		"application/json  1      3\n" +
+		"text/plain        12     700\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("printGroups() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
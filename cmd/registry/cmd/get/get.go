@@ -58,6 +58,12 @@ func Command(ctx context.Context) *cobra.Command {
 				} else {
 					_, err2 = core.GetSpec(ctx, client, spec, getContents, core.PrintSpecDetail)
 				}
+			} else if revision, err := names.ParseArtifactRevision(name); err == nil {
+				if getContents {
+					_, err2 = core.GetArtifactRevision(ctx, client, revision, getContents, core.PrintArtifactContents)
+				} else {
+					_, err2 = core.GetArtifactRevision(ctx, client, revision, getContents, core.PrintArtifactDetail)
+				}
 			} else if artifact, err := names.ParseArtifact(name); err == nil {
 				if getContents {
 					_, err2 = core.GetArtifact(ctx, client, artifact, getContents, core.PrintArtifactContents)
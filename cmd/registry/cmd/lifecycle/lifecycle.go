@@ -0,0 +1,211 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/protobuf/field_mask"
+)
+
+func Command(ctx context.Context) *cobra.Command {
+	var (
+		filter      string
+		annotations map[string]string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lifecycle RESOURCE STATE",
+		Short: "Move APIs and versions between lifecycle states",
+		Long: "Move APIs and versions to another state of their project's lifecycle.\n" +
+			"The state of an API is its availability and the state of a version is its state.\n" +
+			"Annotations can be set with the state for states that require them, such as a deprecation date.",
+		Example: "registry lifecycle projects/my-project/locations/global/apis/my-api DEPRECATED --annotations deprecation-date=2022-06-30\n" +
+			"registry lifecycle projects/my-project/locations/global/apis/-/versions/- RETIRED --filter \"state == 'DEPRECATED'\"",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := connection.NewClient(ctx)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+
+			taskQueue, wait := core.WorkerPool(ctx, 64)
+			defer wait()
+
+			err = matchAndHandleLifecycleCmd(ctx, client, taskQueue, args[0], filter, args[1], annotations)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to match or handle command")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", "Filter selected resources")
+	cmd.Flags().StringToStringVar(&annotations, "annotations", nil, "Annotations to set with the new state, such as deprecation-date=2022-01-01")
+	return cmd
+}
+
+func matchAndHandleLifecycleCmd(
+	ctx context.Context,
+	client *gapic.RegistryClient,
+	taskQueue chan<- core.Task,
+	name string,
+	filter string,
+	state string,
+	annotations map[string]string,
+) error {
+	// First try to match collection names.
+	if api, err := names.ParseApiCollection(name); err == nil {
+		return transitionAPIs(ctx, client, api, filter, state, annotations, taskQueue)
+	} else if version, err := names.ParseVersionCollection(name); err == nil {
+		return transitionVersions(ctx, client, version, filter, state, annotations, taskQueue)
+	}
+
+	// Then try to match resource names.
+	if api, err := names.ParseApi(name); err == nil {
+		return transitionAPIs(ctx, client, api, filter, state, annotations, taskQueue)
+	} else if version, err := names.ParseVersion(name); err == nil {
+		return transitionVersions(ctx, client, version, filter, state, annotations, taskQueue)
+	} else {
+		return fmt.Errorf("unsupported resource name %s, must be APIs or versions", name)
+	}
+}
+
+func transitionAPIs(
+	ctx context.Context,
+	client *gapic.RegistryClient,
+	api names.Api,
+	filterFlag string,
+	state string,
+	annotations map[string]string,
+	taskQueue chan<- core.Task) error {
+	return core.ListAPIs(ctx, client, api, filterFlag, func(api *rpc.Api) {
+		taskQueue <- &transitionApiTask{
+			client:      client,
+			api:         api,
+			state:       state,
+			annotations: annotations,
+		}
+	})
+}
+
+func transitionVersions(
+	ctx context.Context,
+	client *gapic.RegistryClient,
+	version names.Version,
+	filterFlag string,
+	state string,
+	annotations map[string]string,
+	taskQueue chan<- core.Task) error {
+	return core.ListVersions(ctx, client, version, filterFlag, func(version *rpc.ApiVersion) {
+		taskQueue <- &transitionVersionTask{
+			client:      client,
+			version:     version,
+			state:       state,
+			annotations: annotations,
+		}
+	})
+}
+
+// updateMask returns the fields to update for a transition,
+// which only includes annotations when some are set.
+func updateMask(field string, annotations map[string]string) *field_mask.FieldMask {
+	mask := &field_mask.FieldMask{Paths: []string{field}}
+	if len(annotations) > 0 {
+		mask.Paths = append(mask.Paths, "annotations")
+	}
+	return mask
+}
+
+// mergeAnnotations returns the existing annotations of a resource with new values set.
+func mergeAnnotations(existing, values map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(values))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+	return merged
+}
+
+type transitionApiTask struct {
+	client      connection.Client
+	api         *rpc.Api
+	state       string
+	annotations map[string]string
+}
+
+func (task *transitionApiTask) String() string {
+	return fmt.Sprintf("lifecycle %s %s", task.api.Name, task.state)
+}
+
+func (task *transitionApiTask) request() *rpc.UpdateApiRequest {
+	return &rpc.UpdateApiRequest{
+		Api: &rpc.Api{
+			Name:         task.api.Name,
+			Availability: task.state,
+			Annotations:  mergeAnnotations(task.api.Annotations, task.annotations),
+		},
+		UpdateMask: updateMask("availability", task.annotations),
+	}
+}
+
+func (task *transitionApiTask) Run(ctx context.Context) error {
+	api, err := task.client.UpdateApi(ctx, task.request())
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Moved %s from %q to %q", api.Name, task.api.Availability, api.Availability)
+	return nil
+}
+
+type transitionVersionTask struct {
+	client      connection.Client
+	version     *rpc.ApiVersion
+	state       string
+	annotations map[string]string
+}
+
+func (task *transitionVersionTask) String() string {
+	return fmt.Sprintf("lifecycle %s %s", task.version.Name, task.state)
+}
+
+func (task *transitionVersionTask) request() *rpc.UpdateApiVersionRequest {
+	return &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{
+			Name:        task.version.Name,
+			State:       task.state,
+			Annotations: mergeAnnotations(task.version.Annotations, task.annotations),
+		},
+		UpdateMask: updateMask("state", task.annotations),
+	}
+}
+
+func (task *transitionVersionTask) Run(ctx context.Context) error {
+	version, err := task.client.UpdateApiVersion(ctx, task.request())
+	if err != nil {
+		return err
+	}
+	log.Debugf(ctx, "Moved %s from %q to %q", version.Name, task.version.State, version.State)
+	return nil
+}
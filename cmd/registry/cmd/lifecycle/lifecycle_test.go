@@ -0,0 +1,65 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestTransitionRequests(t *testing.T) {
+	const api = "projects/my-project/locations/global/apis/a"
+	apiTask := &transitionApiTask{
+		api: &rpc.Api{
+			Name:         api,
+			Availability: "PRODUCTION",
+			Annotations:  map[string]string{"owner": "team"},
+		},
+		state:       "DEPRECATED",
+		annotations: map[string]string{"deprecation-date": "2022-06-30"},
+	}
+	wantApi := &rpc.UpdateApiRequest{
+		Api: &rpc.Api{
+			Name:         api,
+			Availability: "DEPRECATED",
+			Annotations:  map[string]string{"owner": "team", "deprecation-date": "2022-06-30"},
+		},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"availability", "annotations"}},
+	}
+	if diff := cmp.Diff(wantApi, apiTask.request(), protocmp.Transform()); diff != "" {
+		t.Errorf("request() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	versionTask := &transitionVersionTask{
+		version: &rpc.ApiVersion{Name: api + "/versions/v1", State: "DESIGN"},
+		state:   "PRODUCTION",
+	}
+	wantVersion := &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{Name: api + "/versions/v1", State: "PRODUCTION", Annotations: map[string]string{}},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"state"}},
+	}
+	if diff := cmp.Diff(wantVersion, versionTask.request(), protocmp.Transform()); diff != "" {
+		t.Errorf("request() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// The original annotations are left unchanged for the task's log message.
+	if len(apiTask.api.Annotations) != 1 {
+		t.Errorf("request() changed the annotations of the listed API to %v", apiTask.api.Annotations)
+	}
+}
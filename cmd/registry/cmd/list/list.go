@@ -83,6 +83,8 @@ func matchAndHandleListCmd(
 		return core.ListSpecs(ctx, client, spec, filter, core.PrintSpec)
 	} else if artifact, err := names.ParseArtifact(name); err == nil {
 		return core.ListArtifacts(ctx, client, artifact, filter, false, core.PrintArtifact)
+	} else if revision, err := names.ParseArtifactRevision(name); err == nil && revision.RevisionID == "-" {
+		return core.ListArtifactRevisions(ctx, client, revision.Artifact(), false, core.PrintArtifact)
 	}
 
 	// If nothing matched, return an error.
@@ -0,0 +1,64 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/log"
+	"github.com/spf13/cobra"
+)
+
+func Command(ctx context.Context) *cobra.Command {
+	var projectID string
+	cmd := &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Create a project from an archive file written by \"registry backup\"",
+		Long: "Create a project from an archive file written by \"registry backup\".\n" +
+			"The project must not already exist. Use --project to restore the archive under a different project ID.",
+		Example: "registry restore my-project.tgz\n" +
+			"registry restore my-project.tgz --project my-project-copy",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to read archive")
+			}
+			defer f.Close()
+
+			client, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			resp, err := core.UploadProjectArchive(ctx, client, projectID, f)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to import project")
+			}
+
+			var records int64
+			for _, n := range resp.GetRecordCounts() {
+				records += n
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %s from %s (%d records)\n", resp.GetProject().GetName(), args[0], records)
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project", "", "ID of the restored project (defaults to the archived project's ID)")
+	return cmd
+}
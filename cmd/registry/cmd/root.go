@@ -19,14 +19,19 @@ import (
 	"fmt"
 
 	"github.com/apigee/registry/cmd/registry/cmd/annotate"
+	"github.com/apigee/registry/cmd/registry/cmd/backup"
 	"github.com/apigee/registry/cmd/registry/cmd/compute"
+	"github.com/apigee/registry/cmd/registry/cmd/count"
 	"github.com/apigee/registry/cmd/registry/cmd/delete"
 	"github.com/apigee/registry/cmd/registry/cmd/export"
 	"github.com/apigee/registry/cmd/registry/cmd/get"
 	"github.com/apigee/registry/cmd/registry/cmd/index"
 	"github.com/apigee/registry/cmd/registry/cmd/label"
+	"github.com/apigee/registry/cmd/registry/cmd/lifecycle"
 	"github.com/apigee/registry/cmd/registry/cmd/list"
 	"github.com/apigee/registry/cmd/registry/cmd/resolve"
+	"github.com/apigee/registry/cmd/registry/cmd/restore"
+	"github.com/apigee/registry/cmd/registry/cmd/status"
 	"github.com/apigee/registry/cmd/registry/cmd/upload"
 	"github.com/apigee/registry/cmd/registry/cmd/vocabulary"
 	"github.com/apigee/registry/log"
@@ -47,13 +52,18 @@ func Command(ctx context.Context) *cobra.Command {
 	})
 
 	cmd.AddCommand(annotate.Command(ctx))
+	cmd.AddCommand(backup.Command(ctx))
 	cmd.AddCommand(compute.Command(ctx))
+	cmd.AddCommand(count.Command(ctx))
 	cmd.AddCommand(resolve.Command(ctx))
+	cmd.AddCommand(restore.Command(ctx))
+	cmd.AddCommand(status.Command(ctx))
 	cmd.AddCommand(delete.Command(ctx))
 	cmd.AddCommand(export.Command(ctx))
 	cmd.AddCommand(get.Command(ctx))
 	cmd.AddCommand(index.Command(ctx))
 	cmd.AddCommand(label.Command(ctx))
+	cmd.AddCommand(lifecycle.Command(ctx))
 	cmd.AddCommand(list.Command(ctx))
 	cmd.AddCommand(upload.Command(ctx))
 	cmd.AddCommand(vocabulary.Command(ctx))
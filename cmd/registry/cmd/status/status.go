@@ -0,0 +1,115 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func Command(ctx context.Context) *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print the status of the registry server",
+		Long: "Print the build, database and notification status of the registry server,\n" +
+			"and the number of APIs, specs, revisions and stored bytes in each project.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			status, err := client.GetStatus(ctx, &emptypb.Empty{})
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get status")
+			}
+			if jsonOutput {
+				b, err := protojson.MarshalOptions{Multiline: true}.Marshal(status)
+				if err != nil {
+					log.FromContext(ctx).WithError(err).Fatal("Failed to marshal status")
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				return
+			}
+			if err := printStatus(cmd.OutOrStdout(), status); err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to print status")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the status as JSON")
+	return cmd
+}
+
+// printStatus prints a summary of the server followed by a table with a row for each project.
+func printStatus(out io.Writer, s *rpc.Status) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "status:\t%s\n", s.GetMessage())
+	fmt.Fprintf(w, "version:\t%s (%s)\n", s.GetBuild().GetVersion(), s.GetBuild().GetGoVersion())
+	fmt.Fprintf(w, "uptime:\t%s\n", s.GetUptime().AsDuration())
+
+	db := s.GetDatabase()
+	schema := "current"
+	if !db.GetSchemaCurrent() {
+		schema = "needs migration"
+	}
+	fmt.Fprintf(w, "database:\t%s\n", strings.TrimSpace(db.GetDriver()+" "+db.GetVersion()))
+	if db.GetError() != "" {
+		fmt.Fprintf(w, "database error:\t%s\n", db.GetError())
+	} else {
+		fmt.Fprintf(w, "schema:\t%s (%s)\n", db.GetSchemaVersion(), schema)
+		pool := db.GetPool()
+		fmt.Fprintf(w, "connections:\t%d open, %d in use, %d idle, %d waits (%s)\n",
+			pool.GetOpenConnections(), pool.GetInUse(), pool.GetIdle(), pool.GetWaitCount(), pool.GetWaitDuration().AsDuration())
+	}
+
+	n := s.GetNotifications()
+	if n.GetEnabled() {
+		fmt.Fprintf(w, "notifications:\t%d published, %d failed\n", n.GetPublished(), n.GetFailed())
+		if n.GetLastError() != "" {
+			fmt.Fprintf(w, "last notification error:\t%s (%s)\n", n.GetLastError(), n.GetLastErrorTime().AsTime())
+		}
+	} else {
+		fmt.Fprintf(w, "notifications:\tdisabled\n")
+	}
+	if m := s.GetMaintenance(); m.GetReadOnly() {
+		fmt.Fprintf(w, "maintenance:\tread-only since %s (%s)\n", m.GetUpdateTime().AsTime().Format(time.RFC3339), m.GetMessage())
+	} else {
+		fmt.Fprintf(w, "maintenance:\toff\n")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "project\tapis\tspecs\tspec_revisions\tblob_bytes")
+	for _, p := range s.GetProjects() {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", p.GetName(), p.GetApis(), p.GetSpecs(), p.GetSpecRevisions(), p.GetBlobBytes())
+	}
+	return w.Flush()
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestPrintStatus(t *testing.T) {
+	var out bytes.Buffer
+	err := printStatus(&out, &rpc.Status{
+		Message: "running",
+		Build:   &rpc.Status_Build{Version: "v0.3.0", GoVersion: "go1.17"},
+		Uptime:  durationpb.New(90 * time.Minute),
+		Database: &rpc.Status_Database{
+			Driver:        "postgres",
+			Version:       "13.4",
+			SchemaVersion: "57c4e71ece5e",
+			SchemaCurrent: true,
+			Pool:          &rpc.Status_Database_Pool{OpenConnections: 2, InUse: 1, Idle: 1},
+		},
+		Notifications: &rpc.Status_Notifications{Enabled: true, Published: 10},
+		Projects: []*rpc.Status_Project{
+			{Name: "projects/my-project", Apis: 2, Specs: 3, SpecRevisions: 5, BlobBytes: 1024},
+		},
+	})
+	if err != nil {
+		t.Fatalf("printStatus() returned error: %s", err)
+	}
+	want := "status:         running\n" +
+		"version:        v0.3.0 (go1.17)\n" +
+		"uptime:         1h30m0s\n" +
+		"database:       postgres 13.4\n" +
+		"schema:         57c4e71ece5e (current)\n" +
+		"connections:    2 open, 1 in use, 1 idle, 0 waits (0s)\n" +
+		"notifications:  10 published, 0 failed\n" +
+		"maintenance:    off\n" +
+		"\n" +
+		"project              apis  specs  spec_revisions  blob_bytes\n" +
+		"projects/my-project  2     3      5               1024\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("printStatus() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrintStatusDatabaseError(t *testing.T) {
+	var out bytes.Buffer
+	err := printStatus(&out, &rpc.Status{
+		Message: "running",
+		Build:   &rpc.Status_Build{Version: "v0.3.0", GoVersion: "go1.17"},
+		Uptime:  durationpb.New(90 * time.Minute),
+		Database: &rpc.Status_Database{
+			Driver: "postgres",
+			Error:  "connection refused",
+		},
+		Notifications: &rpc.Status_Notifications{},
+	})
+	if err != nil {
+		t.Fatalf("printStatus() returned error: %s", err)
+	}
+	want := "status:          running\n" +
+		"version:         v0.3.0 (go1.17)\n" +
+		"uptime:          1h30m0s\n" +
+		"database:        postgres\n" +
+		"database error:  connection refused\n" +
+		"notifications:   disabled\n" +
+		"maintenance:     off\n" +
+		"\n" +
+		"project  apis  specs  spec_revisions  blob_bytes\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("printStatus() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
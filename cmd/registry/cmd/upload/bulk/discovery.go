@@ -23,6 +23,7 @@ import (
 	"github.com/apigee/registry/connection"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -119,6 +120,7 @@ func (task *uploadDiscoveryTask) createAPI(ctx context.Context) error {
 			Description: task.info.Description,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err == nil {
 		log.Debugf(ctx, "Updated %s", response.Name)
@@ -142,6 +144,7 @@ func (task *uploadDiscoveryTask) createVersion(ctx context.Context) error {
 			Name: task.versionName(),
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err != nil {
 		log.FromContext(ctx).WithError(err).Debugf("Failed to create version %s", task.versionName())
@@ -177,6 +180,7 @@ func (task *uploadDiscoveryTask) createOrUpdateSpec(ctx context.Context) error {
 			SourceUri: task.path,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	}
 
 	response, err := task.client.UpdateApiSpec(ctx, request)
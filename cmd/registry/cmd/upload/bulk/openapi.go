@@ -28,6 +28,7 @@ import (
 	"github.com/apigee/registry/connection"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -178,6 +179,7 @@ func (task *uploadOpenAPITask) createAPI(ctx context.Context) error {
 			DisplayName: task.apiID,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err == nil {
 		log.Debugf(ctx, "Updated %s", response.Name)
@@ -201,6 +203,7 @@ func (task *uploadOpenAPITask) createVersion(ctx context.Context) error {
 			Name: task.versionName(),
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err == nil {
 		log.Debugf(ctx, "Updated %s", response.Name)
@@ -240,6 +243,7 @@ func (task *uploadOpenAPITask) createOrUpdateSpec(ctx context.Context) error {
 			Contents: gzippedContents,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	}
 	if task.baseURI != "" {
 		request.ApiSpec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
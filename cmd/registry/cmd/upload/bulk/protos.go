@@ -28,6 +28,7 @@ import (
 	"github.com/apigee/registry/connection"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -166,6 +167,7 @@ func (task *uploadProtoTask) createAPI(ctx context.Context) error {
 			Description: task.apiDescription,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err == nil {
 		log.Debugf(ctx, "Updated %s", response.Name)
@@ -189,6 +191,7 @@ func (task *uploadProtoTask) createVersion(ctx context.Context) error {
 			Name: task.versionName(),
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	})
 	if err == nil {
 		log.Debugf(ctx, "Updated %s", response.Name)
@@ -223,6 +226,7 @@ func (task *uploadProtoTask) createOrUpdateSpec(ctx context.Context) error {
 			Contents: contents,
 		},
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 	}
 	if task.baseURI != "" {
 		request.ApiSpec.SourceUri = fmt.Sprintf("%s/%s", task.baseURI, task.apiPath())
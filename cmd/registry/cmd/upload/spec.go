@@ -101,6 +101,9 @@ func uploadSpecDirectory(ctx context.Context, dirname string, client *gapic.Regi
 			Contents: buf.Bytes(),
 		},
 	}
+	if buf.Len() > core.StreamingThreshold {
+		return uploadLargeSpec(ctx, client, request)
+	}
 	response, err := client.CreateApiSpec(ctx, request)
 	if err == nil {
 		log.Debugf(ctx, "Created %s", response.Name)
@@ -149,6 +152,10 @@ func uploadSpecFile(ctx context.Context, filename string, client *gapic.Registry
 				log.FromContext(ctx).WithError(err).Debug("Failed to compress spec contents")
 			}
 
+			if len(request.ApiSpec.Contents) > core.StreamingThreshold {
+				return uploadLargeSpec(ctx, client, request)
+			}
+
 			response, err := client.CreateApiSpec(ctx, request)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Debug("Failed to create spec")
@@ -159,3 +166,27 @@ func uploadSpecFile(ctx context.Context, filename string, client *gapic.Registry
 	}
 	return nil
 }
+
+// uploadLargeSpec creates a spec by streaming its contents in chunks, which avoids message size limits.
+// Like CreateApiSpec, it leaves existing specs unchanged.
+func uploadLargeSpec(ctx context.Context, client *gapic.RegistryClient, request *rpc.CreateApiSpecRequest) error {
+	name := request.Parent + "/specs/" + request.ApiSpecId
+	if _, err := client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: name}); err == nil {
+		log.Debugf(ctx, "Found %s", name)
+		return nil
+	}
+
+	spec := &rpc.ApiSpec{
+		Name:     name,
+		Filename: request.ApiSpec.GetFilename(),
+		MimeType: request.ApiSpec.GetMimeType(),
+	}
+	response, err := core.UploadSpecContents(ctx, client, spec, request.ApiSpec.GetContents())
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Debugf("Error %s [contents-length %d]", name, len(request.ApiSpec.GetContents()))
+		return nil
+	}
+
+	log.Debugf(ctx, "Created %s", response.Name)
+	return nil
+}
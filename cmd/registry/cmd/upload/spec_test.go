@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUploadLargeSpec(t *testing.T) {
+	const project = "upload-large-spec-test"
+	ctx := context.Background()
+	client, err := connection.NewClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create client: %s", err)
+	}
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: Failed to create client: %s", err)
+	}
+
+	err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name: "projects/" + project,
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Setup: Failed to delete test project: %s", err)
+	}
+
+	if _, err := adminClient.CreateProject(ctx, &rpc.CreateProjectRequest{
+		ProjectId: project,
+		Project:   &rpc.Project{},
+	}); err != nil {
+		t.Fatalf("Setup: Failed to create project %s: %s", project, err)
+	}
+
+	version := "projects/" + project + "/locations/global/apis/my-api/versions/v1"
+	if _, err := client.CreateApi(ctx, &rpc.CreateApiRequest{
+		Parent: "projects/" + project + "/locations/global",
+		ApiId:  "my-api",
+		Api:    &rpc.Api{},
+	}); err != nil {
+		t.Fatalf("Setup: Failed to create API: %s", err)
+	}
+	if _, err := client.CreateApiVersion(ctx, &rpc.CreateApiVersionRequest{
+		Parent:       "projects/" + project + "/locations/global/apis/my-api",
+		ApiVersionId: "v1",
+		ApiVersion:   &rpc.ApiVersion{},
+	}); err != nil {
+		t.Fatalf("Setup: Failed to create version: %s", err)
+	}
+
+	// Large enough to be sent and received in several chunks.
+	contents := bytes.Repeat([]byte("0123456789abcdef"), 3*core.StreamingThreshold/16)
+	request := &rpc.CreateApiSpecRequest{
+		Parent:    version,
+		ApiSpecId: "large.txt",
+		ApiSpec: &rpc.ApiSpec{
+			MimeType: "text/plain",
+			Contents: contents,
+		},
+	}
+	if err := uploadLargeSpec(ctx, client, request); err != nil {
+		t.Fatalf("uploadLargeSpec(%s) returned error: %s", request.ApiSpecId, err)
+	}
+
+	name, err := names.ParseSpec(version + "/specs/large.txt")
+	if err != nil {
+		t.Fatalf("Failed to parse spec name: %s", err)
+	}
+	spec, err := core.GetSpec(ctx, client, name, true, nil)
+	if err != nil {
+		t.Fatalf("GetSpec(%s) returned error: %s", name, err)
+	}
+	if spec.GetMimeType() != "text/plain" {
+		t.Errorf("GetSpec(%s) returned mime_type %q, want %q", name, spec.GetMimeType(), "text/plain")
+	}
+	if !bytes.Equal(spec.GetContents(), contents) {
+		t.Errorf("GetSpec(%s) returned %d bytes that don't match the %d uploaded", name, len(spec.GetContents()), len(contents))
+	}
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/apigee/registry/gapic"
 	"github.com/apigee/registry/rpc"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -31,6 +32,7 @@ func SetArtifact(ctx context.Context,
 	request.Artifact = artifact
 	request.ArtifactId = path.Base(artifact.GetName())
 	request.Parent = path.Dir(path.Dir(artifact.GetName()))
+	request.RequestId = uuid.New().String()
 	// First try setting a new artifact value.
 	_, err := client.CreateArtifact(ctx, request)
 	if err == nil {
@@ -41,6 +43,7 @@ func SetArtifact(ctx context.Context,
 	if code == codes.AlreadyExists {
 		request := &rpc.ReplaceArtifactRequest{}
 		request.Artifact = artifact
+		request.RequestId = uuid.New().String()
 		_, err := client.ReplaceArtifact(ctx, request)
 		return err
 	}
@@ -85,7 +85,12 @@ func GetSpec(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	if getContents {
+	if getContents && spec.GetSizeBytes() > StreamingThreshold {
+		spec.Contents, spec.MimeType, err = DownloadSpecContents(ctx, client, spec.GetName())
+		if err != nil {
+			return nil, err
+		}
+	} else if getContents {
 		request := &rpc.GetApiSpecContentsRequest{
 			Name: spec.GetName(),
 		}
@@ -114,7 +119,46 @@ func GetArtifact(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	if getContents {
+	if getContents && artifact.GetSizeBytes() > StreamingThreshold {
+		artifact.Contents, artifact.MimeType, err = DownloadArtifactContents(ctx, client, artifact.GetName())
+		if err != nil {
+			return nil, err
+		}
+	} else if getContents {
+		request := &rpc.GetArtifactContentsRequest{
+			Name: artifact.GetName(),
+		}
+		contents, err := client.GetArtifactContents(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		artifact.Contents = contents.GetData()
+		artifact.MimeType = contents.GetContentType()
+	}
+	if handler != nil {
+		handler(artifact)
+	}
+	return artifact, nil
+}
+
+func GetArtifactRevision(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.ArtifactRevision,
+	getContents bool,
+	handler ArtifactHandler) (*rpc.Artifact, error) {
+	request := &rpc.GetArtifactRequest{
+		Name: name.String(),
+	}
+	artifact, err := client.GetArtifact(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if getContents && artifact.GetSizeBytes() > StreamingThreshold {
+		artifact.Contents, artifact.MimeType, err = DownloadArtifactContents(ctx, client, artifact.GetName())
+		if err != nil {
+			return nil, err
+		}
+	} else if getContents {
 		request := &rpc.GetArtifactContentsRequest{
 			Name: artifact.GetName(),
 		}
@@ -203,6 +203,37 @@ func ListArtifacts(ctx context.Context,
 	return nil
 }
 
+func ListArtifactRevisions(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Artifact,
+	getContents bool,
+	handler ArtifactHandler) error {
+	request := &rpc.ListArtifactRevisionsRequest{
+		Name: name.String(),
+	}
+	it := client.ListArtifactRevisions(ctx, request)
+	for {
+		artifact, err := it.Next()
+		if err == iterator.Done {
+			break
+		} else if err != nil {
+			return err
+		}
+		if getContents {
+			req := &rpc.GetArtifactContentsRequest{
+				Name: artifact.GetName(),
+			}
+			resp, err := client.GetArtifactContents(ctx, req)
+			if err != nil {
+				return err
+			}
+			artifact.Contents = resp.GetData()
+		}
+		handler(artifact)
+	}
+	return nil
+}
+
 func ListArtifactsForParent(ctx context.Context,
 	client *gapic.RegistryClient,
 	parent names.Name,
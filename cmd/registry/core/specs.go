@@ -22,6 +22,7 @@ import (
 	"github.com/apigee/registry/connection"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -58,6 +59,7 @@ func UploadBytesForSpec(ctx context.Context, client connection.Client, parent, s
 
 	request := &rpc.UpdateApiSpecRequest{
 		AllowMissing: true,
+		RequestId:    uuid.New().String(),
 		ApiSpec: &rpc.ApiSpec{
 			Name:     parent + "/specs/" + specID,
 			MimeType: style,
@@ -0,0 +1,172 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/rpc"
+)
+
+// StreamingThreshold is the size in bytes above which contents are transferred in chunks.
+const StreamingThreshold = 1 << 20
+
+// chunkSize is the number of bytes sent in each chunk of an upload.
+const chunkSize = 1 << 20
+
+type contentsChunkReceiver interface {
+	Recv() (*rpc.ContentsChunk, error)
+}
+
+// DownloadSpecContents reads the contents of a spec in chunks and returns them with their MIME type.
+func DownloadSpecContents(ctx context.Context, client *gapic.RegistryClient, name string) ([]byte, string, error) {
+	stream, err := client.DownloadApiSpecContents(ctx, &rpc.DownloadApiSpecContentsRequest{Name: name})
+	if err != nil {
+		return nil, "", err
+	}
+	return receiveContents(stream)
+}
+
+// DownloadArtifactContents reads the contents of an artifact in chunks and returns them with their MIME type.
+func DownloadArtifactContents(ctx context.Context, client *gapic.RegistryClient, name string) ([]byte, string, error) {
+	stream, err := client.DownloadArtifactContents(ctx, &rpc.DownloadArtifactContentsRequest{Name: name})
+	if err != nil {
+		return nil, "", err
+	}
+	return receiveContents(stream)
+}
+
+func receiveContents(stream contentsChunkReceiver) ([]byte, string, error) {
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(first.GetSizeBytes()))
+	buf.Write(first.GetData())
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, "", err
+		}
+		buf.Write(chunk.GetData())
+	}
+
+	contents := buf.Bytes()
+	if size := int64(len(contents)); size != first.GetSizeBytes() {
+		return nil, "", fmt.Errorf("received %d bytes, expected %d", size, first.GetSizeBytes())
+	}
+	if hash := fmt.Sprintf("%x", sha256.Sum256(contents)); hash != first.GetHash() {
+		return nil, "", fmt.Errorf("received contents with hash %q, expected %q", hash, first.GetHash())
+	}
+	return contents, first.GetMimeType(), nil
+}
+
+// UploadSpecContents sends the contents of a spec in chunks, creating the spec if it doesn't exist.
+// All other fields that are set in spec are also updated.
+func UploadSpecContents(ctx context.Context, client *gapic.RegistryClient, spec *rpc.ApiSpec, contents []byte) (*rpc.ApiSpec, error) {
+	stream, err := client.UploadApiSpecContents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	first := &rpc.UploadApiSpecContentsRequest{
+		ApiSpec:      spec,
+		AllowMissing: true,
+		Hash:         fmt.Sprintf("%x", sha256.Sum256(contents)),
+	}
+	if err := stream.Send(first); err != nil {
+		return nil, err
+	}
+
+	for offset := 0; offset < len(contents); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(contents) {
+			end = len(contents)
+		}
+		if err := stream.Send(&rpc.UploadApiSpecContentsRequest{Chunk: contents[offset:end]}); err != nil {
+			// The server's status is returned by CloseAndRecv when a send fails.
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// DownloadProjectArchive writes an archive of a project to w as it is received in chunks.
+// It returns the number of archived records of each kind.
+func DownloadProjectArchive(ctx context.Context, client *gapic.AdminClient, name string, w io.Writer) (map[string]int64, error) {
+	stream, err := client.DownloadProjectArchive(ctx, &rpc.DownloadProjectArchiveRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var counts map[string]int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return nil, err
+		}
+		if chunk.GetRecordCounts() != nil {
+			counts = chunk.GetRecordCounts()
+		}
+	}
+	return counts, nil
+}
+
+// UploadProjectArchive sends an archive read from r in chunks, creating a project from it.
+// If projectID is nonempty, the project is created with that ID instead of the archived one.
+func UploadProjectArchive(ctx context.Context, client *gapic.AdminClient, projectID string, r io.Reader) (*rpc.ImportProjectResponse, error) {
+	stream, err := client.UploadProjectArchive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&rpc.UploadProjectArchiveRequest{ProjectId: projectID}); err != nil {
+		return stream.CloseAndRecv()
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := stream.Send(&rpc.UploadProjectArchiveRequest{Chunk: buf[:n]}); err != nil {
+				// The server's status is returned by CloseAndRecv when a send fails.
+				break
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			stream.CloseSend()
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
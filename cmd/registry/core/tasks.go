@@ -16,9 +16,13 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // Task is a generic interface for a runnable operation
@@ -57,9 +61,23 @@ func worker(ctx context.Context, wg *sync.WaitGroup, taskQueue <-chan Task) {
 		case <-ctx.Done():
 			return
 		default:
-			if err := task.Run(ctx); err != nil {
+			if err := runTask(ctx, task); err != nil {
 				log.FromContext(ctx).WithError(err).Fatalf("Task failed: %s", task)
 			}
 		}
 	}
 }
+
+// runTask runs a task in its own span, named after the task's type.
+func runTask(ctx context.Context, task Task) error {
+	ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("%T", task))
+	defer span.End()
+	span.SetAttributes(attribute.String("task", task.String()))
+
+	err := task.Run(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
@@ -30,6 +30,11 @@ func DiscoveryMimeType(compression string) string {
 	return fmt.Sprintf("application/x.discovery%s", compression)
 }
 
+// AsyncAPIMimeType returns a MIME type for an AsyncAPI description of an API.
+func AsyncAPIMimeType(compression, version string) string {
+	return fmt.Sprintf("application/x.asyncapi%s;version=%s", compression, version)
+}
+
 // ProtobufMimeType returns a MIME type for a Protocol Buffers description of an API.
 func ProtobufMimeType(compression string) string {
 	return fmt.Sprintf("application/x.protobuf%s", compression)
@@ -60,12 +60,12 @@ func GetDiff(base, revision []byte) (*rpc.Diff, error) {
 	loader.IsExternalRefsAllowed = true
 	baseSpec, err := loader.LoadFromData(base)
 	if err != nil {
-		err := fmt.Errorf("failed to load base spec from %q with %v", base, err)
+		err := fmt.Errorf("failed to load base spec: %v", err)
 		return nil, err
 	}
 	revisionSpec, err := loader.LoadFromData(revision)
 	if err != nil {
-		err := fmt.Errorf("failed to load revision spec from %q with %v", revision, err)
+		err := fmt.Errorf("failed to load revision spec: %v", err)
 		return nil, err
 	}
 	diffReport, err := diff.Get(&diff.Config{
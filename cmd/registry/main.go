@@ -20,12 +20,35 @@ import (
 	"os"
 
 	"github.com/apigee/registry/cmd/registry/cmd"
+	"github.com/apigee/registry/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
 func main() {
 	ctx := context.Background()
+	shutdown, err := tracing.Setup(ctx, tracing.ConfigFromEnv("registry"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Trace the entire command so that spans for its tasks and API calls share a trace.
+	ctx, span := tracing.Tracer().Start(ctx, "registry")
+	span.SetAttributes(attribute.StringSlice("args", os.Args[1:]))
 	cmd := cmd.Command(ctx)
-	if err := cmd.Execute(); err != nil {
+	c, err := cmd.ExecuteC()
+	if c != nil {
+		span.SetName(c.CommandPath())
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	_ = shutdown(ctx)
+
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 
 	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/tracing"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
@@ -51,11 +52,17 @@ func clientOptions(settings *Settings) ([]option.ClientOption, error) {
 	}
 	opts = append(opts, option.WithEndpoint(settings.Address))
 	if settings.Insecure {
-		conn, err := grpc.Dial(settings.Address, grpc.WithInsecure())
+		dialOpts := append([]grpc.DialOption{grpc.WithInsecure()}, tracing.DialOptions()...)
+		conn, err := grpc.Dial(settings.Address, dialOpts...)
 		if err != nil {
 			return nil, err
 		}
 		opts = append(opts, option.WithGRPCConn(conn))
+	} else {
+		// Propagate trace context to the server and trace each call.
+		for _, o := range tracing.DialOptions() {
+			opts = append(opts, option.WithGRPCDialOption(o))
+		}
 	}
 	if settings.Token != "" {
 		opts = append(opts, option.WithTokenSource(oauth2.StaticTokenSource(
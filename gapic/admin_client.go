@@ -42,13 +42,20 @@ var newAdminClientHook clientHook
 
 // AdminCallOptions contains the retry settings for each method of AdminClient.
 type AdminCallOptions struct {
-	GetStatus       []gax.CallOption
-	MigrateDatabase []gax.CallOption
-	ListProjects    []gax.CallOption
-	GetProject      []gax.CallOption
-	CreateProject   []gax.CallOption
-	UpdateProject   []gax.CallOption
-	DeleteProject   []gax.CallOption
+	GetStatus              []gax.CallOption
+	MigrateDatabase        []gax.CallOption
+	ListProjects           []gax.CallOption
+	GetProject             []gax.CallOption
+	CreateProject          []gax.CallOption
+	UpdateProject          []gax.CallOption
+	DeleteProject          []gax.CallOption
+	PruneRevisions         []gax.CallOption
+	ExportProject          []gax.CallOption
+	ImportProject          []gax.CallOption
+	CheckConsistency       []gax.CallOption
+	SetMaintenanceMode     []gax.CallOption
+	DownloadProjectArchive []gax.CallOption
+	UploadProjectArchive   []gax.CallOption
 }
 
 func defaultAdminGRPCClientOptions() []option.ClientOption {
@@ -65,13 +72,20 @@ func defaultAdminGRPCClientOptions() []option.ClientOption {
 
 func defaultAdminCallOptions() *AdminCallOptions {
 	return &AdminCallOptions{
-		GetStatus:       []gax.CallOption{},
-		MigrateDatabase: []gax.CallOption{},
-		ListProjects:    []gax.CallOption{},
-		GetProject:      []gax.CallOption{},
-		CreateProject:   []gax.CallOption{},
-		UpdateProject:   []gax.CallOption{},
-		DeleteProject:   []gax.CallOption{},
+		GetStatus:              []gax.CallOption{},
+		MigrateDatabase:        []gax.CallOption{},
+		ListProjects:           []gax.CallOption{},
+		GetProject:             []gax.CallOption{},
+		CreateProject:          []gax.CallOption{},
+		UpdateProject:          []gax.CallOption{},
+		DeleteProject:          []gax.CallOption{},
+		PruneRevisions:         []gax.CallOption{},
+		ExportProject:          []gax.CallOption{},
+		ImportProject:          []gax.CallOption{},
+		CheckConsistency:       []gax.CallOption{},
+		SetMaintenanceMode:     []gax.CallOption{},
+		DownloadProjectArchive: []gax.CallOption{},
+		UploadProjectArchive:   []gax.CallOption{},
 	}
 }
 
@@ -88,6 +102,16 @@ type internalAdminClient interface {
 	CreateProject(context.Context, *rpcpb.CreateProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
 	UpdateProject(context.Context, *rpcpb.UpdateProjectRequest, ...gax.CallOption) (*rpcpb.Project, error)
 	DeleteProject(context.Context, *rpcpb.DeleteProjectRequest, ...gax.CallOption) error
+	PruneRevisions(context.Context, *rpcpb.PruneRevisionsRequest, ...gax.CallOption) (*rpcpb.PruneRevisionsResponse, error)
+	ExportProject(context.Context, *rpcpb.ExportProjectRequest, ...gax.CallOption) (*ExportProjectOperation, error)
+	ExportProjectOperation(name string) *ExportProjectOperation
+	ImportProject(context.Context, *rpcpb.ImportProjectRequest, ...gax.CallOption) (*ImportProjectOperation, error)
+	ImportProjectOperation(name string) *ImportProjectOperation
+	CheckConsistency(context.Context, *rpcpb.CheckConsistencyRequest, ...gax.CallOption) (*CheckConsistencyOperation, error)
+	CheckConsistencyOperation(name string) *CheckConsistencyOperation
+	SetMaintenanceMode(context.Context, *rpcpb.SetMaintenanceModeRequest, ...gax.CallOption) (*rpcpb.MaintenanceMode, error)
+	DownloadProjectArchive(context.Context, *rpcpb.DownloadProjectArchiveRequest, ...gax.CallOption) (rpcpb.Admin_DownloadProjectArchiveClient, error)
+	UploadProjectArchive(context.Context, ...gax.CallOption) (rpcpb.Admin_UploadProjectArchiveClient, error)
 }
 
 // AdminClient is a client for interacting with .
@@ -186,6 +210,72 @@ func (c *AdminClient) DeleteProject(ctx context.Context, req *rpcpb.DeleteProjec
 	return c.internalClient.DeleteProject(ctx, req, opts...)
 }
 
+// PruneRevisions pruneRevisions deletes spec and deployment revisions that fall outside
+// the revision retention policy of a project.
+func (c *AdminClient) PruneRevisions(ctx context.Context, req *rpcpb.PruneRevisionsRequest, opts ...gax.CallOption) (*rpcpb.PruneRevisionsResponse, error) {
+	return c.internalClient.PruneRevisions(ctx, req, opts...)
+}
+
+// ExportProject exportProject writes a project and all of the resources that it owns,
+// including every revision, revision tag and artifact and their contents, to
+// a portable archive that can be restored with ImportProject.
+func (c *AdminClient) ExportProject(ctx context.Context, req *rpcpb.ExportProjectRequest, opts ...gax.CallOption) (*ExportProjectOperation, error) {
+	return c.internalClient.ExportProject(ctx, req, opts...)
+}
+
+// ExportProjectOperation returns a new ExportProjectOperation from a given name.
+// The name must be that of a previously created ExportProjectOperation, possibly from a different process.
+func (c *AdminClient) ExportProjectOperation(name string) *ExportProjectOperation {
+	return c.internalClient.ExportProjectOperation(name)
+}
+
+// ImportProject importProject creates a project from an archive written by ExportProject.
+func (c *AdminClient) ImportProject(ctx context.Context, req *rpcpb.ImportProjectRequest, opts ...gax.CallOption) (*ImportProjectOperation, error) {
+	return c.internalClient.ImportProject(ctx, req, opts...)
+}
+
+// ImportProjectOperation returns a new ImportProjectOperation from a given name.
+// The name must be that of a previously created ImportProjectOperation, possibly from a different process.
+func (c *AdminClient) ImportProjectOperation(name string) *ImportProjectOperation {
+	return c.internalClient.ImportProjectOperation(name)
+}
+
+// CheckConsistency checkConsistency looks for stored records that are inconsistent with each
+// other, such as orphaned records, dangling revision tags, contents that
+// don't match their hashes and references to missing resources, and
+// optionally repairs them.
+func (c *AdminClient) CheckConsistency(ctx context.Context, req *rpcpb.CheckConsistencyRequest, opts ...gax.CallOption) (*CheckConsistencyOperation, error) {
+	return c.internalClient.CheckConsistency(ctx, req, opts...)
+}
+
+// CheckConsistencyOperation returns a new CheckConsistencyOperation from a given name.
+// The name must be that of a previously created CheckConsistencyOperation, possibly from a different process.
+func (c *AdminClient) CheckConsistencyOperation(name string) *CheckConsistencyOperation {
+	return c.internalClient.CheckConsistencyOperation(name)
+}
+
+// SetMaintenanceMode setMaintenanceMode puts the server into or takes it out of read-only
+// maintenance mode. In read-only mode, requests that would change stored
+// resources fail with UNAVAILABLE, while requests that read them continue
+// to succeed. The mode applies only to the server that handles the request.
+func (c *AdminClient) SetMaintenanceMode(ctx context.Context, req *rpcpb.SetMaintenanceModeRequest, opts ...gax.CallOption) (*rpcpb.MaintenanceMode, error) {
+	return c.internalClient.SetMaintenanceMode(ctx, req, opts...)
+}
+
+// DownloadProjectArchive downloadProjectArchive streams an archive of a project in chunks. The
+// archive is the same as the one written by ExportProject, but projects of
+// any size can be exported without reaching message size limits.
+func (c *AdminClient) DownloadProjectArchive(ctx context.Context, req *rpcpb.DownloadProjectArchiveRequest, opts ...gax.CallOption) (rpcpb.Admin_DownloadProjectArchiveClient, error) {
+	return c.internalClient.DownloadProjectArchive(ctx, req, opts...)
+}
+
+// UploadProjectArchive uploadProjectArchive creates a project from an archive written by
+// ExportProject or DownloadProjectArchive that is streamed in chunks.
+// Archives of any size can be imported without reaching message size limits.
+func (c *AdminClient) UploadProjectArchive(ctx context.Context, opts ...gax.CallOption) (rpcpb.Admin_UploadProjectArchiveClient, error) {
+	return c.internalClient.UploadProjectArchive(ctx, opts...)
+}
+
 // adminGRPCClient is a client for interacting with  over gRPC transport.
 //
 // Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
@@ -417,6 +507,120 @@ func (c *adminGRPCClient) DeleteProject(ctx context.Context, req *rpcpb.DeletePr
 	return err
 }
 
+func (c *adminGRPCClient) PruneRevisions(ctx context.Context, req *rpcpb.PruneRevisionsRequest, opts ...gax.CallOption) (*rpcpb.PruneRevisionsResponse, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).PruneRevisions[0:len((*c.CallOptions).PruneRevisions):len((*c.CallOptions).PruneRevisions)], opts...)
+	var resp *rpcpb.PruneRevisionsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.PruneRevisions(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) ExportProject(ctx context.Context, req *rpcpb.ExportProjectRequest, opts ...gax.CallOption) (*ExportProjectOperation, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ExportProject[0:len((*c.CallOptions).ExportProject):len((*c.CallOptions).ExportProject)], opts...)
+	var resp *longrunningpb.Operation
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ExportProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, resp),
+	}, nil
+}
+
+func (c *adminGRPCClient) ImportProject(ctx context.Context, req *rpcpb.ImportProjectRequest, opts ...gax.CallOption) (*ImportProjectOperation, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).ImportProject[0:len((*c.CallOptions).ImportProject):len((*c.CallOptions).ImportProject)], opts...)
+	var resp *longrunningpb.Operation
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.ImportProject(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, resp),
+	}, nil
+}
+
+func (c *adminGRPCClient) CheckConsistency(ctx context.Context, req *rpcpb.CheckConsistencyRequest, opts ...gax.CallOption) (*CheckConsistencyOperation, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).CheckConsistency[0:len((*c.CallOptions).CheckConsistency):len((*c.CallOptions).CheckConsistency)], opts...)
+	var resp *longrunningpb.Operation
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.CheckConsistency(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckConsistencyOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, resp),
+	}, nil
+}
+
+func (c *adminGRPCClient) SetMaintenanceMode(ctx context.Context, req *rpcpb.SetMaintenanceModeRequest, opts ...gax.CallOption) (*rpcpb.MaintenanceMode, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	opts = append((*c.CallOptions).SetMaintenanceMode[0:len((*c.CallOptions).SetMaintenanceMode):len((*c.CallOptions).SetMaintenanceMode)], opts...)
+	var resp *rpcpb.MaintenanceMode
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.SetMaintenanceMode(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) DownloadProjectArchive(ctx context.Context, req *rpcpb.DownloadProjectArchiveRequest, opts ...gax.CallOption) (rpcpb.Admin_DownloadProjectArchiveClient, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DownloadProjectArchive[0:len((*c.CallOptions).DownloadProjectArchive):len((*c.CallOptions).DownloadProjectArchive)], opts...)
+	var resp rpcpb.Admin_DownloadProjectArchiveClient
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.DownloadProjectArchive(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *adminGRPCClient) UploadProjectArchive(ctx context.Context, opts ...gax.CallOption) (rpcpb.Admin_UploadProjectArchiveClient, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	var resp rpcpb.Admin_UploadProjectArchiveClient
+	opts = append((*c.CallOptions).UploadProjectArchive[0:len((*c.CallOptions).UploadProjectArchive):len((*c.CallOptions).UploadProjectArchive)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.adminClient.UploadProjectArchive(ctx, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // MigrateDatabaseOperation manages a long-running operation from MigrateDatabase.
 type MigrateDatabaseOperation struct {
 	lro *longrunning.Operation
@@ -486,6 +690,213 @@ func (op *MigrateDatabaseOperation) Name() string {
 	return op.lro.Name()
 }
 
+// ExportProjectOperation manages a long-running operation from ExportProject.
+type ExportProjectOperation struct {
+	lro *longrunning.Operation
+}
+
+// ExportProjectOperation returns a new ExportProjectOperation from a given name.
+// The name must be that of a previously created ExportProjectOperation, possibly from a different process.
+func (c *adminGRPCClient) ExportProjectOperation(name string) *ExportProjectOperation {
+	return &ExportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, &longrunningpb.Operation{Name: name}),
+	}
+}
+
+// Wait blocks until the long-running operation is completed, returning the response and any errors encountered.
+//
+// See documentation of Poll for error-handling information.
+func (op *ExportProjectOperation) Wait(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ExportProjectResponse, error) {
+	var resp rpcpb.ExportProjectResponse
+	if err := op.lro.WaitWithInterval(ctx, &resp, time.Minute, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Poll fetches the latest state of the long-running operation.
+//
+// Poll also fetches the latest metadata, which can be retrieved by Metadata.
+//
+// If Poll fails, the error is returned and op is unmodified. If Poll succeeds and
+// the operation has completed with failure, the error is returned and op.Done will return true.
+// If Poll succeeds and the operation has completed successfully,
+// op.Done will return true, and the response of the operation is returned.
+// If Poll succeeds and the operation has not completed, the returned response and error are both nil.
+func (op *ExportProjectOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ExportProjectResponse, error) {
+	var resp rpcpb.ExportProjectResponse
+	if err := op.lro.Poll(ctx, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if !op.Done() {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+// Metadata returns metadata associated with the long-running operation.
+// Metadata itself does not contact the server, but Poll does.
+// To get the latest metadata, call this method after a successful call to Poll.
+// If the metadata is not available, the returned metadata and error are both nil.
+func (op *ExportProjectOperation) Metadata() (*rpcpb.ExportProjectMetadata, error) {
+	var meta rpcpb.ExportProjectMetadata
+	if err := op.lro.Metadata(&meta); err == longrunning.ErrNoMetadata {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Done reports whether the long-running operation has completed.
+func (op *ExportProjectOperation) Done() bool {
+	return op.lro.Done()
+}
+
+// Name returns the name of the long-running operation.
+// The name is assigned by the server and is unique within the service from which the operation is created.
+func (op *ExportProjectOperation) Name() string {
+	return op.lro.Name()
+}
+
+// ImportProjectOperation manages a long-running operation from ImportProject.
+type ImportProjectOperation struct {
+	lro *longrunning.Operation
+}
+
+// ImportProjectOperation returns a new ImportProjectOperation from a given name.
+// The name must be that of a previously created ImportProjectOperation, possibly from a different process.
+func (c *adminGRPCClient) ImportProjectOperation(name string) *ImportProjectOperation {
+	return &ImportProjectOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, &longrunningpb.Operation{Name: name}),
+	}
+}
+
+// Wait blocks until the long-running operation is completed, returning the response and any errors encountered.
+//
+// See documentation of Poll for error-handling information.
+func (op *ImportProjectOperation) Wait(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ImportProjectResponse, error) {
+	var resp rpcpb.ImportProjectResponse
+	if err := op.lro.WaitWithInterval(ctx, &resp, time.Minute, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Poll fetches the latest state of the long-running operation.
+//
+// Poll also fetches the latest metadata, which can be retrieved by Metadata.
+//
+// If Poll fails, the error is returned and op is unmodified. If Poll succeeds and
+// the operation has completed with failure, the error is returned and op.Done will return true.
+// If Poll succeeds and the operation has completed successfully,
+// op.Done will return true, and the response of the operation is returned.
+// If Poll succeeds and the operation has not completed, the returned response and error are both nil.
+func (op *ImportProjectOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*rpcpb.ImportProjectResponse, error) {
+	var resp rpcpb.ImportProjectResponse
+	if err := op.lro.Poll(ctx, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if !op.Done() {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+// Metadata returns metadata associated with the long-running operation.
+// Metadata itself does not contact the server, but Poll does.
+// To get the latest metadata, call this method after a successful call to Poll.
+// If the metadata is not available, the returned metadata and error are both nil.
+func (op *ImportProjectOperation) Metadata() (*rpcpb.ImportProjectMetadata, error) {
+	var meta rpcpb.ImportProjectMetadata
+	if err := op.lro.Metadata(&meta); err == longrunning.ErrNoMetadata {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Done reports whether the long-running operation has completed.
+func (op *ImportProjectOperation) Done() bool {
+	return op.lro.Done()
+}
+
+// Name returns the name of the long-running operation.
+// The name is assigned by the server and is unique within the service from which the operation is created.
+func (op *ImportProjectOperation) Name() string {
+	return op.lro.Name()
+}
+
+// CheckConsistencyOperation manages a long-running operation from CheckConsistency.
+type CheckConsistencyOperation struct {
+	lro *longrunning.Operation
+}
+
+// CheckConsistencyOperation returns a new CheckConsistencyOperation from a given name.
+// The name must be that of a previously created CheckConsistencyOperation, possibly from a different process.
+func (c *adminGRPCClient) CheckConsistencyOperation(name string) *CheckConsistencyOperation {
+	return &CheckConsistencyOperation{
+		lro: longrunning.InternalNewOperation(*c.LROClient, &longrunningpb.Operation{Name: name}),
+	}
+}
+
+// Wait blocks until the long-running operation is completed, returning the response and any errors encountered.
+//
+// See documentation of Poll for error-handling information.
+func (op *CheckConsistencyOperation) Wait(ctx context.Context, opts ...gax.CallOption) (*rpcpb.CheckConsistencyResponse, error) {
+	var resp rpcpb.CheckConsistencyResponse
+	if err := op.lro.WaitWithInterval(ctx, &resp, time.Minute, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Poll fetches the latest state of the long-running operation.
+//
+// Poll also fetches the latest metadata, which can be retrieved by Metadata.
+//
+// If Poll fails, the error is returned and op is unmodified. If Poll succeeds and
+// the operation has completed with failure, the error is returned and op.Done will return true.
+// If Poll succeeds and the operation has completed successfully,
+// op.Done will return true, and the response of the operation is returned.
+// If Poll succeeds and the operation has not completed, the returned response and error are both nil.
+func (op *CheckConsistencyOperation) Poll(ctx context.Context, opts ...gax.CallOption) (*rpcpb.CheckConsistencyResponse, error) {
+	var resp rpcpb.CheckConsistencyResponse
+	if err := op.lro.Poll(ctx, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if !op.Done() {
+		return nil, nil
+	}
+	return &resp, nil
+}
+
+// Metadata returns metadata associated with the long-running operation.
+// Metadata itself does not contact the server, but Poll does.
+// To get the latest metadata, call this method after a successful call to Poll.
+// If the metadata is not available, the returned metadata and error are both nil.
+func (op *CheckConsistencyOperation) Metadata() (*rpcpb.CheckConsistencyMetadata, error) {
+	var meta rpcpb.CheckConsistencyMetadata
+	if err := op.lro.Metadata(&meta); err == longrunning.ErrNoMetadata {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// Done reports whether the long-running operation has completed.
+func (op *CheckConsistencyOperation) Done() bool {
+	return op.lro.Done()
+}
+
+// Name returns the name of the long-running operation.
+// The name is assigned by the server and is unique within the service from which the operation is created.
+func (op *CheckConsistencyOperation) Name() string {
+	return op.lro.Name()
+}
+
 // ProjectIterator manages a stream of *rpcpb.Project.
 type ProjectIterator struct {
 	items    []*rpcpb.Project
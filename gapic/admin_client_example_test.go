@@ -18,6 +18,7 @@ package gapic_test
 
 import (
 	"context"
+	"io"
 
 	gapic "github.com/apigee/registry/gapic"
 	rpcpb "github.com/apigee/registry/rpc"
@@ -185,3 +186,175 @@ func ExampleAdminClient_DeleteProject() {
 		// TODO: Handle error.
 	}
 }
+
+func ExampleAdminClient_PruneRevisions() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.PruneRevisionsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#PruneRevisionsRequest.
+	}
+	resp, err := c.PruneRevisions(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleAdminClient_ExportProject() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ExportProjectRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ExportProjectRequest.
+	}
+	op, err := c.ExportProject(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+
+	resp, err := op.Wait(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleAdminClient_ImportProject() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ImportProjectRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ImportProjectRequest.
+	}
+	op, err := c.ImportProject(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+
+	resp, err := op.Wait(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleAdminClient_CheckConsistency() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.CheckConsistencyRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#CheckConsistencyRequest.
+	}
+	op, err := c.CheckConsistency(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+
+	resp, err := op.Wait(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleAdminClient_SetMaintenanceMode() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.SetMaintenanceModeRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#SetMaintenanceModeRequest.
+	}
+	resp, err := c.SetMaintenanceMode(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleAdminClient_DownloadProjectArchive() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.DownloadProjectArchiveRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#DownloadProjectArchiveRequest.
+	}
+	stream, err := c.DownloadProjectArchive(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
+func ExampleAdminClient_UploadProjectArchive() {
+	ctx := context.Background()
+	c, err := gapic.NewAdminClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	stream, err := c.UploadProjectArchive(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	reqs := []*rpcpb.UploadProjectArchiveRequest{
+		// TODO: Create requests.
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			// TODO: Handle error.
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
@@ -55,6 +55,7 @@ type RegistryCallOptions struct {
 	GetApiSpecContents          []gax.CallOption
 	CreateApiSpec               []gax.CallOption
 	UpdateApiSpec               []gax.CallOption
+	UploadApiSpecContents       []gax.CallOption
 	DeleteApiSpec               []gax.CallOption
 	TagApiSpecRevision          []gax.CallOption
 	ListApiSpecRevisions        []gax.CallOption
@@ -75,6 +76,19 @@ type RegistryCallOptions struct {
 	CreateArtifact              []gax.CallOption
 	ReplaceArtifact             []gax.CallOption
 	DeleteArtifact              []gax.CallOption
+	TagArtifactRevision         []gax.CallOption
+	ListArtifactRevisions       []gax.CallOption
+	RollbackArtifact            []gax.CallOption
+	DeleteArtifactRevision      []gax.CallOption
+	ListApiRevisions            []gax.CallOption
+	ListApiVersionRevisions     []gax.CallOption
+	DownloadApiSpecContents     []gax.CallOption
+	DownloadArtifactContents    []gax.CallOption
+	AggregateResources          []gax.CallOption
+	ListReferences              []gax.CallOption
+	ListRevisionTags            []gax.CallOption
+	DeleteRevisionTag           []gax.CallOption
+	CompareApiSpecRevisions     []gax.CallOption
 }
 
 func defaultRegistryGRPCClientOptions() []option.ClientOption {
@@ -256,6 +270,7 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		UploadApiSpecContents: []gax.CallOption{},
 		DeleteApiSpec: []gax.CallOption{
 			gax.WithRetry(func() gax.Retryer {
 				return gax.OnCodes([]codes.Code{
@@ -476,6 +491,149 @@ func defaultRegistryCallOptions() *RegistryCallOptions {
 				})
 			}),
 		},
+		TagArtifactRevision: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListArtifactRevisions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		RollbackArtifact: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		DeleteArtifactRevision: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListApiRevisions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListApiVersionRevisions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		DownloadApiSpecContents: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		DownloadArtifactContents: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		AggregateResources: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListReferences: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		ListRevisionTags: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		DeleteRevisionTag: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
+		CompareApiSpecRevisions: []gax.CallOption{
+			gax.WithRetry(func() gax.Retryer {
+				return gax.OnCodes([]codes.Code{
+					codes.Unavailable,
+				}, gax.Backoff{
+					Initial:    200 * time.Millisecond,
+					Max:        10000 * time.Millisecond,
+					Multiplier: 1.30,
+				})
+			}),
+		},
 	}
 }
 
@@ -499,6 +657,7 @@ type internalRegistryClient interface {
 	GetApiSpecContents(context.Context, *rpcpb.GetApiSpecContentsRequest, ...gax.CallOption) (*httpbodypb.HttpBody, error)
 	CreateApiSpec(context.Context, *rpcpb.CreateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
 	UpdateApiSpec(context.Context, *rpcpb.UpdateApiSpecRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
+	UploadApiSpecContents(context.Context, ...gax.CallOption) (rpcpb.Registry_UploadApiSpecContentsClient, error)
 	DeleteApiSpec(context.Context, *rpcpb.DeleteApiSpecRequest, ...gax.CallOption) error
 	TagApiSpecRevision(context.Context, *rpcpb.TagApiSpecRevisionRequest, ...gax.CallOption) (*rpcpb.ApiSpec, error)
 	ListApiSpecRevisions(context.Context, *rpcpb.ListApiSpecRevisionsRequest, ...gax.CallOption) *ApiSpecIterator
@@ -519,6 +678,19 @@ type internalRegistryClient interface {
 	CreateArtifact(context.Context, *rpcpb.CreateArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
 	ReplaceArtifact(context.Context, *rpcpb.ReplaceArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
 	DeleteArtifact(context.Context, *rpcpb.DeleteArtifactRequest, ...gax.CallOption) error
+	TagArtifactRevision(context.Context, *rpcpb.TagArtifactRevisionRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	ListArtifactRevisions(context.Context, *rpcpb.ListArtifactRevisionsRequest, ...gax.CallOption) *ArtifactIterator
+	RollbackArtifact(context.Context, *rpcpb.RollbackArtifactRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	DeleteArtifactRevision(context.Context, *rpcpb.DeleteArtifactRevisionRequest, ...gax.CallOption) (*rpcpb.Artifact, error)
+	ListApiRevisions(context.Context, *rpcpb.ListApiRevisionsRequest, ...gax.CallOption) *ApiIterator
+	ListApiVersionRevisions(context.Context, *rpcpb.ListApiVersionRevisionsRequest, ...gax.CallOption) *ApiVersionIterator
+	DownloadApiSpecContents(context.Context, *rpcpb.DownloadApiSpecContentsRequest, ...gax.CallOption) (rpcpb.Registry_DownloadApiSpecContentsClient, error)
+	DownloadArtifactContents(context.Context, *rpcpb.DownloadArtifactContentsRequest, ...gax.CallOption) (rpcpb.Registry_DownloadArtifactContentsClient, error)
+	AggregateResources(context.Context, *rpcpb.AggregateResourcesRequest, ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error)
+	ListReferences(context.Context, *rpcpb.ListReferencesRequest, ...gax.CallOption) (*rpcpb.ListReferencesResponse, error)
+	ListRevisionTags(context.Context, *rpcpb.ListRevisionTagsRequest, ...gax.CallOption) (*rpcpb.ListRevisionTagsResponse, error)
+	DeleteRevisionTag(context.Context, *rpcpb.DeleteRevisionTagRequest, ...gax.CallOption) error
+	CompareApiSpecRevisions(context.Context, *rpcpb.CompareApiSpecRevisionsRequest, ...gax.CallOption) (*rpcpb.CompareApiSpecRevisionsResponse, error)
 }
 
 // RegistryClient is a client for interacting with .
@@ -581,6 +753,13 @@ func (c *RegistryClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApiRequ
 	return c.internalClient.DeleteApi(ctx, req, opts...)
 }
 
+// ListApiRevisions listApiRevisions lists all metadata revisions of an API.
+// A revision is recorded when the API is created and each time its metadata changes.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListApiRevisions(ctx context.Context, req *rpcpb.ListApiRevisionsRequest, opts ...gax.CallOption) *ApiIterator {
+	return c.internalClient.ListApiRevisions(ctx, req, opts...)
+}
+
 // ListApiVersions listApiVersions returns matching versions.
 func (c *RegistryClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
 	return c.internalClient.ListApiVersions(ctx, req, opts...)
@@ -607,6 +786,13 @@ func (c *RegistryClient) DeleteApiVersion(ctx context.Context, req *rpcpb.Delete
 	return c.internalClient.DeleteApiVersion(ctx, req, opts...)
 }
 
+// ListApiVersionRevisions listApiVersionRevisions lists all metadata revisions of a version.
+// A revision is recorded when the version is created and each time its metadata changes.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListApiVersionRevisions(ctx context.Context, req *rpcpb.ListApiVersionRevisionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
+	return c.internalClient.ListApiVersionRevisions(ctx, req, opts...)
+}
+
 // ListApiSpecs listApiSpecs returns matching specs.
 func (c *RegistryClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
 	return c.internalClient.ListApiSpecs(ctx, req, opts...)
@@ -620,11 +806,20 @@ func (c *RegistryClient) GetApiSpec(ctx context.Context, req *rpcpb.GetApiSpecRe
 // GetApiSpecContents getApiSpecContents returns the contents of a specified spec.
 // If specs are stored with GZip compression, the default behavior
 // is to return the spec uncompressed (the mime_type response field
-// indicates the exact format returned).
+// indicates the exact format returned). Callers may also request
+// that contents be converted to JSON or YAML or that a single file
+// be extracted from a zip archive.
 func (c *RegistryClient) GetApiSpecContents(ctx context.Context, req *rpcpb.GetApiSpecContentsRequest, opts ...gax.CallOption) (*httpbodypb.HttpBody, error) {
 	return c.internalClient.GetApiSpecContents(ctx, req, opts...)
 }
 
+// DownloadApiSpecContents downloadApiSpecContents streams the contents of a specified spec in chunks.
+// The contents are the same as those returned by GetApiSpecContents,
+// but specs of any size can be downloaded without reaching message size limits.
+func (c *RegistryClient) DownloadApiSpecContents(ctx context.Context, req *rpcpb.DownloadApiSpecContentsRequest, opts ...gax.CallOption) (rpcpb.Registry_DownloadApiSpecContentsClient, error) {
+	return c.internalClient.DownloadApiSpecContents(ctx, req, opts...)
+}
+
 // CreateApiSpec createApiSpec creates a specified spec.
 func (c *RegistryClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
 	return c.internalClient.CreateApiSpec(ctx, req, opts...)
@@ -635,6 +830,13 @@ func (c *RegistryClient) UpdateApiSpec(ctx context.Context, req *rpcpb.UpdateApi
 	return c.internalClient.UpdateApiSpec(ctx, req, opts...)
 }
 
+// UploadApiSpecContents uploadApiSpecContents replaces the contents of a specified spec with
+// contents streamed in chunks. Specs of any size can be uploaded without
+// reaching message size limits.
+func (c *RegistryClient) UploadApiSpecContents(ctx context.Context, opts ...gax.CallOption) (rpcpb.Registry_UploadApiSpecContentsClient, error) {
+	return c.internalClient.UploadApiSpecContents(ctx, opts...)
+}
+
 // DeleteApiSpec deleteApiSpec removes a specified spec, all revisions, and all child
 // resources (e.g. artifacts).
 func (c *RegistryClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
@@ -642,6 +844,7 @@ func (c *RegistryClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApi
 }
 
 // TagApiSpecRevision tagApiSpecRevision adds a tag to a specified revision of a spec.
+// If the tag is on another revision of the spec, it is moved.
 func (c *RegistryClient) TagApiSpecRevision(ctx context.Context, req *rpcpb.TagApiSpecRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
 	return c.internalClient.TagApiSpecRevision(ctx, req, opts...)
 }
@@ -690,7 +893,8 @@ func (c *RegistryClient) DeleteApiDeployment(ctx context.Context, req *rpcpb.Del
 }
 
 // TagApiDeploymentRevision tagApiDeploymentRevision adds a tag to a specified revision of a
-// deployment.
+// deployment. If the tag is on another revision of the deployment, it is
+// moved.
 func (c *RegistryClient) TagApiDeploymentRevision(ctx context.Context, req *rpcpb.TagApiDeploymentRevisionRequest, opts ...gax.CallOption) (*rpcpb.ApiDeployment, error) {
 	return c.internalClient.TagApiDeploymentRevision(ctx, req, opts...)
 }
@@ -730,6 +934,13 @@ func (c *RegistryClient) GetArtifactContents(ctx context.Context, req *rpcpb.Get
 	return c.internalClient.GetArtifactContents(ctx, req, opts...)
 }
 
+// DownloadArtifactContents downloadArtifactContents streams the contents of a specified artifact in chunks.
+// The contents are the same as those returned by GetArtifactContents,
+// but artifacts of any size can be downloaded without reaching message size limits.
+func (c *RegistryClient) DownloadArtifactContents(ctx context.Context, req *rpcpb.DownloadArtifactContentsRequest, opts ...gax.CallOption) (rpcpb.Registry_DownloadArtifactContentsClient, error) {
+	return c.internalClient.DownloadArtifactContents(ctx, req, opts...)
+}
+
 // CreateArtifact createArtifact creates a specified artifact.
 func (c *RegistryClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
 	return c.internalClient.CreateArtifact(ctx, req, opts...)
@@ -745,6 +956,57 @@ func (c *RegistryClient) DeleteArtifact(ctx context.Context, req *rpcpb.DeleteAr
 	return c.internalClient.DeleteArtifact(ctx, req, opts...)
 }
 
+// TagArtifactRevision tagArtifactRevision adds a tag to a specified revision of an artifact.
+func (c *RegistryClient) TagArtifactRevision(ctx context.Context, req *rpcpb.TagArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.TagArtifactRevision(ctx, req, opts...)
+}
+
+// ListArtifactRevisions listArtifactRevisions lists all revisions of an artifact.
+// Revisions are returned in descending order of revision creation time.
+func (c *RegistryClient) ListArtifactRevisions(ctx context.Context, req *rpcpb.ListArtifactRevisionsRequest, opts ...gax.CallOption) *ArtifactIterator {
+	return c.internalClient.ListArtifactRevisions(ctx, req, opts...)
+}
+
+// RollbackArtifact rollbackArtifact sets the current revision to a specified prior revision.
+// Note that this creates a new revision with a new revision ID.
+func (c *RegistryClient) RollbackArtifact(ctx context.Context, req *rpcpb.RollbackArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.RollbackArtifact(ctx, req, opts...)
+}
+
+// DeleteArtifactRevision deleteArtifactRevision deletes a revision of an artifact.
+func (c *RegistryClient) DeleteArtifactRevision(ctx context.Context, req *rpcpb.DeleteArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	return c.internalClient.DeleteArtifactRevision(ctx, req, opts...)
+}
+
+// AggregateResources aggregateResources counts the resources in a collection, grouped by the
+// values of specified fields.
+func (c *RegistryClient) AggregateResources(ctx context.Context, req *rpcpb.AggregateResourcesRequest, opts ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error) {
+	return c.internalClient.AggregateResources(ctx, req, opts...)
+}
+
+// ListReferences listReferences lists the resources that refer to a specified resource,
+// such as the deployments that serve a spec revision.
+func (c *RegistryClient) ListReferences(ctx context.Context, req *rpcpb.ListReferencesRequest, opts ...gax.CallOption) (*rpcpb.ListReferencesResponse, error) {
+	return c.internalClient.ListReferences(ctx, req, opts...)
+}
+
+// ListRevisionTags listRevisionTags lists the tags of the revisions of a spec or deployment.
+func (c *RegistryClient) ListRevisionTags(ctx context.Context, req *rpcpb.ListRevisionTagsRequest, opts ...gax.CallOption) (*rpcpb.ListRevisionTagsResponse, error) {
+	return c.internalClient.ListRevisionTags(ctx, req, opts...)
+}
+
+// DeleteRevisionTag deleteRevisionTag removes a tag from a revision of a spec or deployment.
+// The revision itself is not changed.
+func (c *RegistryClient) DeleteRevisionTag(ctx context.Context, req *rpcpb.DeleteRevisionTagRequest, opts ...gax.CallOption) error {
+	return c.internalClient.DeleteRevisionTag(ctx, req, opts...)
+}
+
+// CompareApiSpecRevisions compareApiSpecRevisions returns the differences between two spec
+// revisions and classifies them as breaking or non-breaking changes.
+func (c *RegistryClient) CompareApiSpecRevisions(ctx context.Context, req *rpcpb.CompareApiSpecRevisionsRequest, opts ...gax.CallOption) (*rpcpb.CompareApiSpecRevisionsResponse, error) {
+	return c.internalClient.CompareApiSpecRevisions(ctx, req, opts...)
+}
+
 // registryGRPCClient is a client for interacting with  over gRPC transport.
 //
 // Methods, except Close, may be called concurrently. However, fields must not be modified concurrently with method calls.
@@ -949,6 +1211,50 @@ func (c *registryGRPCClient) DeleteApi(ctx context.Context, req *rpcpb.DeleteApi
 	return err
 }
 
+func (c *registryGRPCClient) ListApiRevisions(ctx context.Context, req *rpcpb.ListApiRevisionsRequest, opts ...gax.CallOption) *ApiIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiRevisions[0:len((*c.CallOptions).ListApiRevisions):len((*c.CallOptions).ListApiRevisions)], opts...)
+	it := &ApiIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Api, string, error) {
+		resp := &rpcpb.ListApiRevisionsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiRevisions(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApis(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
 func (c *registryGRPCClient) ListApiVersions(ctx context.Context, req *rpcpb.ListApiVersionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
@@ -1073,6 +1379,50 @@ func (c *registryGRPCClient) DeleteApiVersion(ctx context.Context, req *rpcpb.De
 	return err
 }
 
+func (c *registryGRPCClient) ListApiVersionRevisions(ctx context.Context, req *rpcpb.ListApiVersionRevisionsRequest, opts ...gax.CallOption) *ApiVersionIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListApiVersionRevisions[0:len((*c.CallOptions).ListApiVersionRevisions):len((*c.CallOptions).ListApiVersionRevisions)], opts...)
+	it := &ApiVersionIterator{}
+	req = proto.Clone(req).(*rpcpb.ListApiVersionRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.ApiVersion, string, error) {
+		resp := &rpcpb.ListApiVersionRevisionsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListApiVersionRevisions(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetApiVersions(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
 func (c *registryGRPCClient) ListApiSpecs(ctx context.Context, req *rpcpb.ListApiSpecsRequest, opts ...gax.CallOption) *ApiSpecIterator {
 	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
 	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
@@ -1159,6 +1509,22 @@ func (c *registryGRPCClient) GetApiSpecContents(ctx context.Context, req *rpcpb.
 	return resp, nil
 }
 
+func (c *registryGRPCClient) DownloadApiSpecContents(ctx context.Context, req *rpcpb.DownloadApiSpecContentsRequest, opts ...gax.CallOption) (rpcpb.Registry_DownloadApiSpecContentsClient, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DownloadApiSpecContents[0:len((*c.CallOptions).DownloadApiSpecContents):len((*c.CallOptions).DownloadApiSpecContents)], opts...)
+	var resp rpcpb.Registry_DownloadApiSpecContentsClient
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.DownloadApiSpecContents(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *registryGRPCClient) CreateApiSpec(ctx context.Context, req *rpcpb.CreateApiSpecRequest, opts ...gax.CallOption) (*rpcpb.ApiSpec, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
@@ -1201,6 +1567,21 @@ func (c *registryGRPCClient) UpdateApiSpec(ctx context.Context, req *rpcpb.Updat
 	return resp, nil
 }
 
+func (c *registryGRPCClient) UploadApiSpecContents(ctx context.Context, opts ...gax.CallOption) (rpcpb.Registry_UploadApiSpecContentsClient, error) {
+	ctx = insertMetadata(ctx, c.xGoogMetadata)
+	var resp rpcpb.Registry_UploadApiSpecContentsClient
+	opts = append((*c.CallOptions).UploadApiSpecContents[0:len((*c.CallOptions).UploadApiSpecContents):len((*c.CallOptions).UploadApiSpecContents)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.UploadApiSpecContents(ctx, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *registryGRPCClient) DeleteApiSpec(ctx context.Context, req *rpcpb.DeleteApiSpecRequest, opts ...gax.CallOption) error {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
@@ -1642,6 +2023,22 @@ func (c *registryGRPCClient) GetArtifactContents(ctx context.Context, req *rpcpb
 	return resp, nil
 }
 
+func (c *registryGRPCClient) DownloadArtifactContents(ctx context.Context, req *rpcpb.DownloadArtifactContentsRequest, opts ...gax.CallOption) (rpcpb.Registry_DownloadArtifactContentsClient, error) {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DownloadArtifactContents[0:len((*c.CallOptions).DownloadArtifactContents):len((*c.CallOptions).DownloadArtifactContents)], opts...)
+	var resp rpcpb.Registry_DownloadArtifactContentsClient
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.DownloadArtifactContents(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *registryGRPCClient) CreateArtifact(ctx context.Context, req *rpcpb.CreateArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
 	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
 		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
@@ -1701,6 +2098,214 @@ func (c *registryGRPCClient) DeleteArtifact(ctx context.Context, req *rpcpb.Dele
 	return err
 }
 
+func (c *registryGRPCClient) TagArtifactRevision(ctx context.Context, req *rpcpb.TagArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).TagArtifactRevision[0:len((*c.CallOptions).TagArtifactRevision):len((*c.CallOptions).TagArtifactRevision)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.TagArtifactRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) ListArtifactRevisions(ctx context.Context, req *rpcpb.ListArtifactRevisionsRequest, opts ...gax.CallOption) *ArtifactIterator {
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListArtifactRevisions[0:len((*c.CallOptions).ListArtifactRevisions):len((*c.CallOptions).ListArtifactRevisions)], opts...)
+	it := &ArtifactIterator{}
+	req = proto.Clone(req).(*rpcpb.ListArtifactRevisionsRequest)
+	it.InternalFetch = func(pageSize int, pageToken string) ([]*rpcpb.Artifact, string, error) {
+		resp := &rpcpb.ListArtifactRevisionsResponse{}
+		if pageToken != "" {
+			req.PageToken = pageToken
+		}
+		if pageSize > math.MaxInt32 {
+			req.PageSize = math.MaxInt32
+		} else if pageSize != 0 {
+			req.PageSize = int32(pageSize)
+		}
+		err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+			var err error
+			resp, err = c.registryClient.ListArtifactRevisions(ctx, req, settings.GRPC...)
+			return err
+		}, opts...)
+		if err != nil {
+			return nil, "", err
+		}
+
+		it.Response = resp
+		return resp.GetArtifacts(), resp.GetNextPageToken(), nil
+	}
+	fetch := func(pageSize int, pageToken string) (string, error) {
+		items, nextPageToken, err := it.InternalFetch(pageSize, pageToken)
+		if err != nil {
+			return "", err
+		}
+		it.items = append(it.items, items...)
+		return nextPageToken, nil
+	}
+
+	it.pageInfo, it.nextFunc = iterator.NewPageInfo(fetch, it.bufLen, it.takeBuf)
+	it.pageInfo.MaxSize = int(req.GetPageSize())
+	it.pageInfo.Token = req.GetPageToken()
+
+	return it
+}
+
+func (c *registryGRPCClient) RollbackArtifact(ctx context.Context, req *rpcpb.RollbackArtifactRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).RollbackArtifact[0:len((*c.CallOptions).RollbackArtifact):len((*c.CallOptions).RollbackArtifact)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.RollbackArtifact(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) DeleteArtifactRevision(ctx context.Context, req *rpcpb.DeleteArtifactRevisionRequest, opts ...gax.CallOption) (*rpcpb.Artifact, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteArtifactRevision[0:len((*c.CallOptions).DeleteArtifactRevision):len((*c.CallOptions).DeleteArtifactRevision)], opts...)
+	var resp *rpcpb.Artifact
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.DeleteArtifactRevision(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) AggregateResources(ctx context.Context, req *rpcpb.AggregateResourcesRequest, opts ...gax.CallOption) (*rpcpb.AggregateResourcesResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "parent", url.QueryEscape(req.GetParent())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).AggregateResources[0:len((*c.CallOptions).AggregateResources):len((*c.CallOptions).AggregateResources)], opts...)
+	var resp *rpcpb.AggregateResourcesResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.AggregateResources(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) ListReferences(ctx context.Context, req *rpcpb.ListReferencesRequest, opts ...gax.CallOption) (*rpcpb.ListReferencesResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListReferences[0:len((*c.CallOptions).ListReferences):len((*c.CallOptions).ListReferences)], opts...)
+	var resp *rpcpb.ListReferencesResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.ListReferences(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) ListRevisionTags(ctx context.Context, req *rpcpb.ListRevisionTagsRequest, opts ...gax.CallOption) (*rpcpb.ListRevisionTagsResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).ListRevisionTags[0:len((*c.CallOptions).ListRevisionTags):len((*c.CallOptions).ListRevisionTags)], opts...)
+	var resp *rpcpb.ListRevisionTagsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.ListRevisionTags(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *registryGRPCClient) DeleteRevisionTag(ctx context.Context, req *rpcpb.DeleteRevisionTagRequest, opts ...gax.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).DeleteRevisionTag[0:len((*c.CallOptions).DeleteRevisionTag):len((*c.CallOptions).DeleteRevisionTag)], opts...)
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		_, err = c.registryClient.DeleteRevisionTag(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	return err
+}
+
+func (c *registryGRPCClient) CompareApiSpecRevisions(ctx context.Context, req *rpcpb.CompareApiSpecRevisionsRequest, opts ...gax.CallOption) (*rpcpb.CompareApiSpecRevisionsResponse, error) {
+	if _, ok := ctx.Deadline(); !ok && !c.disableDeadlines {
+		cctx, cancel := context.WithTimeout(ctx, 10000*time.Millisecond)
+		defer cancel()
+		ctx = cctx
+	}
+	md := metadata.Pairs("x-goog-request-params", fmt.Sprintf("%s=%v", "name", url.QueryEscape(req.GetName())))
+	ctx = insertMetadata(ctx, c.xGoogMetadata, md)
+	opts = append((*c.CallOptions).CompareApiSpecRevisions[0:len((*c.CallOptions).CompareApiSpecRevisions):len((*c.CallOptions).CompareApiSpecRevisions)], opts...)
+	var resp *rpcpb.CompareApiSpecRevisionsResponse
+	err := gax.Invoke(ctx, func(ctx context.Context, settings gax.CallSettings) error {
+		var err error
+		resp, err = c.registryClient.CompareApiSpecRevisions(ctx, req, settings.GRPC...)
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // ApiDeploymentIterator manages a stream of *rpcpb.ApiDeployment.
 type ApiDeploymentIterator struct {
 	items    []*rpcpb.ApiDeployment
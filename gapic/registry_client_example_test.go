@@ -18,6 +18,7 @@ package gapic_test
 
 import (
 	"context"
+	"io"
 
 	gapic "github.com/apigee/registry/gapic"
 	rpcpb "github.com/apigee/registry/rpc"
@@ -140,6 +141,32 @@ func ExampleRegistryClient_DeleteApi() {
 	}
 }
 
+func ExampleRegistryClient_ListApiRevisions() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ListApiRevisionsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ListApiRevisionsRequest.
+	}
+	it := c.ListApiRevisions(ctx, req)
+	for {
+		resp, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
 func ExampleRegistryClient_ListApiVersions() {
 	ctx := context.Background()
 	c, err := gapic.NewRegistryClient(ctx)
@@ -244,6 +271,32 @@ func ExampleRegistryClient_DeleteApiVersion() {
 	}
 }
 
+func ExampleRegistryClient_ListApiVersionRevisions() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ListApiVersionRevisionsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ListApiVersionRevisionsRequest.
+	}
+	it := c.ListApiVersionRevisions(ctx, req)
+	for {
+		resp, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
 func ExampleRegistryClient_ListApiSpecs() {
 	ctx := context.Background()
 	c, err := gapic.NewRegistryClient(ctx)
@@ -310,6 +363,35 @@ func ExampleRegistryClient_GetApiSpecContents() {
 	_ = resp
 }
 
+func ExampleRegistryClient_DownloadApiSpecContents() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.DownloadApiSpecContentsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#DownloadApiSpecContentsRequest.
+	}
+	stream, err := c.DownloadApiSpecContents(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
 func ExampleRegistryClient_CreateApiSpec() {
 	ctx := context.Background()
 	c, err := gapic.NewRegistryClient(ctx)
@@ -350,6 +432,34 @@ func ExampleRegistryClient_UpdateApiSpec() {
 	_ = resp
 }
 
+func ExampleRegistryClient_UploadApiSpecContents() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	stream, err := c.UploadApiSpecContents(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	reqs := []*rpcpb.UploadApiSpecContentsRequest{
+		// TODO: Create requests.
+	}
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			// TODO: Handle error.
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
 func ExampleRegistryClient_DeleteApiSpec() {
 	ctx := context.Background()
 	c, err := gapic.NewRegistryClient(ctx)
@@ -710,6 +820,35 @@ func ExampleRegistryClient_GetArtifactContents() {
 	_ = resp
 }
 
+func ExampleRegistryClient_DownloadArtifactContents() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.DownloadArtifactContentsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#DownloadArtifactContentsRequest.
+	}
+	stream, err := c.DownloadArtifactContents(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
 func ExampleRegistryClient_CreateArtifact() {
 	ctx := context.Background()
 	c, err := gapic.NewRegistryClient(ctx)
@@ -767,3 +906,187 @@ func ExampleRegistryClient_DeleteArtifact() {
 		// TODO: Handle error.
 	}
 }
+
+func ExampleRegistryClient_TagArtifactRevision() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.TagArtifactRevisionRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#TagArtifactRevisionRequest.
+	}
+	resp, err := c.TagArtifactRevision(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_ListArtifactRevisions() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ListArtifactRevisionsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ListArtifactRevisionsRequest.
+	}
+	it := c.ListArtifactRevisions(ctx, req)
+	for {
+		resp, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			// TODO: Handle error.
+		}
+		// TODO: Use resp.
+		_ = resp
+	}
+}
+
+func ExampleRegistryClient_RollbackArtifact() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.RollbackArtifactRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#RollbackArtifactRequest.
+	}
+	resp, err := c.RollbackArtifact(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_DeleteArtifactRevision() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.DeleteArtifactRevisionRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#DeleteArtifactRevisionRequest.
+	}
+	resp, err := c.DeleteArtifactRevision(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_AggregateResources() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.AggregateResourcesRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#AggregateResourcesRequest.
+	}
+	resp, err := c.AggregateResources(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_ListReferences() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ListReferencesRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ListReferencesRequest.
+	}
+	resp, err := c.ListReferences(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_ListRevisionTags() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.ListRevisionTagsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#ListRevisionTagsRequest.
+	}
+	resp, err := c.ListRevisionTags(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
+
+func ExampleRegistryClient_DeleteRevisionTag() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.DeleteRevisionTagRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#DeleteRevisionTagRequest.
+	}
+	err = c.DeleteRevisionTag(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+}
+
+func ExampleRegistryClient_CompareApiSpecRevisions() {
+	ctx := context.Background()
+	c, err := gapic.NewRegistryClient(ctx)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	defer c.Close()
+
+	req := &rpcpb.CompareApiSpecRevisionsRequest{
+		// TODO: Fill request struct fields.
+		// See https://pkg.go.dev/github.com/apigee/registry/rpc#CompareApiSpecRevisionsRequest.
+	}
+	resp, err := c.CompareApiSpecRevisions(ctx, req)
+	if err != nil {
+		// TODO: Handle error.
+	}
+	// TODO: Use resp.
+	_ = resp
+}
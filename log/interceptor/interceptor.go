@@ -52,6 +52,8 @@ func CallLogger(opts ...log.Option) grpc.UnaryServerInterceptor {
 			reqInfo["collection"] = r.GetParent()
 		}
 
+		addTraceID(ctx, reqInfo)
+
 		// Bind request-scoped and inbound attributes to the context logger before handling the request.
 		logger := log.WithInboundFields(ctx, sharedLogger).WithFields(reqInfo)
 		ctx = log.NewContext(ctx, logger)
@@ -70,26 +72,63 @@ func CallLogger(opts ...log.Option) grpc.UnaryServerInterceptor {
 		}
 
 		// Bind response details before logging a response.
-		logger = logger.WithFields(respInfo)
+		logResult(logger.WithFields(respInfo), err)
+		return resp, err
+	}
+}
 
-		// Error messages may include a status code, but we want to log messages and codes separately.
-		if err != nil {
-			st, _ := status.FromError(err)
-			unwrapped := errors.New(st.Message())
-			logger = logger.WithError(unwrapped)
+// StreamCallLogger returns a gRPC server interceptor for logging streaming API operations.
+// Streamed messages aren't inspected, so only the method, duration, and status are logged.
+func StreamCallLogger(opts ...log.Option) grpc.StreamServerInterceptor {
+	sharedLogger := log.NewLogger(opts...)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		reqInfo := map[string]interface{}{
+			"request_id": fmt.Sprintf("%.8s", uuid.New()),
+			"method":     filepath.Base(info.FullMethod),
 		}
+		addTraceID(ss.Context(), reqInfo)
 
-		switch status.Code(err) {
-		case codes.OK:
-			logger.Info("Success.")
-		case codes.Internal:
-			logger.Error("Internal error.")
-		case codes.Unknown:
-			logger.Error("Unknown error.")
-		default:
-			logger.Info("User error.")
-		}
+		logger := log.WithInboundFields(ss.Context(), sharedLogger).WithFields(reqInfo)
+		ss = &loggedStream{ServerStream: ss, ctx: log.NewContext(ss.Context(), logger)}
 
-		return resp, err
+		logger.Info("Handling request.")
+		start := time.Now()
+		err := handler(srv, ss)
+
+		logResult(logger.WithFields(map[string]interface{}{
+			"duration":    time.Since(start),
+			"status_code": status.Code(err),
+		}), err)
+		return err
+	}
+}
+
+// loggedStream replaces the context of a server stream with one that carries a request-scoped logger.
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
+
+func logResult(logger log.Logger, err error) {
+	// Error messages may include a status code, but we want to log messages and codes separately.
+	if err != nil {
+		st, _ := status.FromError(err)
+		unwrapped := errors.New(st.Message())
+		logger = logger.WithError(unwrapped)
+	}
+
+	switch status.Code(err) {
+	case codes.OK:
+		logger.Info("Success.")
+	case codes.Internal:
+		logger.Error("Internal error.")
+	case codes.Unknown:
+		logger.Error("Unknown error.")
+	default:
+		logger.Info("User error.")
 	}
 }
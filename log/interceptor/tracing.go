@@ -0,0 +1,42 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// CallTracer returns a gRPC server interceptor that creates a span for each API operation.
+// Trace context propagated by callers is continued. It should precede CallLogger so that
+// log entries include the trace ID.
+func CallTracer() grpc.UnaryServerInterceptor {
+	return otelgrpc.UnaryServerInterceptor()
+}
+
+// StreamCallTracer returns a gRPC server interceptor that creates a span for each streaming API operation.
+func StreamCallTracer() grpc.StreamServerInterceptor {
+	return otelgrpc.StreamServerInterceptor()
+}
+
+// addTraceID adds the ID of the current trace, if any, to a set of log fields.
+func addTraceID(ctx context.Context, fields map[string]interface{}) {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields["trace_id"] = sc.TraceID().String()
+	}
+}
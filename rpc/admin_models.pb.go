@@ -27,6 +27,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -37,6 +38,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Modes of server-side validation of spec contents.
+type Project_SpecValidation int32
+
+const (
+	// Validation mode not specified. Spec contents are not validated.
+	Project_SPEC_VALIDATION_UNSPECIFIED Project_SpecValidation = 0
+	// Spec contents are not validated.
+	Project_OFF Project_SpecValidation = 1
+	// Spec contents are validated and failures are logged,
+	// but specs with invalid contents are still saved.
+	Project_WARN Project_SpecValidation = 2
+	// Spec contents are validated and specs with invalid contents are rejected.
+	Project_ENFORCE Project_SpecValidation = 3
+)
+
+// Enum value maps for Project_SpecValidation.
+var (
+	Project_SpecValidation_name = map[int32]string{
+		0: "SPEC_VALIDATION_UNSPECIFIED",
+		1: "OFF",
+		2: "WARN",
+		3: "ENFORCE",
+	}
+	Project_SpecValidation_value = map[string]int32{
+		"SPEC_VALIDATION_UNSPECIFIED": 0,
+		"OFF":                         1,
+		"WARN":                        2,
+		"ENFORCE":                     3,
+	}
+)
+
+func (x Project_SpecValidation) Enum() *Project_SpecValidation {
+	p := new(Project_SpecValidation)
+	*p = x
+	return p
+}
+
+func (x Project_SpecValidation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Project_SpecValidation) Descriptor() protoreflect.EnumDescriptor {
+	return file_google_cloud_apigeeregistry_v1_admin_models_proto_enumTypes[0].Descriptor()
+}
+
+func (Project_SpecValidation) Type() protoreflect.EnumType {
+	return &file_google_cloud_apigeeregistry_v1_admin_models_proto_enumTypes[0]
+}
+
+func (x Project_SpecValidation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Project_SpecValidation.Descriptor instead.
+func (Project_SpecValidation) EnumDescriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescGZIP(), []int{0, 0}
+}
+
 // A Project is a top-level description of a collection of APIs.
 // Typically there would be one project for an entire organization.
 // Note: in a Google Cloud deployment, this resource and associated methods
@@ -57,6 +116,17 @@ type Project struct {
 	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 	// Last update timestamp.
 	UpdateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// Policy used to prune old revisions of specs and deployments in the project.
+	// If unset, revisions are kept indefinitely.
+	RevisionRetentionPolicy *RevisionRetentionPolicy `protobuf:"bytes,6,opt,name=revision_retention_policy,json=revisionRetentionPolicy,proto3" json:"revision_retention_policy,omitempty"`
+	// How spec contents in the project are validated against their MIME types.
+	SpecValidation Project_SpecValidation `protobuf:"varint,7,opt,name=spec_validation,json=specValidation,proto3,enum=google.cloud.apigeeregistry.v1.Project_SpecValidation" json:"spec_validation,omitempty"`
+	// Lifecycle that constrains the availability of APIs in the project.
+	// If unset, availability is a free-form string.
+	ApiLifecycle *Lifecycle `protobuf:"bytes,8,opt,name=api_lifecycle,json=apiLifecycle,proto3" json:"api_lifecycle,omitempty"`
+	// Lifecycle that constrains the state of API versions in the project.
+	// If unset, state is a free-form string.
+	VersionLifecycle *Lifecycle `protobuf:"bytes,9,opt,name=version_lifecycle,json=versionLifecycle,proto3" json:"version_lifecycle,omitempty"`
 }
 
 func (x *Project) Reset() {
@@ -126,6 +196,229 @@ func (x *Project) GetUpdateTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Project) GetRevisionRetentionPolicy() *RevisionRetentionPolicy {
+	if x != nil {
+		return x.RevisionRetentionPolicy
+	}
+	return nil
+}
+
+func (x *Project) GetSpecValidation() Project_SpecValidation {
+	if x != nil {
+		return x.SpecValidation
+	}
+	return Project_SPEC_VALIDATION_UNSPECIFIED
+}
+
+func (x *Project) GetApiLifecycle() *Lifecycle {
+	if x != nil {
+		return x.ApiLifecycle
+	}
+	return nil
+}
+
+func (x *Project) GetVersionLifecycle() *Lifecycle {
+	if x != nil {
+		return x.VersionLifecycle
+	}
+	return nil
+}
+
+// A RevisionRetentionPolicy limits the revisions kept for each spec and
+// deployment in a project. A revision is pruned when it falls outside any of
+// the configured limits. The current revision and tagged revisions are always
+// kept.
+type RevisionRetentionPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of revisions to keep for each resource, counting from
+	// the most recent. If zero, the number of revisions is not limited.
+	MaxRevisions int32 `protobuf:"varint,1,opt,name=max_revisions,json=maxRevisions,proto3" json:"max_revisions,omitempty"`
+	// The maximum age of revisions to keep, measured from revision creation
+	// time. If unset or zero, revisions are kept regardless of age.
+	MaxAge *durationpb.Duration `protobuf:"bytes,2,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+}
+
+func (x *RevisionRetentionPolicy) Reset() {
+	*x = RevisionRetentionPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevisionRetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisionRetentionPolicy) ProtoMessage() {}
+
+func (x *RevisionRetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisionRetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RevisionRetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RevisionRetentionPolicy) GetMaxRevisions() int32 {
+	if x != nil {
+		return x.MaxRevisions
+	}
+	return 0
+}
+
+func (x *RevisionRetentionPolicy) GetMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+// A Lifecycle defines the states that a resource can be in, the transitions
+// between them, and the fields that resources in each state must set.
+// States are matched case-insensitively and saved with the case used in the
+// lifecycle, so an availability of "ga" is saved as "GA".
+type Lifecycle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The states of the lifecycle.
+	States []*Lifecycle_State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// The state of new resources. New resources that don't set a state start in
+	// it and can't be created in other states. If unset, new resources can start
+	// in any state or leave their state empty.
+	InitialState string `protobuf:"bytes,2,opt,name=initial_state,json=initialState,proto3" json:"initial_state,omitempty"`
+}
+
+func (x *Lifecycle) Reset() {
+	*x = Lifecycle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lifecycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lifecycle) ProtoMessage() {}
+
+func (x *Lifecycle) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lifecycle.ProtoReflect.Descriptor instead.
+func (*Lifecycle) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Lifecycle) GetStates() []*Lifecycle_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *Lifecycle) GetInitialState() string {
+	if x != nil {
+		return x.InitialState
+	}
+	return ""
+}
+
+// A state of a lifecycle.
+type Lifecycle_State struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the state, such as "GA" or "DEPRECATED".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The states that resources in this state can move to.
+	// A state without transitions is final.
+	Transitions []string `protobuf:"bytes,2,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	// Fields that resources in this state must set, such as "description",
+	// "labels.owner" or "annotations.deprecation-date".
+	RequiredFields []string `protobuf:"bytes,3,rep,name=required_fields,json=requiredFields,proto3" json:"required_fields,omitempty"`
+}
+
+func (x *Lifecycle_State) Reset() {
+	*x = Lifecycle_State{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lifecycle_State) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lifecycle_State) ProtoMessage() {}
+
+func (x *Lifecycle_State) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lifecycle_State.ProtoReflect.Descriptor instead.
+func (*Lifecycle_State) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Lifecycle_State) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Lifecycle_State) GetTransitions() []string {
+	if x != nil {
+		return x.Transitions
+	}
+	return nil
+}
+
+func (x *Lifecycle_State) GetRequiredFields() []string {
+	if x != nil {
+		return x.RequiredFields
+	}
+	return nil
+}
+
 var File_google_cloud_apigeeregistry_v1_admin_models_proto protoreflect.FileDescriptor
 
 var file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDesc = []byte{
@@ -138,9 +431,11 @@ var file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDesc = []byte{
 	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69,
 	0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xa6, 0x02, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x22, 0xf7, 0x05, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e,
@@ -154,17 +449,68 @@ var file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDesc = []byte{
 	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x03, 0xe0, 0x41, 0x03, 0x52, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x3a, 0x3e, 0xea, 0x41, 0x3b, 0x0a, 0x25,
-	0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2f,
-	0x7b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x7d, 0x42, 0x5c, 0x0a, 0x22, 0x63, 0x6f, 0x6d,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70,
-	0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x42,
-	0x10, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f,
-	0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x73, 0x0a, 0x19, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x17, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x5f,
+	0x0a, 0x0f, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x2e, 0x53, 0x70, 0x65, 0x63, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0e, 0x73, 0x70, 0x65, 0x63, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x4e, 0x0a, 0x0d, 0x61, 0x70, 0x69, 0x5f, 0x6c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x52, 0x0c, 0x61, 0x70, 0x69, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12,
+	0x56, 0x0a, 0x11, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x66, 0x65, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x66, 0x65,
+	0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x10, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x22, 0x51, 0x0a, 0x0e, 0x53, 0x70, 0x65, 0x63, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x50, 0x45,
+	0x43, 0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x46,
+	0x46, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x0b, 0x0a,
+	0x07, 0x45, 0x4e, 0x46, 0x4f, 0x52, 0x43, 0x45, 0x10, 0x03, 0x3a, 0x3e, 0xea, 0x41, 0x3b, 0x0a,
+	0x25, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x61, 0x70, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2f, 0x7b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x7d, 0x22, 0x72, 0x0a, 0x17, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61,
+	0x78, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x6d, 0x61,
+	0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x22, 0xe1,
+	0x01, 0x0a, 0x09, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x47, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x1a, 0x66, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x42, 0x5c, 0x0a, 0x22, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x4d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -179,19 +525,31 @@ func file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescGZIP() []byte
 	return file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDescData
 }
 
-var file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_google_cloud_apigeeregistry_v1_admin_models_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_google_cloud_apigeeregistry_v1_admin_models_proto_goTypes = []interface{}{
-	(*Project)(nil),               // 0: google.cloud.apigeeregistry.v1.Project
-	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(Project_SpecValidation)(0),     // 0: google.cloud.apigeeregistry.v1.Project.SpecValidation
+	(*Project)(nil),                 // 1: google.cloud.apigeeregistry.v1.Project
+	(*RevisionRetentionPolicy)(nil), // 2: google.cloud.apigeeregistry.v1.RevisionRetentionPolicy
+	(*Lifecycle)(nil),               // 3: google.cloud.apigeeregistry.v1.Lifecycle
+	(*Lifecycle_State)(nil),         // 4: google.cloud.apigeeregistry.v1.Lifecycle.State
+	(*timestamppb.Timestamp)(nil),   // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 6: google.protobuf.Duration
 }
 var file_google_cloud_apigeeregistry_v1_admin_models_proto_depIdxs = []int32{
-	1, // 0: google.cloud.apigeeregistry.v1.Project.create_time:type_name -> google.protobuf.Timestamp
-	1, // 1: google.cloud.apigeeregistry.v1.Project.update_time:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	5, // 0: google.cloud.apigeeregistry.v1.Project.create_time:type_name -> google.protobuf.Timestamp
+	5, // 1: google.cloud.apigeeregistry.v1.Project.update_time:type_name -> google.protobuf.Timestamp
+	2, // 2: google.cloud.apigeeregistry.v1.Project.revision_retention_policy:type_name -> google.cloud.apigeeregistry.v1.RevisionRetentionPolicy
+	0, // 3: google.cloud.apigeeregistry.v1.Project.spec_validation:type_name -> google.cloud.apigeeregistry.v1.Project.SpecValidation
+	3, // 4: google.cloud.apigeeregistry.v1.Project.api_lifecycle:type_name -> google.cloud.apigeeregistry.v1.Lifecycle
+	3, // 5: google.cloud.apigeeregistry.v1.Project.version_lifecycle:type_name -> google.cloud.apigeeregistry.v1.Lifecycle
+	6, // 6: google.cloud.apigeeregistry.v1.RevisionRetentionPolicy.max_age:type_name -> google.protobuf.Duration
+	4, // 7: google.cloud.apigeeregistry.v1.Lifecycle.states:type_name -> google.cloud.apigeeregistry.v1.Lifecycle.State
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_google_cloud_apigeeregistry_v1_admin_models_proto_init() }
@@ -212,19 +570,56 @@ func file_google_cloud_apigeeregistry_v1_admin_models_proto_init() {
 				return nil
 			}
 		}
+		file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevisionRetentionPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lifecycle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lifecycle_State); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_google_cloud_apigeeregistry_v1_admin_models_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_google_cloud_apigeeregistry_v1_admin_models_proto_goTypes,
 		DependencyIndexes: file_google_cloud_apigeeregistry_v1_admin_models_proto_depIdxs,
+		EnumInfos:         file_google_cloud_apigeeregistry_v1_admin_models_proto_enumTypes,
 		MessageInfos:      file_google_cloud_apigeeregistry_v1_admin_models_proto_msgTypes,
 	}.Build()
 	File_google_cloud_apigeeregistry_v1_admin_models_proto = out.File
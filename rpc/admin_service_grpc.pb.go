@@ -50,6 +50,37 @@ type AdminClient interface {
 	// DeleteProject removes a specified project and all of the resources that it
 	// owns.
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// PruneRevisions deletes spec and deployment revisions that fall outside
+	// the revision retention policy of a project.
+	PruneRevisions(ctx context.Context, in *PruneRevisionsRequest, opts ...grpc.CallOption) (*PruneRevisionsResponse, error)
+	// ExportProject writes a project and all of the resources that it owns,
+	// including every revision, revision tag and artifact and their contents, to
+	// a portable archive that can be restored with ImportProject. The archive is
+	// returned in a single message, so large projects should be exported with
+	// DownloadProjectArchive.
+	ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// ImportProject creates a project from an archive written by ExportProject.
+	// The archive is sent in a single message, so large archives should be
+	// imported with UploadProjectArchive.
+	ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// CheckConsistency looks for stored records that are inconsistent with each
+	// other, such as orphaned records, dangling revision tags, contents that
+	// don't match their hashes and references to missing resources, and
+	// optionally repairs them.
+	CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*longrunning.Operation, error)
+	// SetMaintenanceMode puts the server into or takes it out of read-only
+	// maintenance mode. In read-only mode, requests that would change stored
+	// resources fail with UNAVAILABLE, while requests that read them continue
+	// to succeed. The mode applies only to the server that handles the request.
+	SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*MaintenanceMode, error)
+	// DownloadProjectArchive streams an archive of a project in chunks. The
+	// archive is the same as the one written by ExportProject, but projects of
+	// any size can be exported without reaching message size limits.
+	DownloadProjectArchive(ctx context.Context, in *DownloadProjectArchiveRequest, opts ...grpc.CallOption) (Admin_DownloadProjectArchiveClient, error)
+	// UploadProjectArchive creates a project from an archive written by
+	// ExportProject or DownloadProjectArchive that is streamed in chunks.
+	// Archives of any size can be imported without reaching message size limits.
+	UploadProjectArchive(ctx context.Context, opts ...grpc.CallOption) (Admin_UploadProjectArchiveClient, error)
 }
 
 type adminClient struct {
@@ -123,6 +154,117 @@ func (c *adminClient) DeleteProject(ctx context.Context, in *DeleteProjectReques
 	return out, nil
 }
 
+func (c *adminClient) PruneRevisions(ctx context.Context, in *PruneRevisionsRequest, opts ...grpc.CallOption) (*PruneRevisionsResponse, error) {
+	out := new(PruneRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/PruneRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ExportProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/ImportProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*longrunning.Operation, error) {
+	out := new(longrunning.Operation)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/CheckConsistency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetMaintenanceMode(ctx context.Context, in *SetMaintenanceModeRequest, opts ...grpc.CallOption) (*MaintenanceMode, error) {
+	out := new(MaintenanceMode)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Admin/SetMaintenanceMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DownloadProjectArchive(ctx context.Context, in *DownloadProjectArchiveRequest, opts ...grpc.CallOption) (Admin_DownloadProjectArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], "/google.cloud.apigeeregistry.v1.Admin/DownloadProjectArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminDownloadProjectArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_DownloadProjectArchiveClient interface {
+	Recv() (*ProjectArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type adminDownloadProjectArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminDownloadProjectArchiveClient) Recv() (*ProjectArchiveChunk, error) {
+	m := new(ProjectArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) UploadProjectArchive(ctx context.Context, opts ...grpc.CallOption) (Admin_UploadProjectArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], "/google.cloud.apigeeregistry.v1.Admin/UploadProjectArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminUploadProjectArchiveClient{stream}
+	return x, nil
+}
+
+type Admin_UploadProjectArchiveClient interface {
+	Send(*UploadProjectArchiveRequest) error
+	CloseAndRecv() (*ImportProjectResponse, error)
+	grpc.ClientStream
+}
+
+type adminUploadProjectArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminUploadProjectArchiveClient) Send(m *UploadProjectArchiveRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminUploadProjectArchiveClient) CloseAndRecv() (*ImportProjectResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportProjectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AdminServer is the server API for Admin service.
 // All implementations must embed UnimplementedAdminServer
 // for forward compatibility
@@ -156,6 +298,37 @@ type AdminServer interface {
 	// DeleteProject removes a specified project and all of the resources that it
 	// owns.
 	DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error)
+	// PruneRevisions deletes spec and deployment revisions that fall outside
+	// the revision retention policy of a project.
+	PruneRevisions(context.Context, *PruneRevisionsRequest) (*PruneRevisionsResponse, error)
+	// ExportProject writes a project and all of the resources that it owns,
+	// including every revision, revision tag and artifact and their contents, to
+	// a portable archive that can be restored with ImportProject. The archive is
+	// returned in a single message, so large projects should be exported with
+	// DownloadProjectArchive.
+	ExportProject(context.Context, *ExportProjectRequest) (*longrunning.Operation, error)
+	// ImportProject creates a project from an archive written by ExportProject.
+	// The archive is sent in a single message, so large archives should be
+	// imported with UploadProjectArchive.
+	ImportProject(context.Context, *ImportProjectRequest) (*longrunning.Operation, error)
+	// CheckConsistency looks for stored records that are inconsistent with each
+	// other, such as orphaned records, dangling revision tags, contents that
+	// don't match their hashes and references to missing resources, and
+	// optionally repairs them.
+	CheckConsistency(context.Context, *CheckConsistencyRequest) (*longrunning.Operation, error)
+	// SetMaintenanceMode puts the server into or takes it out of read-only
+	// maintenance mode. In read-only mode, requests that would change stored
+	// resources fail with UNAVAILABLE, while requests that read them continue
+	// to succeed. The mode applies only to the server that handles the request.
+	SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*MaintenanceMode, error)
+	// DownloadProjectArchive streams an archive of a project in chunks. The
+	// archive is the same as the one written by ExportProject, but projects of
+	// any size can be exported without reaching message size limits.
+	DownloadProjectArchive(*DownloadProjectArchiveRequest, Admin_DownloadProjectArchiveServer) error
+	// UploadProjectArchive creates a project from an archive written by
+	// ExportProject or DownloadProjectArchive that is streamed in chunks.
+	// Archives of any size can be imported without reaching message size limits.
+	UploadProjectArchive(Admin_UploadProjectArchiveServer) error
 	mustEmbedUnimplementedAdminServer()
 }
 
@@ -184,6 +357,27 @@ func (UnimplementedAdminServer) UpdateProject(context.Context, *UpdateProjectReq
 func (UnimplementedAdminServer) DeleteProject(context.Context, *DeleteProjectRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
 }
+func (UnimplementedAdminServer) PruneRevisions(context.Context, *PruneRevisionsRequest) (*PruneRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneRevisions not implemented")
+}
+func (UnimplementedAdminServer) ExportProject(context.Context, *ExportProjectRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportProject not implemented")
+}
+func (UnimplementedAdminServer) ImportProject(context.Context, *ImportProjectRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportProject not implemented")
+}
+func (UnimplementedAdminServer) CheckConsistency(context.Context, *CheckConsistencyRequest) (*longrunning.Operation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckConsistency not implemented")
+}
+func (UnimplementedAdminServer) SetMaintenanceMode(context.Context, *SetMaintenanceModeRequest) (*MaintenanceMode, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenanceMode not implemented")
+}
+func (UnimplementedAdminServer) DownloadProjectArchive(*DownloadProjectArchiveRequest, Admin_DownloadProjectArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadProjectArchive not implemented")
+}
+func (UnimplementedAdminServer) UploadProjectArchive(Admin_UploadProjectArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProjectArchive not implemented")
+}
 func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
 
 // UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
@@ -323,6 +517,143 @@ func _Admin_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_PruneRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PruneRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/PruneRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PruneRevisions(ctx, req.(*PruneRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ExportProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportProject(ctx, req.(*ExportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ImportProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ImportProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/ImportProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ImportProject(ctx, req.(*ImportProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_CheckConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConsistencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).CheckConsistency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/CheckConsistency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).CheckConsistency(ctx, req.(*CheckConsistencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetMaintenanceMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetMaintenanceMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Admin/SetMaintenanceMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetMaintenanceMode(ctx, req.(*SetMaintenanceModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DownloadProjectArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadProjectArchiveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).DownloadProjectArchive(m, &adminDownloadProjectArchiveServer{stream})
+}
+
+type Admin_DownloadProjectArchiveServer interface {
+	Send(*ProjectArchiveChunk) error
+	grpc.ServerStream
+}
+
+type adminDownloadProjectArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminDownloadProjectArchiveServer) Send(m *ProjectArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_UploadProjectArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServer).UploadProjectArchive(&adminUploadProjectArchiveServer{stream})
+}
+
+type Admin_UploadProjectArchiveServer interface {
+	SendAndClose(*ImportProjectResponse) error
+	Recv() (*UploadProjectArchiveRequest, error)
+	grpc.ServerStream
+}
+
+type adminUploadProjectArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminUploadProjectArchiveServer) SendAndClose(m *ImportProjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminUploadProjectArchiveServer) Recv() (*UploadProjectArchiveRequest, error) {
+	m := new(UploadProjectArchiveRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -358,7 +689,38 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProject",
 			Handler:    _Admin_DeleteProject_Handler,
 		},
+		{
+			MethodName: "PruneRevisions",
+			Handler:    _Admin_PruneRevisions_Handler,
+		},
+		{
+			MethodName: "ExportProject",
+			Handler:    _Admin_ExportProject_Handler,
+		},
+		{
+			MethodName: "ImportProject",
+			Handler:    _Admin_ImportProject_Handler,
+		},
+		{
+			MethodName: "CheckConsistency",
+			Handler:    _Admin_CheckConsistency_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceMode",
+			Handler:    _Admin_SetMaintenanceMode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadProjectArchive",
+			Handler:       _Admin_DownloadProjectArchive_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadProjectArchive",
+			Handler:       _Admin_UploadProjectArchive_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "google/cloud/apigeeregistry/v1/admin_service.proto",
 }
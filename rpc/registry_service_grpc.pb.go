@@ -32,6 +32,10 @@ type RegistryClient interface {
 	// DeleteApi removes a specified API and all of the resources that it
 	// owns.
 	DeleteApi(ctx context.Context, in *DeleteApiRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListApiRevisions lists all metadata revisions of an API.
+	// A revision is recorded when the API is created and each time its metadata changes.
+	// Revisions are returned in descending order of revision creation time.
+	ListApiRevisions(ctx context.Context, in *ListApiRevisionsRequest, opts ...grpc.CallOption) (*ListApiRevisionsResponse, error)
 	// ListApiVersions returns matching versions.
 	ListApiVersions(ctx context.Context, in *ListApiVersionsRequest, opts ...grpc.CallOption) (*ListApiVersionsResponse, error)
 	// GetApiVersion returns a specified version.
@@ -43,6 +47,10 @@ type RegistryClient interface {
 	// DeleteApiVersion removes a specified version and all of the resources that
 	// it owns.
 	DeleteApiVersion(ctx context.Context, in *DeleteApiVersionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListApiVersionRevisions lists all metadata revisions of a version.
+	// A revision is recorded when the version is created and each time its metadata changes.
+	// Revisions are returned in descending order of revision creation time.
+	ListApiVersionRevisions(ctx context.Context, in *ListApiVersionRevisionsRequest, opts ...grpc.CallOption) (*ListApiVersionRevisionsResponse, error)
 	// ListApiSpecs returns matching specs.
 	ListApiSpecs(ctx context.Context, in *ListApiSpecsRequest, opts ...grpc.CallOption) (*ListApiSpecsResponse, error)
 	// GetApiSpec returns a specified spec.
@@ -50,12 +58,22 @@ type RegistryClient interface {
 	// GetApiSpecContents returns the contents of a specified spec.
 	// If specs are stored with GZip compression, the default behavior
 	// is to return the spec uncompressed (the mime_type response field
-	// indicates the exact format returned).
+	// indicates the exact format returned). Callers may also request
+	// that contents be converted to JSON or YAML or that a single file
+	// be extracted from a zip archive.
 	GetApiSpecContents(ctx context.Context, in *GetApiSpecContentsRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// DownloadApiSpecContents streams the contents of a specified spec in chunks.
+	// The contents are the same as those returned by GetApiSpecContents,
+	// but specs of any size can be downloaded without reaching message size limits.
+	DownloadApiSpecContents(ctx context.Context, in *DownloadApiSpecContentsRequest, opts ...grpc.CallOption) (Registry_DownloadApiSpecContentsClient, error)
 	// CreateApiSpec creates a specified spec.
 	CreateApiSpec(ctx context.Context, in *CreateApiSpecRequest, opts ...grpc.CallOption) (*ApiSpec, error)
 	// UpdateApiSpec can be used to modify a specified spec.
 	UpdateApiSpec(ctx context.Context, in *UpdateApiSpecRequest, opts ...grpc.CallOption) (*ApiSpec, error)
+	// UploadApiSpecContents replaces the contents of a specified spec with
+	// contents streamed in chunks. Specs of any size can be uploaded without
+	// reaching message size limits.
+	UploadApiSpecContents(ctx context.Context, opts ...grpc.CallOption) (Registry_UploadApiSpecContentsClient, error)
 	// DeleteApiSpec removes a specified spec, all revisions, and all child
 	// resources (e.g. artifacts).
 	DeleteApiSpec(ctx context.Context, in *DeleteApiSpecRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -100,12 +118,40 @@ type RegistryClient interface {
 	// is to return the artifact uncompressed (the mime_type response field
 	// indicates the exact format returned).
 	GetArtifactContents(ctx context.Context, in *GetArtifactContentsRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// DownloadArtifactContents streams the contents of a specified artifact in chunks.
+	// The contents are the same as those returned by GetArtifactContents,
+	// but artifacts of any size can be downloaded without reaching message size limits.
+	DownloadArtifactContents(ctx context.Context, in *DownloadArtifactContentsRequest, opts ...grpc.CallOption) (Registry_DownloadArtifactContentsClient, error)
 	// CreateArtifact creates a specified artifact.
 	CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
 	// ReplaceArtifact can be used to replace a specified artifact.
 	ReplaceArtifact(ctx context.Context, in *ReplaceArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
 	// DeleteArtifact removes a specified artifact.
 	DeleteArtifact(ctx context.Context, in *DeleteArtifactRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TagArtifactRevision adds a tag to a specified revision of an artifact.
+	TagArtifactRevision(ctx context.Context, in *TagArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// ListArtifactRevisions lists all revisions of an artifact.
+	// Revisions are returned in descending order of revision creation time.
+	ListArtifactRevisions(ctx context.Context, in *ListArtifactRevisionsRequest, opts ...grpc.CallOption) (*ListArtifactRevisionsResponse, error)
+	// RollbackArtifact sets the current revision to a specified prior revision.
+	// Note that this creates a new revision with a new revision ID.
+	RollbackArtifact(ctx context.Context, in *RollbackArtifactRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// DeleteArtifactRevision deletes a revision of an artifact.
+	DeleteArtifactRevision(ctx context.Context, in *DeleteArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error)
+	// AggregateResources counts the resources in a collection, grouped by the
+	// values of specified fields.
+	AggregateResources(ctx context.Context, in *AggregateResourcesRequest, opts ...grpc.CallOption) (*AggregateResourcesResponse, error)
+	// ListReferences lists the resources that refer to a specified resource,
+	// such as the deployments that serve a spec revision.
+	ListReferences(ctx context.Context, in *ListReferencesRequest, opts ...grpc.CallOption) (*ListReferencesResponse, error)
+	// ListRevisionTags lists the tags of the revisions of a spec or deployment.
+	ListRevisionTags(ctx context.Context, in *ListRevisionTagsRequest, opts ...grpc.CallOption) (*ListRevisionTagsResponse, error)
+	// DeleteRevisionTag removes a tag from a revision of a spec or deployment.
+	// The revision itself is not changed.
+	DeleteRevisionTag(ctx context.Context, in *DeleteRevisionTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// CompareApiSpecRevisions returns the differences between two spec
+	// revisions and classifies them as breaking or non-breaking changes.
+	CompareApiSpecRevisions(ctx context.Context, in *CompareApiSpecRevisionsRequest, opts ...grpc.CallOption) (*CompareApiSpecRevisionsResponse, error)
 }
 
 type registryClient struct {
@@ -161,6 +207,15 @@ func (c *registryClient) DeleteApi(ctx context.Context, in *DeleteApiRequest, op
 	return out, nil
 }
 
+func (c *registryClient) ListApiRevisions(ctx context.Context, in *ListApiRevisionsRequest, opts ...grpc.CallOption) (*ListApiRevisionsResponse, error) {
+	out := new(ListApiRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) ListApiVersions(ctx context.Context, in *ListApiVersionsRequest, opts ...grpc.CallOption) (*ListApiVersionsResponse, error) {
 	out := new(ListApiVersionsResponse)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiVersions", in, out, opts...)
@@ -206,6 +261,15 @@ func (c *registryClient) DeleteApiVersion(ctx context.Context, in *DeleteApiVers
 	return out, nil
 }
 
+func (c *registryClient) ListApiVersionRevisions(ctx context.Context, in *ListApiVersionRevisionsRequest, opts ...grpc.CallOption) (*ListApiVersionRevisionsResponse, error) {
+	out := new(ListApiVersionRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiVersionRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registryClient) ListApiSpecs(ctx context.Context, in *ListApiSpecsRequest, opts ...grpc.CallOption) (*ListApiSpecsResponse, error) {
 	out := new(ListApiSpecsResponse)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListApiSpecs", in, out, opts...)
@@ -233,6 +297,38 @@ func (c *registryClient) GetApiSpecContents(ctx context.Context, in *GetApiSpecC
 	return out, nil
 }
 
+func (c *registryClient) DownloadApiSpecContents(ctx context.Context, in *DownloadApiSpecContentsRequest, opts ...grpc.CallOption) (Registry_DownloadApiSpecContentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[0], "/google.cloud.apigeeregistry.v1.Registry/DownloadApiSpecContents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryDownloadApiSpecContentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_DownloadApiSpecContentsClient interface {
+	Recv() (*ContentsChunk, error)
+	grpc.ClientStream
+}
+
+type registryDownloadApiSpecContentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryDownloadApiSpecContentsClient) Recv() (*ContentsChunk, error) {
+	m := new(ContentsChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *registryClient) CreateApiSpec(ctx context.Context, in *CreateApiSpecRequest, opts ...grpc.CallOption) (*ApiSpec, error) {
 	out := new(ApiSpec)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/CreateApiSpec", in, out, opts...)
@@ -251,6 +347,40 @@ func (c *registryClient) UpdateApiSpec(ctx context.Context, in *UpdateApiSpecReq
 	return out, nil
 }
 
+func (c *registryClient) UploadApiSpecContents(ctx context.Context, opts ...grpc.CallOption) (Registry_UploadApiSpecContentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[1], "/google.cloud.apigeeregistry.v1.Registry/UploadApiSpecContents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryUploadApiSpecContentsClient{stream}
+	return x, nil
+}
+
+type Registry_UploadApiSpecContentsClient interface {
+	Send(*UploadApiSpecContentsRequest) error
+	CloseAndRecv() (*ApiSpec, error)
+	grpc.ClientStream
+}
+
+type registryUploadApiSpecContentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryUploadApiSpecContentsClient) Send(m *UploadApiSpecContentsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *registryUploadApiSpecContentsClient) CloseAndRecv() (*ApiSpec, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ApiSpec)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *registryClient) DeleteApiSpec(ctx context.Context, in *DeleteApiSpecRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/DeleteApiSpec", in, out, opts...)
@@ -404,6 +534,38 @@ func (c *registryClient) GetArtifactContents(ctx context.Context, in *GetArtifac
 	return out, nil
 }
 
+func (c *registryClient) DownloadArtifactContents(ctx context.Context, in *DownloadArtifactContentsRequest, opts ...grpc.CallOption) (Registry_DownloadArtifactContentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[2], "/google.cloud.apigeeregistry.v1.Registry/DownloadArtifactContents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryDownloadArtifactContentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_DownloadArtifactContentsClient interface {
+	Recv() (*ContentsChunk, error)
+	grpc.ClientStream
+}
+
+type registryDownloadArtifactContentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryDownloadArtifactContentsClient) Recv() (*ContentsChunk, error) {
+	m := new(ContentsChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *registryClient) CreateArtifact(ctx context.Context, in *CreateArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
 	out := new(Artifact)
 	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/CreateArtifact", in, out, opts...)
@@ -431,6 +593,87 @@ func (c *registryClient) DeleteArtifact(ctx context.Context, in *DeleteArtifactR
 	return out, nil
 }
 
+func (c *registryClient) TagArtifactRevision(ctx context.Context, in *TagArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/TagArtifactRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListArtifactRevisions(ctx context.Context, in *ListArtifactRevisionsRequest, opts ...grpc.CallOption) (*ListArtifactRevisionsResponse, error) {
+	out := new(ListArtifactRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListArtifactRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) RollbackArtifact(ctx context.Context, in *RollbackArtifactRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/RollbackArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DeleteArtifactRevision(ctx context.Context, in *DeleteArtifactRevisionRequest, opts ...grpc.CallOption) (*Artifact, error) {
+	out := new(Artifact)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/DeleteArtifactRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) AggregateResources(ctx context.Context, in *AggregateResourcesRequest, opts ...grpc.CallOption) (*AggregateResourcesResponse, error) {
+	out := new(AggregateResourcesResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/AggregateResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListReferences(ctx context.Context, in *ListReferencesRequest, opts ...grpc.CallOption) (*ListReferencesResponse, error) {
+	out := new(ListReferencesResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListReferences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) ListRevisionTags(ctx context.Context, in *ListRevisionTagsRequest, opts ...grpc.CallOption) (*ListRevisionTagsResponse, error) {
+	out := new(ListRevisionTagsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/ListRevisionTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) DeleteRevisionTag(ctx context.Context, in *DeleteRevisionTagRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/DeleteRevisionTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) CompareApiSpecRevisions(ctx context.Context, in *CompareApiSpecRevisionsRequest, opts ...grpc.CallOption) (*CompareApiSpecRevisionsResponse, error) {
+	out := new(CompareApiSpecRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/google.cloud.apigeeregistry.v1.Registry/CompareApiSpecRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistryServer is the server API for Registry service.
 // All implementations must embed UnimplementedRegistryServer
 // for forward compatibility
@@ -446,6 +689,10 @@ type RegistryServer interface {
 	// DeleteApi removes a specified API and all of the resources that it
 	// owns.
 	DeleteApi(context.Context, *DeleteApiRequest) (*emptypb.Empty, error)
+	// ListApiRevisions lists all metadata revisions of an API.
+	// A revision is recorded when the API is created and each time its metadata changes.
+	// Revisions are returned in descending order of revision creation time.
+	ListApiRevisions(context.Context, *ListApiRevisionsRequest) (*ListApiRevisionsResponse, error)
 	// ListApiVersions returns matching versions.
 	ListApiVersions(context.Context, *ListApiVersionsRequest) (*ListApiVersionsResponse, error)
 	// GetApiVersion returns a specified version.
@@ -457,6 +704,10 @@ type RegistryServer interface {
 	// DeleteApiVersion removes a specified version and all of the resources that
 	// it owns.
 	DeleteApiVersion(context.Context, *DeleteApiVersionRequest) (*emptypb.Empty, error)
+	// ListApiVersionRevisions lists all metadata revisions of a version.
+	// A revision is recorded when the version is created and each time its metadata changes.
+	// Revisions are returned in descending order of revision creation time.
+	ListApiVersionRevisions(context.Context, *ListApiVersionRevisionsRequest) (*ListApiVersionRevisionsResponse, error)
 	// ListApiSpecs returns matching specs.
 	ListApiSpecs(context.Context, *ListApiSpecsRequest) (*ListApiSpecsResponse, error)
 	// GetApiSpec returns a specified spec.
@@ -464,12 +715,22 @@ type RegistryServer interface {
 	// GetApiSpecContents returns the contents of a specified spec.
 	// If specs are stored with GZip compression, the default behavior
 	// is to return the spec uncompressed (the mime_type response field
-	// indicates the exact format returned).
+	// indicates the exact format returned). Callers may also request
+	// that contents be converted to JSON or YAML or that a single file
+	// be extracted from a zip archive.
 	GetApiSpecContents(context.Context, *GetApiSpecContentsRequest) (*httpbody.HttpBody, error)
+	// DownloadApiSpecContents streams the contents of a specified spec in chunks.
+	// The contents are the same as those returned by GetApiSpecContents,
+	// but specs of any size can be downloaded without reaching message size limits.
+	DownloadApiSpecContents(*DownloadApiSpecContentsRequest, Registry_DownloadApiSpecContentsServer) error
 	// CreateApiSpec creates a specified spec.
 	CreateApiSpec(context.Context, *CreateApiSpecRequest) (*ApiSpec, error)
 	// UpdateApiSpec can be used to modify a specified spec.
 	UpdateApiSpec(context.Context, *UpdateApiSpecRequest) (*ApiSpec, error)
+	// UploadApiSpecContents replaces the contents of a specified spec with
+	// contents streamed in chunks. Specs of any size can be uploaded without
+	// reaching message size limits.
+	UploadApiSpecContents(Registry_UploadApiSpecContentsServer) error
 	// DeleteApiSpec removes a specified spec, all revisions, and all child
 	// resources (e.g. artifacts).
 	DeleteApiSpec(context.Context, *DeleteApiSpecRequest) (*emptypb.Empty, error)
@@ -514,12 +775,40 @@ type RegistryServer interface {
 	// is to return the artifact uncompressed (the mime_type response field
 	// indicates the exact format returned).
 	GetArtifactContents(context.Context, *GetArtifactContentsRequest) (*httpbody.HttpBody, error)
+	// DownloadArtifactContents streams the contents of a specified artifact in chunks.
+	// The contents are the same as those returned by GetArtifactContents,
+	// but artifacts of any size can be downloaded without reaching message size limits.
+	DownloadArtifactContents(*DownloadArtifactContentsRequest, Registry_DownloadArtifactContentsServer) error
 	// CreateArtifact creates a specified artifact.
 	CreateArtifact(context.Context, *CreateArtifactRequest) (*Artifact, error)
 	// ReplaceArtifact can be used to replace a specified artifact.
 	ReplaceArtifact(context.Context, *ReplaceArtifactRequest) (*Artifact, error)
 	// DeleteArtifact removes a specified artifact.
 	DeleteArtifact(context.Context, *DeleteArtifactRequest) (*emptypb.Empty, error)
+	// TagArtifactRevision adds a tag to a specified revision of an artifact.
+	TagArtifactRevision(context.Context, *TagArtifactRevisionRequest) (*Artifact, error)
+	// ListArtifactRevisions lists all revisions of an artifact.
+	// Revisions are returned in descending order of revision creation time.
+	ListArtifactRevisions(context.Context, *ListArtifactRevisionsRequest) (*ListArtifactRevisionsResponse, error)
+	// RollbackArtifact sets the current revision to a specified prior revision.
+	// Note that this creates a new revision with a new revision ID.
+	RollbackArtifact(context.Context, *RollbackArtifactRequest) (*Artifact, error)
+	// DeleteArtifactRevision deletes a revision of an artifact.
+	DeleteArtifactRevision(context.Context, *DeleteArtifactRevisionRequest) (*Artifact, error)
+	// AggregateResources counts the resources in a collection, grouped by the
+	// values of specified fields.
+	AggregateResources(context.Context, *AggregateResourcesRequest) (*AggregateResourcesResponse, error)
+	// ListReferences lists the resources that refer to a specified resource,
+	// such as the deployments that serve a spec revision.
+	ListReferences(context.Context, *ListReferencesRequest) (*ListReferencesResponse, error)
+	// ListRevisionTags lists the tags of the revisions of a spec or deployment.
+	ListRevisionTags(context.Context, *ListRevisionTagsRequest) (*ListRevisionTagsResponse, error)
+	// DeleteRevisionTag removes a tag from a revision of a spec or deployment.
+	// The revision itself is not changed.
+	DeleteRevisionTag(context.Context, *DeleteRevisionTagRequest) (*emptypb.Empty, error)
+	// CompareApiSpecRevisions returns the differences between two spec
+	// revisions and classifies them as breaking or non-breaking changes.
+	CompareApiSpecRevisions(context.Context, *CompareApiSpecRevisionsRequest) (*CompareApiSpecRevisionsResponse, error)
 	mustEmbedUnimplementedRegistryServer()
 }
 
@@ -542,6 +831,9 @@ func (UnimplementedRegistryServer) UpdateApi(context.Context, *UpdateApiRequest)
 func (UnimplementedRegistryServer) DeleteApi(context.Context, *DeleteApiRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApi not implemented")
 }
+func (UnimplementedRegistryServer) ListApiRevisions(context.Context, *ListApiRevisionsRequest) (*ListApiRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiRevisions not implemented")
+}
 func (UnimplementedRegistryServer) ListApiVersions(context.Context, *ListApiVersionsRequest) (*ListApiVersionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListApiVersions not implemented")
 }
@@ -557,6 +849,9 @@ func (UnimplementedRegistryServer) UpdateApiVersion(context.Context, *UpdateApiV
 func (UnimplementedRegistryServer) DeleteApiVersion(context.Context, *DeleteApiVersionRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiVersion not implemented")
 }
+func (UnimplementedRegistryServer) ListApiVersionRevisions(context.Context, *ListApiVersionRevisionsRequest) (*ListApiVersionRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApiVersionRevisions not implemented")
+}
 func (UnimplementedRegistryServer) ListApiSpecs(context.Context, *ListApiSpecsRequest) (*ListApiSpecsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListApiSpecs not implemented")
 }
@@ -566,12 +861,18 @@ func (UnimplementedRegistryServer) GetApiSpec(context.Context, *GetApiSpecReques
 func (UnimplementedRegistryServer) GetApiSpecContents(context.Context, *GetApiSpecContentsRequest) (*httpbody.HttpBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetApiSpecContents not implemented")
 }
+func (UnimplementedRegistryServer) DownloadApiSpecContents(*DownloadApiSpecContentsRequest, Registry_DownloadApiSpecContentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadApiSpecContents not implemented")
+}
 func (UnimplementedRegistryServer) CreateApiSpec(context.Context, *CreateApiSpecRequest) (*ApiSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateApiSpec not implemented")
 }
 func (UnimplementedRegistryServer) UpdateApiSpec(context.Context, *UpdateApiSpecRequest) (*ApiSpec, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateApiSpec not implemented")
 }
+func (UnimplementedRegistryServer) UploadApiSpecContents(Registry_UploadApiSpecContentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadApiSpecContents not implemented")
+}
 func (UnimplementedRegistryServer) DeleteApiSpec(context.Context, *DeleteApiSpecRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteApiSpec not implemented")
 }
@@ -623,6 +924,9 @@ func (UnimplementedRegistryServer) GetArtifact(context.Context, *GetArtifactRequ
 func (UnimplementedRegistryServer) GetArtifactContents(context.Context, *GetArtifactContentsRequest) (*httpbody.HttpBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetArtifactContents not implemented")
 }
+func (UnimplementedRegistryServer) DownloadArtifactContents(*DownloadArtifactContentsRequest, Registry_DownloadArtifactContentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadArtifactContents not implemented")
+}
 func (UnimplementedRegistryServer) CreateArtifact(context.Context, *CreateArtifactRequest) (*Artifact, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateArtifact not implemented")
 }
@@ -632,6 +936,33 @@ func (UnimplementedRegistryServer) ReplaceArtifact(context.Context, *ReplaceArti
 func (UnimplementedRegistryServer) DeleteArtifact(context.Context, *DeleteArtifactRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteArtifact not implemented")
 }
+func (UnimplementedRegistryServer) TagArtifactRevision(context.Context, *TagArtifactRevisionRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TagArtifactRevision not implemented")
+}
+func (UnimplementedRegistryServer) ListArtifactRevisions(context.Context, *ListArtifactRevisionsRequest) (*ListArtifactRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifactRevisions not implemented")
+}
+func (UnimplementedRegistryServer) RollbackArtifact(context.Context, *RollbackArtifactRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackArtifact not implemented")
+}
+func (UnimplementedRegistryServer) DeleteArtifactRevision(context.Context, *DeleteArtifactRevisionRequest) (*Artifact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteArtifactRevision not implemented")
+}
+func (UnimplementedRegistryServer) AggregateResources(context.Context, *AggregateResourcesRequest) (*AggregateResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AggregateResources not implemented")
+}
+func (UnimplementedRegistryServer) ListReferences(context.Context, *ListReferencesRequest) (*ListReferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReferences not implemented")
+}
+func (UnimplementedRegistryServer) ListRevisionTags(context.Context, *ListRevisionTagsRequest) (*ListRevisionTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisionTags not implemented")
+}
+func (UnimplementedRegistryServer) DeleteRevisionTag(context.Context, *DeleteRevisionTagRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRevisionTag not implemented")
+}
+func (UnimplementedRegistryServer) CompareApiSpecRevisions(context.Context, *CompareApiSpecRevisionsRequest) (*CompareApiSpecRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareApiSpecRevisions not implemented")
+}
 func (UnimplementedRegistryServer) mustEmbedUnimplementedRegistryServer() {}
 
 // UnsafeRegistryServer may be embedded to opt out of forward compatibility for this service.
@@ -735,6 +1066,24 @@ func _Registry_DeleteApi_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_ListApiRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListApiRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListApiRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListApiRevisions(ctx, req.(*ListApiRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_ListApiVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListApiVersionsRequest)
 	if err := dec(in); err != nil {
@@ -825,6 +1174,24 @@ func _Registry_DeleteApiVersion_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_ListApiVersionRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApiVersionRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListApiVersionRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListApiVersionRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListApiVersionRevisions(ctx, req.(*ListApiVersionRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_ListApiSpecs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListApiSpecsRequest)
 	if err := dec(in); err != nil {
@@ -879,6 +1246,27 @@ func _Registry_GetApiSpecContents_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_DownloadApiSpecContents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadApiSpecContentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).DownloadApiSpecContents(m, &registryDownloadApiSpecContentsServer{stream})
+}
+
+type Registry_DownloadApiSpecContentsServer interface {
+	Send(*ContentsChunk) error
+	grpc.ServerStream
+}
+
+type registryDownloadApiSpecContentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryDownloadApiSpecContentsServer) Send(m *ContentsChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Registry_CreateApiSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateApiSpecRequest)
 	if err := dec(in); err != nil {
@@ -915,6 +1303,32 @@ func _Registry_UpdateApiSpec_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_UploadApiSpecContents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RegistryServer).UploadApiSpecContents(&registryUploadApiSpecContentsServer{stream})
+}
+
+type Registry_UploadApiSpecContentsServer interface {
+	SendAndClose(*ApiSpec) error
+	Recv() (*UploadApiSpecContentsRequest, error)
+	grpc.ServerStream
+}
+
+type registryUploadApiSpecContentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryUploadApiSpecContentsServer) SendAndClose(m *ApiSpec) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *registryUploadApiSpecContentsServer) Recv() (*UploadApiSpecContentsRequest, error) {
+	m := new(UploadApiSpecContentsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Registry_DeleteApiSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteApiSpecRequest)
 	if err := dec(in); err != nil {
@@ -1221,6 +1635,27 @@ func _Registry_GetArtifactContents_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_DownloadArtifactContents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadArtifactContentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).DownloadArtifactContents(m, &registryDownloadArtifactContentsServer{stream})
+}
+
+type Registry_DownloadArtifactContentsServer interface {
+	Send(*ContentsChunk) error
+	grpc.ServerStream
+}
+
+type registryDownloadArtifactContentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryDownloadArtifactContentsServer) Send(m *ContentsChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Registry_CreateArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateArtifactRequest)
 	if err := dec(in); err != nil {
@@ -1275,6 +1710,168 @@ func _Registry_DeleteArtifact_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_TagArtifactRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TagArtifactRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).TagArtifactRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/TagArtifactRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).TagArtifactRevision(ctx, req.(*TagArtifactRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListArtifactRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArtifactRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListArtifactRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListArtifactRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListArtifactRevisions(ctx, req.(*ListArtifactRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_RollbackArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).RollbackArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/RollbackArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).RollbackArtifact(ctx, req.(*RollbackArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DeleteArtifactRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteArtifactRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DeleteArtifactRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/DeleteArtifactRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DeleteArtifactRevision(ctx, req.(*DeleteArtifactRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_AggregateResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).AggregateResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/AggregateResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).AggregateResources(ctx, req.(*AggregateResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListReferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListReferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListReferences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListReferences(ctx, req.(*ListReferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_ListRevisionTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).ListRevisionTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/ListRevisionTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).ListRevisionTags(ctx, req.(*ListRevisionTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_DeleteRevisionTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRevisionTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).DeleteRevisionTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/DeleteRevisionTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).DeleteRevisionTag(ctx, req.(*DeleteRevisionTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_CompareApiSpecRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareApiSpecRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).CompareApiSpecRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/google.cloud.apigeeregistry.v1.Registry/CompareApiSpecRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).CompareApiSpecRevisions(ctx, req.(*CompareApiSpecRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1302,6 +1899,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApi",
 			Handler:    _Registry_DeleteApi_Handler,
 		},
+		{
+			MethodName: "ListApiRevisions",
+			Handler:    _Registry_ListApiRevisions_Handler,
+		},
 		{
 			MethodName: "ListApiVersions",
 			Handler:    _Registry_ListApiVersions_Handler,
@@ -1322,6 +1923,10 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteApiVersion",
 			Handler:    _Registry_DeleteApiVersion_Handler,
 		},
+		{
+			MethodName: "ListApiVersionRevisions",
+			Handler:    _Registry_ListApiVersionRevisions_Handler,
+		},
 		{
 			MethodName: "ListApiSpecs",
 			Handler:    _Registry_ListApiSpecs_Handler,
@@ -1422,7 +2027,59 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteArtifact",
 			Handler:    _Registry_DeleteArtifact_Handler,
 		},
+		{
+			MethodName: "TagArtifactRevision",
+			Handler:    _Registry_TagArtifactRevision_Handler,
+		},
+		{
+			MethodName: "ListArtifactRevisions",
+			Handler:    _Registry_ListArtifactRevisions_Handler,
+		},
+		{
+			MethodName: "RollbackArtifact",
+			Handler:    _Registry_RollbackArtifact_Handler,
+		},
+		{
+			MethodName: "DeleteArtifactRevision",
+			Handler:    _Registry_DeleteArtifactRevision_Handler,
+		},
+		{
+			MethodName: "AggregateResources",
+			Handler:    _Registry_AggregateResources_Handler,
+		},
+		{
+			MethodName: "ListReferences",
+			Handler:    _Registry_ListReferences_Handler,
+		},
+		{
+			MethodName: "ListRevisionTags",
+			Handler:    _Registry_ListRevisionTags_Handler,
+		},
+		{
+			MethodName: "DeleteRevisionTag",
+			Handler:    _Registry_DeleteRevisionTag_Handler,
+		},
+		{
+			MethodName: "CompareApiSpecRevisions",
+			Handler:    _Registry_CompareApiSpecRevisions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadApiSpecContents",
+			Handler:       _Registry_DownloadApiSpecContents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadApiSpecContents",
+			Handler:       _Registry_UploadApiSpecContents_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadArtifactContents",
+			Handler:       _Registry_DownloadArtifactContents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "google/cloud/apigeeregistry/v1/registry_service.proto",
 }
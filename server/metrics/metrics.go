@@ -0,0 +1,133 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics defines the Prometheus metrics exported by the registry server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "registry"
+
+var (
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_duration_seconds",
+		Help:      "Latency of handled RPCs, by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Number of RPCs that returned a status other than OK, by method and status code.",
+	}, []string{"method", "code"})
+
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "storage_query_duration_seconds",
+		Help:      "Latency of storage queries, by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation", "table"})
+
+	blobSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "blob_size_bytes",
+		Help:      "Size of stored spec and artifact contents.",
+		Buckets:   prometheus.ExponentialBuckets(256, 4, 10),
+	}, []string{"kind"})
+
+	notificationFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notification_publish_failures_total",
+		Help:      "Number of change notifications that could not be published.",
+	})
+)
+
+// Registry holds all metrics exported by the server, along with the
+// standard Go runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcDuration,
+		rpcErrors,
+		storageDuration,
+		blobSize,
+		notificationFailures,
+	)
+}
+
+// Handler returns an HTTP handler that serves metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// UnaryServerInterceptor returns a gRPC server interceptor that records RPC latencies and errors.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observeRPC(info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream interceptor that records RPC latencies and errors.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observeRPC(info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+func observeRPC(fullMethod string, elapsed time.Duration, err error) {
+	method := filepath.Base(fullMethod)
+	code := status.Code(err)
+	rpcDuration.WithLabelValues(method, code.String()).Observe(elapsed.Seconds())
+	if code != codes.OK {
+		rpcErrors.WithLabelValues(method, code.String()).Inc()
+	}
+}
+
+// ObserveStorageQuery records the latency of a storage query.
+func ObserveStorageQuery(operation, table string, elapsed time.Duration) {
+	storageDuration.WithLabelValues(operation, table).Observe(elapsed.Seconds())
+}
+
+// ObserveBlobSize records the size of stored contents.
+// Kind should be "spec" or "artifact".
+func ObserveBlobSize(kind string, size int) {
+	blobSize.WithLabelValues(kind).Observe(float64(size))
+}
+
+// NotificationFailed records a failure to publish a change notification.
+func NotificationFailed() {
+	notificationFailures.Inc()
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const fullMethod = "/google.cloud.apigeeregistry.v1.Registry/GetApi"
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+
+	tests := []struct {
+		desc string
+		err  error
+		code codes.Code
+	}{
+		{
+			desc: "success",
+			code: codes.OK,
+		},
+		{
+			desc: "failure",
+			err:  status.Error(codes.NotFound, "not found"),
+			code: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			errors := rpcErrors.WithLabelValues("GetApi", test.code.String())
+			before := testutil.ToFloat64(errors)
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, test.err
+			}
+			if _, err := interceptor(context.Background(), nil, info, handler); err != test.err {
+				t.Fatalf("interceptor returned error %v, want %v", err, test.err)
+			}
+
+			after := testutil.ToFloat64(errors)
+			want := before
+			if test.code != codes.OK {
+				want++
+			}
+			if after != want {
+				t.Errorf("rpc_errors_total{method=GetApi,code=%s} is %v, want %v", test.code, after, want)
+			}
+		})
+	}
+
+	if n := testutil.CollectAndCount(rpcDuration); n == 0 {
+		t.Errorf("rpc_duration_seconds has no observations")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ObserveStorageQuery("query", "projects", time.Millisecond)
+	ObserveBlobSize("spec", 1024)
+	NotificationFailed()
+
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get(%q) returned error: %s", server.URL, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %s", err)
+	}
+
+	for _, name := range []string{
+		"registry_storage_query_duration_seconds",
+		"registry_blob_size_bytes",
+		"registry_notification_publish_failures_total",
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("Handler() response is missing metric %q", name)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit limits the rate of requests made to the registry server by each caller.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Limit is a sustained rate of requests along with the number of requests allowed in a burst.
+type Limit struct {
+	// Rate is the number of requests allowed per second. Zero means unlimited.
+	Rate float64
+	// Burst is the number of requests that can be made at once.
+	// If unset or zero, the rate rounded up (or 1) is used.
+	Burst int
+}
+
+func (l Limit) unlimited() bool {
+	return l.Rate <= 0
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Max(1, math.Ceil(l.Rate)))
+}
+
+// Config configures rate limits. Limits are tracked separately for each caller.
+type Config struct {
+	// PerCaller limits requests from each caller to all methods.
+	PerCaller Limit
+	// Callers overrides PerCaller for specific callers, keyed by caller identity (see Caller).
+	Callers map[string]Limit
+	// Methods limits requests from each caller to specific methods, keyed by method name (like "CreateApiSpec").
+	Methods map[string]Limit
+}
+
+// exemptServices are never rate limited, so that health checks continue to work under load.
+var exemptServices = []string{
+	"/grpc.health.v1.Health/",
+}
+
+// pruneInterval is how often limiters of idle callers are removed.
+const pruneInterval = time.Minute
+
+// Limiter enforces rate limits on gRPC requests.
+type Limiter struct {
+	config Config
+	now    func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[string]*entry
+	lastPrune time.Time
+}
+
+type entry struct {
+	limiter  *rate.Limiter
+	limit    Limit
+	lastUsed time.Time
+	// refill is how long the limiter takes to refill from empty, after which
+	// an idle limiter is indistinguishable from a new one.
+	refill time.Duration
+}
+
+// New returns a Limiter that enforces the provided limits.
+func New(config Config) *Limiter {
+	return &Limiter{
+		config:   config,
+		now:      time.Now,
+		limiters: make(map[string]*entry),
+	}
+}
+
+// UnaryServerInterceptor returns a gRPC server interceptor that rejects requests exceeding rate limits.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.Allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC stream interceptor that rejects streams exceeding rate limits.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.Allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Allow returns a RESOURCE_EXHAUSTED error with retry information if a call to fullMethod
+// by the caller identified in ctx exceeds a rate limit.
+func (l *Limiter) Allow(ctx context.Context, fullMethod string) error {
+	for _, prefix := range exemptServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return nil
+		}
+	}
+
+	caller := Caller(ctx)
+	method := filepath.Base(fullMethod)
+
+	callerLimit, ok := l.config.Callers[caller]
+	if !ok {
+		callerLimit = l.config.PerCaller
+	}
+	methodLimit := l.config.Methods[method]
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.prune(now)
+
+	// Reserve from both limiters so that a request denied by one doesn't consume the other.
+	var reservations []*rate.Reservation
+	var delay time.Duration
+	var exceeded string
+	for _, c := range []struct {
+		key, desc string
+		limit     Limit
+	}{
+		{caller, "all methods", callerLimit},
+		{caller + " " + method, method, methodLimit},
+	} {
+		if c.limit.unlimited() {
+			continue
+		}
+		r := l.limiter(c.key, c.limit, now).ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay, exceeded = d, fmt.Sprintf("rate limit of %v requests per second for %s exceeded", c.limit.Rate, c.desc)
+		}
+	}
+
+	if delay == 0 {
+		return nil
+	}
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+	return exhausted(caller, exceeded, delay)
+}
+
+// limiter returns the limiter for a key, creating it if necessary.
+// Must be called with l.mu held.
+func (l *Limiter) limiter(key string, limit Limit, now time.Time) *rate.Limiter {
+	e, ok := l.limiters[key]
+	if !ok || e.limit != limit {
+		e = &entry{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.burst()), limit: limit}
+		e.refill = time.Duration(float64(limit.burst()) / limit.Rate * float64(time.Second))
+		l.limiters[key] = e
+	}
+	e.lastUsed = now
+	return e.limiter
+}
+
+// prune removes limiters that have been idle long enough to refill, so removing them
+// doesn't change which requests are allowed. Must be called with l.mu held.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < pruneInterval {
+		return
+	}
+	for key, e := range l.limiters {
+		if now.Sub(e.lastUsed) >= e.refill {
+			delete(l.limiters, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+func exhausted(caller, description string, delay time.Duration) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("%s: %s", caller, description))
+	st, err := st.WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     caller,
+			Description: description,
+		}}},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, description)
+	}
+	return st.Err()
+}
+
+// Caller returns an identity for the caller of the request in ctx. In order of preference, it is:
+//   - "cert:<common name>" for callers that present a verified TLS client certificate,
+//   - "ip:<address>" for other callers. Requests forwarded by the in-process HTTP gateway
+//     are identified by the address that the gateway appended to their X-Forwarded-For header.
+func Caller(ctx context.Context) string {
+	p, hasPeer := peer.FromContext(ctx)
+	if hasPeer {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) > 0 {
+			return "cert:" + info.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+
+	// Authorization headers aren't verified here, so they can't identify callers:
+	// a caller could avoid limits by sending a different header with each request.
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !hasPeer || p.Addr == nil {
+		return "unknown"
+	}
+	switch p.Addr.Network() {
+	case "tcp":
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	case "unix":
+		return "unix"
+	default:
+		// In-process connections, like those from the HTTP gateway. The gateway appends the
+		// address of the HTTP client to any X-Forwarded-For header that the client sent,
+		// so only the last address can be trusted.
+		if fwd := md.Get("x-forwarded-for"); len(fwd) > 0 {
+			addrs := strings.Split(fwd[len(fwd)-1], ",")
+			return "ip:" + strings.TrimSpace(addrs[len(addrs)-1])
+		}
+		return "local"
+	}
+}
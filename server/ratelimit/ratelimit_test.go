@@ -0,0 +1,271 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	getApi        = "/google.cloud.apigeeregistry.v1.Registry/GetApi"
+	createApiSpec = "/google.cloud.apigeeregistry.v1.Registry/CreateApiSpec"
+	healthCheck   = "/grpc.health.v1.Health/Check"
+)
+
+func callerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 12345},
+	})
+}
+
+// allowed makes n calls and returns the number that were allowed.
+func allowed(t *testing.T, l *Limiter, ctx context.Context, method string, n int) int {
+	t.Helper()
+	count := 0
+	for i := 0; i < n; i++ {
+		err := l.Allow(ctx, method)
+		if err == nil {
+			count++
+		} else if status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("Allow(%q) returned unexpected error: %s", method, err)
+		}
+	}
+	return count
+}
+
+func TestPerCallerLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(Config{PerCaller: Limit{Rate: 1, Burst: 3}})
+	l.now = func() time.Time { return now }
+
+	alice, bob := callerContext("10.0.0.1"), callerContext("10.0.0.2")
+	if got := allowed(t, l, alice, getApi, 5); got != 3 {
+		t.Errorf("Allowed %d calls from alice, want 3", got)
+	}
+	if got := allowed(t, l, bob, createApiSpec, 5); got != 3 {
+		t.Errorf("Allowed %d calls from bob, want 3", got)
+	}
+	if got := allowed(t, l, alice, healthCheck, 5); got != 5 {
+		t.Errorf("Allowed %d health checks, want 5", got)
+	}
+
+	now = now.Add(time.Second)
+	if got := allowed(t, l, alice, getApi, 5); got != 1 {
+		t.Errorf("Allowed %d calls from alice after one second, want 1", got)
+	}
+}
+
+func TestMethodLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(Config{Methods: map[string]Limit{"CreateApiSpec": {Rate: 1}}})
+	l.now = func() time.Time { return now }
+
+	ctx := callerContext("10.0.0.1")
+	if got := allowed(t, l, ctx, createApiSpec, 5); got != 1 {
+		t.Errorf("Allowed %d calls to CreateApiSpec, want 1", got)
+	}
+	if got := allowed(t, l, ctx, getApi, 5); got != 5 {
+		t.Errorf("Allowed %d calls to GetApi, want 5", got)
+	}
+	if got := allowed(t, l, callerContext("10.0.0.2"), createApiSpec, 5); got != 1 {
+		t.Errorf("Allowed %d calls to CreateApiSpec from another caller, want 1", got)
+	}
+}
+
+func TestCallerOverride(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(Config{
+		PerCaller: Limit{Rate: 1},
+		Callers:   map[string]Limit{"ip:10.0.0.9": {Rate: 10}},
+	})
+	l.now = func() time.Time { return now }
+
+	if got := allowed(t, l, callerContext("10.0.0.9"), getApi, 20); got != 10 {
+		t.Errorf("Allowed %d calls from overridden caller, want 10", got)
+	}
+	if got := allowed(t, l, callerContext("10.0.0.1"), getApi, 20); got != 1 {
+		t.Errorf("Allowed %d calls from default caller, want 1", got)
+	}
+}
+
+func TestIdleLimitersEvicted(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(Config{PerCaller: Limit{Rate: 1, Burst: 3}})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		allowed(t, l, callerContext(fmt.Sprintf("10.0.1.%d", i)), getApi, 1)
+	}
+	alice := callerContext("10.0.0.1")
+	now = now.Add(pruneInterval - time.Second)
+	if got := allowed(t, l, alice, getApi, 5); got != 3 {
+		t.Errorf("Allowed %d calls from alice, want 3", got)
+	}
+
+	// Limiters of idle callers are removed, but alice's limiter hasn't refilled yet.
+	now = now.Add(time.Second)
+	if got := allowed(t, l, alice, getApi, 5); got != 1 {
+		t.Errorf("Allowed %d calls from alice after pruning, want 1", got)
+	}
+	if got := len(l.limiters); got != 1 {
+		t.Errorf("Kept %d limiters after pruning, want 1", got)
+	}
+
+	// Removing a refilled limiter doesn't change the calls that are allowed.
+	now = now.Add(pruneInterval)
+	if got := allowed(t, l, alice, getApi, 5); got != 3 {
+		t.Errorf("Allowed %d calls from alice after her limiter was removed, want 3", got)
+	}
+}
+
+func TestRetryInfo(t *testing.T) {
+	now := time.Unix(1000, 0)
+	l := New(Config{PerCaller: Limit{Rate: 0.5, Burst: 1}})
+	l.now = func() time.Time { return now }
+
+	ctx := callerContext("10.0.0.1")
+	if err := l.Allow(ctx, getApi); err != nil {
+		t.Fatalf("Allow(%q) returned error: %s", getApi, err)
+	}
+	err := l.Allow(ctx, getApi)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Allow(%q) returned status code %q, want %q", getApi, status.Code(err), codes.ResourceExhausted)
+	}
+
+	var retry *errdetails.RetryInfo
+	var quota *errdetails.QuotaFailure
+	for _, d := range status.Convert(err).Details() {
+		switch d := d.(type) {
+		case *errdetails.RetryInfo:
+			retry = d
+		case *errdetails.QuotaFailure:
+			quota = d
+		}
+	}
+	if retry == nil {
+		t.Fatalf("Allow(%q) returned no retry info", getApi)
+	}
+	if got := retry.GetRetryDelay().AsDuration(); got != 2*time.Second {
+		t.Errorf("Allow(%q) returned retry delay %s, want %s", getApi, got, 2*time.Second)
+	}
+	if quota == nil || quota.GetViolations()[0].GetSubject() != "ip:10.0.0.1" {
+		t.Errorf("Allow(%q) returned quota failure %v, want subject %q", getApi, quota, "ip:10.0.0.1")
+	}
+
+	// Rejected calls don't consume tokens.
+	now = now.Add(2 * time.Second)
+	if err := l.Allow(ctx, getApi); err != nil {
+		t.Errorf("Allow(%q) returned error after waiting: %s", getApi, err)
+	}
+}
+
+func TestCaller(t *testing.T) {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "ci-bot"}}
+	tlsInfo := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}}
+	unverified := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+	}}
+	tcp := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 12345}
+	pipe := &net.UnixAddr{Name: "bufconn", Net: "bufconn"}
+
+	tests := []struct {
+		desc string
+		peer *peer.Peer
+		md   metadata.MD
+		want string
+	}{
+		{
+			desc: "client certificate",
+			peer: &peer.Peer{Addr: tcp, AuthInfo: tlsInfo},
+			md:   metadata.Pairs("authorization", "Bearer abc"),
+			want: "cert:ci-bot",
+		},
+		{
+			desc: "unverified client certificate",
+			peer: &peer.Peer{Addr: tcp, AuthInfo: unverified},
+			want: "ip:10.0.0.1",
+		},
+		{
+			desc: "authorization header",
+			peer: &peer.Peer{Addr: tcp},
+			md:   metadata.Pairs("authorization", "Bearer abc"),
+			want: "ip:10.0.0.1",
+		},
+		{
+			desc: "address",
+			peer: &peer.Peer{Addr: tcp},
+			want: "ip:10.0.0.1",
+		},
+		{
+			desc: "forwarded header from direct caller",
+			peer: &peer.Peer{Addr: tcp},
+			md:   metadata.Pairs("x-forwarded-for", "192.168.1.1"),
+			want: "ip:10.0.0.1",
+		},
+		{
+			desc: "forwarded header from gateway",
+			peer: &peer.Peer{Addr: pipe},
+			md:   metadata.Pairs("x-forwarded-for", "10.0.0.5"),
+			want: "ip:10.0.0.5",
+		},
+		{
+			desc: "forwarded header set by client",
+			peer: &peer.Peer{Addr: pipe},
+			md:   metadata.Pairs("x-forwarded-for", "192.168.1.1, 10.0.0.5"),
+			want: "ip:10.0.0.5",
+		},
+		{
+			desc: "unix socket",
+			peer: &peer.Peer{Addr: &net.UnixAddr{Name: "/tmp/registry.sock", Net: "unix"}},
+			want: "unix",
+		},
+		{
+			desc: "unknown",
+			want: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if test.peer != nil {
+				ctx = peer.NewContext(ctx, test.peer)
+			}
+			if test.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, test.md)
+			}
+			if got := Caller(ctx); got != test.want {
+				t.Errorf("Caller() returned %q, want %q", got, test.want)
+			}
+		})
+	}
+}
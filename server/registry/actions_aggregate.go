@@ -0,0 +1,129 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AggregateResources handles the corresponding API request.
+func (s *RegistryServer) AggregateResources(ctx context.Context, req *rpc.AggregateResourcesRequest) (*rpc.AggregateResourcesResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	if _, err := names.ParseProjectWithLocation(req.GetParent()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.GetCollection() == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid collection: must be provided")
+	}
+
+	// The collection's parent is its name without the final collection ID.
+	name := req.GetParent() + "/" + req.GetCollection()
+	i := strings.LastIndex(name, "/")
+	parent, collection := name[:i], name[i+1:]
+
+	opts := storage.AggregateOptions{
+		Filter:  req.GetFilter(),
+		GroupBy: req.GetGroupBy(),
+	}
+
+	var groups []storage.AggregateGroup
+	switch collection {
+	case "apis":
+		p, perr := names.ParseProjectWithLocation(parent)
+		if perr != nil {
+			return nil, invalidCollection(req, perr)
+		}
+		groups, err = db.AggregateApis(ctx, p, opts)
+	case "versions":
+		p, perr := names.ParseApi(parent)
+		if perr != nil {
+			return nil, invalidCollection(req, perr)
+		}
+		groups, err = db.AggregateVersions(ctx, p, opts)
+	case "specs":
+		p, perr := names.ParseVersion(parent)
+		if perr != nil {
+			return nil, invalidCollection(req, perr)
+		}
+		groups, err = db.AggregateSpecs(ctx, p, opts)
+	case "deployments":
+		p, perr := names.ParseApi(parent)
+		if perr != nil {
+			return nil, invalidCollection(req, perr)
+		}
+		groups, err = db.AggregateDeployments(ctx, p, opts)
+	case "artifacts":
+		p, perr := parseArtifactParent(parent)
+		if perr != nil {
+			return nil, invalidCollection(req, perr)
+		}
+		groups, err = db.AggregateArtifacts(ctx, artifactOwner(p), opts)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid collection %q: must be one of apis, versions, specs, deployments or artifacts", req.GetCollection())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.AggregateResourcesResponse{
+		Groups: make([]*rpc.AggregateResourcesResponse_Group, len(groups)),
+	}
+	for i, g := range groups {
+		values := make(map[string]string, len(g.Values))
+		for j, field := range req.GetGroupBy() {
+			values[field] = g.Values[j]
+		}
+		response.Groups[i] = &rpc.AggregateResourcesResponse_Group{
+			Values:    values,
+			Count:     g.Count,
+			SizeBytes: g.SizeBytes,
+		}
+	}
+
+	return response, nil
+}
+
+func invalidCollection(req *rpc.AggregateResourcesRequest, err error) error {
+	return status.Errorf(codes.InvalidArgument, "invalid collection %q: %s", req.GetCollection(), err)
+}
+
+// artifactOwner returns the name of an artifact parent in the form used for artifact aggregation,
+// where empty IDs indicate resource types that don't own the artifacts.
+func artifactOwner(parent artifactParent) names.Spec {
+	switch p := parent.(type) {
+	case names.Project:
+		return names.Spec{ProjectID: p.ProjectID}
+	case names.Api:
+		return names.Spec{ProjectID: p.ProjectID, ApiID: p.ApiID}
+	case names.Version:
+		return names.Spec{ProjectID: p.ProjectID, ApiID: p.ApiID, VersionID: p.VersionID}
+	case names.Spec:
+		return p
+	}
+	return names.Spec{}
+}
@@ -0,0 +1,322 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func seedAggregateResources(ctx context.Context, t *testing.T, server *RegistryServer) {
+	t.Helper()
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s1",
+			MimeType: "application/json",
+			Contents: []byte("abc"),
+			Labels:   map[string]string{"team": "a"},
+		},
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s2",
+			MimeType: "text/plain",
+			Contents: []byte("hello"),
+			Labels:   map[string]string{"team": "b"},
+		},
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/b/versions/v1/specs/s1",
+			MimeType: "text/plain",
+			Contents: []byte("hi"),
+		},
+		&rpc.ApiDeployment{
+			Name:   "projects/my-project/locations/global/apis/a/deployments/d1",
+			Labels: map[string]string{"env": "prod"},
+		},
+		&rpc.ApiDeployment{
+			Name:   "projects/my-project/locations/global/apis/a/deployments/d2",
+			Labels: map[string]string{"env": "dev"},
+		},
+		&rpc.ApiDeployment{
+			Name:   "projects/my-project/locations/global/apis/b/deployments/d1",
+			Labels: map[string]string{"env": "prod"},
+		},
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/artifacts/x",
+			Contents: []byte("1234"),
+		},
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/artifacts/y",
+			Contents: []byte("12"),
+		},
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/a/artifacts/z",
+			Contents: []byte("1"),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+}
+
+func TestAggregateResources(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedAggregateResources(ctx, t, server)
+
+	tests := []struct {
+		desc string
+		req  *rpc.AggregateResourcesRequest
+		want []*rpc.AggregateResourcesResponse_Group
+	}{
+		{
+			desc: "specs grouped by mime type",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/versions/-/specs",
+				GroupBy:    []string{"mime_type"},
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{"mime_type": "application/json"}, Count: 1, SizeBytes: 3},
+				{Values: map[string]string{"mime_type": "text/plain"}, Count: 2, SizeBytes: 7},
+			},
+		},
+		{
+			desc: "specs grouped by label",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/versions/-/specs",
+				GroupBy:    []string{"labels.team"},
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{"labels.team": ""}, Count: 1, SizeBytes: 2},
+				{Values: map[string]string{"labels.team": "a"}, Count: 1, SizeBytes: 3},
+				{Values: map[string]string{"labels.team": "b"}, Count: 1, SizeBytes: 5},
+			},
+		},
+		{
+			desc: "filtered specs grouped by api",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/versions/-/specs",
+				Filter:     "mime_type == 'text/plain'",
+				GroupBy:    []string{"api_id"},
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{"api_id": "a"}, Count: 1, SizeBytes: 5},
+				{Values: map[string]string{"api_id": "b"}, Count: 1, SizeBytes: 2},
+			},
+		},
+		{
+			desc: "specs of a single api",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/a/versions/-/specs",
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{}, Count: 2, SizeBytes: 8},
+			},
+		},
+		{
+			desc: "all versions",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/versions",
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{}, Count: 2},
+			},
+		},
+		{
+			desc: "apis with no matches",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis",
+				Filter:     "api_id == 'c'",
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{}, Count: 0},
+			},
+		},
+		{
+			desc: "deployments grouped by api and label",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/deployments",
+				GroupBy:    []string{"labels.env", "api_id"},
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{"labels.env": "dev", "api_id": "a"}, Count: 1},
+				{Values: map[string]string{"labels.env": "prod", "api_id": "a"}, Count: 1},
+				{Values: map[string]string{"labels.env": "prod", "api_id": "b"}, Count: 1},
+			},
+		},
+		{
+			desc: "project artifacts",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "artifacts",
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{}, Count: 2, SizeBytes: 6},
+			},
+		},
+		{
+			desc: "api artifacts",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/artifacts",
+				GroupBy:    []string{"api_id"},
+			},
+			want: []*rpc.AggregateResourcesResponse_Group{
+				{Values: map[string]string{"api_id": "a"}, Count: 1, SizeBytes: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := server.AggregateResources(ctx, test.req)
+			if err != nil {
+				t.Fatalf("AggregateResources(%+v) returned error: %s", test.req, err)
+			}
+			want := &rpc.AggregateResourcesResponse{Groups: test.want}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("AggregateResources(%+v) returned unexpected diff (-want +got):\n%s", test.req, diff)
+			}
+		})
+	}
+}
+
+func TestAggregateResourcesCountsLatestRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedAggregateResources(ctx, t, server)
+
+	_, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s2",
+			Contents: []byte("hello world"),
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	})
+	if err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+
+	for _, groupBy := range [][]string{{"mime_type"}, {"labels.team"}} {
+		req := &rpc.AggregateResourcesRequest{
+			Parent:     "projects/my-project/locations/global",
+			Collection: "apis/a/versions/v1/specs",
+			GroupBy:    groupBy,
+		}
+		got, err := server.AggregateResources(ctx, req)
+		if err != nil {
+			t.Fatalf("AggregateResources(%+v) returned error: %s", req, err)
+		}
+		var count, size int64
+		for _, g := range got.GetGroups() {
+			count += g.GetCount()
+			size += g.GetSizeBytes()
+		}
+		if count != 2 || size != 14 {
+			t.Errorf("AggregateResources(%+v) counted %d specs of %d bytes, want 2 specs of 14 bytes", req, count, size)
+		}
+	}
+}
+
+func TestAggregateResourcesErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedAggregateResources(ctx, t, server)
+
+	tests := []struct {
+		desc string
+		req  *rpc.AggregateResourcesRequest
+		want codes.Code
+	}{
+		{
+			desc: "invalid parent",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project",
+				Collection: "apis",
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing collection",
+			req: &rpc.AggregateResourcesRequest{
+				Parent: "projects/my-project/locations/global",
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "unsupported collection",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/widgets",
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "malformed collection",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "versions/-/specs",
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "non-string group_by field",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/-/versions/-/specs",
+				GroupBy:    []string{"size_bytes"},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "unknown group_by field",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis",
+				GroupBy:    []string{"owner.team"},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing parent",
+			req: &rpc.AggregateResourcesRequest{
+				Parent:     "projects/my-project/locations/global",
+				Collection: "apis/c/versions",
+			},
+			want: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.AggregateResources(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("AggregateResources(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListApiRevisions handles the corresponding API request.
+func (s *RegistryServer) ListApiRevisions(ctx context.Context, req *rpc.ListApiRevisionsRequest) (*rpc.ListApiRevisionsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", req.GetPageSize())
+	} else if req.GetPageSize() > 1000 {
+		req.PageSize = 1000
+	} else if req.GetPageSize() == 0 {
+		req.PageSize = 50
+	}
+
+	if err := validateReadMask(&rpc.Api{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
+	parent, err := names.ParseApi(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Revisions are only listed for resources that currently exist.
+	if _, err := db.GetApi(ctx, parent); err != nil {
+		return nil, err
+	}
+
+	listing, err := db.ListApiRevisions(ctx, parent, storage.PageOptions{
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListApiRevisionsResponse{
+		Apis:          make([]*rpc.Api, len(listing.Revisions)),
+		NextPageToken: listing.Token,
+	}
+
+	for i, revision := range listing.Revisions {
+		response.Apis[i], err = revision.Message()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		applyReadMask(response.Apis[i], req.GetReadMask())
+	}
+
+	return response, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestListApiRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedApis(ctx, server, &rpc.Api{
+		Name:         "projects/my-project/locations/global/apis/my-api",
+		Availability: "Preview",
+	}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	for _, availability := range []string{"GA", "GA"} {
+		req := &rpc.UpdateApiRequest{
+			Api: &rpc.Api{
+				Name:         "projects/my-project/locations/global/apis/my-api",
+				Availability: availability,
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"availability"}},
+		}
+		if _, err := server.UpdateApi(ctx, req); err != nil {
+			t.Fatalf("Setup: UpdateApi(%+v) returned error: %s", req, err)
+		}
+	}
+
+	t.Run("all revisions", func(t *testing.T) {
+		req := &rpc.ListApiRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/my-api",
+		}
+
+		got, err := server.ListApiRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListApiRevisions(%+v) returned error: %s", req, err)
+		}
+
+		// Unchanged metadata should not be recorded as a new revision.
+		if count := len(got.GetApis()); count != 2 {
+			t.Fatalf("ListApiRevisions(%+v) returned %d revisions, want 2", req, count)
+		}
+
+		// Check that the most recent revision is returned first.
+		for i, want := range []string{"GA", "Preview"} {
+			revision := got.GetApis()[i]
+			if revision.GetAvailability() != want {
+				t.Errorf("ListApiRevisions(%+v) returned availability %q at position %d, want %q", req, revision.GetAvailability(), i, want)
+			}
+			if !strings.HasPrefix(revision.GetName(), "projects/my-project/locations/global/apis/my-api@") {
+				t.Errorf("ListApiRevisions(%+v) returned unexpected revision name %q", req, revision.GetName())
+			}
+		}
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		req := &rpc.ListApiRevisionsRequest{
+			Name:     "projects/my-project/locations/global/apis/my-api",
+			PageSize: 1,
+		}
+
+		got, err := server.ListApiRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListApiRevisions(%+v) returned error: %s", req, err)
+		}
+
+		if count := len(got.GetApis()); count != 1 {
+			t.Errorf("ListApiRevisions(%+v) returned %d revisions, want 1", req, count)
+		}
+
+		if got.GetNextPageToken() == "" {
+			t.Errorf("ListApiRevisions(%+v) returned empty next_page_token, expected another page", req)
+		}
+	})
+
+	t.Run("missing api", func(t *testing.T) {
+		req := &rpc.ListApiRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/doesnt-exist",
+		}
+
+		if _, err := server.ListApiRevisions(ctx, req); status.Code(err) != codes.NotFound {
+			t.Errorf("ListApiRevisions(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+
+	t.Run("after delete", func(t *testing.T) {
+		req := &rpc.DeleteApiRequest{
+			Name: "projects/my-project/locations/global/apis/my-api",
+		}
+		if _, err := server.DeleteApi(ctx, req); err != nil {
+			t.Fatalf("DeleteApi(%+v) returned error: %s", req, err)
+		}
+
+		createReq := &rpc.CreateApiRequest{
+			Parent: "projects/my-project/locations/global",
+			ApiId:  "my-api",
+			Api:    &rpc.Api{},
+		}
+		if _, err := server.CreateApi(ctx, createReq); err != nil {
+			t.Fatalf("Setup: CreateApi(%+v) returned error: %s", createReq, err)
+		}
+
+		listReq := &rpc.ListApiRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/my-api",
+		}
+
+		got, err := server.ListApiRevisions(ctx, listReq)
+		if err != nil {
+			t.Fatalf("ListApiRevisions(%+v) returned error: %s", listReq, err)
+		}
+
+		if count := len(got.GetApis()); count != 1 {
+			t.Errorf("ListApiRevisions(%+v) returned %d revisions, want 1", listReq, count)
+		}
+	})
+}
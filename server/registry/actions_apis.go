@@ -59,7 +59,12 @@ func (s *RegistryServer) createApi(ctx context.Context, name names.Api, body *rp
 	}
 
 	// Creation should only succeed when the parent exists.
-	if _, err := db.GetProject(ctx, name.Project()); err != nil {
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkQuota(ctx, db, name.Project(), quotaRequest{apis: 1}); err != nil {
 		return nil, err
 	}
 
@@ -68,10 +73,22 @@ func (s *RegistryServer) createApi(ctx context.Context, name names.Api, body *rp
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if _, err := checkApiLifecycle(project, api, nil); err != nil {
+		return nil, err
+	}
+
+	if err := validateApiReferences(ctx, db, api, &models.Api{}); err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveApi(ctx, api); err != nil {
 		return nil, err
 	}
 
+	if err := db.SaveApiRevision(ctx, models.NewApiRevision(api)); err != nil {
+		return nil, err
+	}
+
 	message, err := api.Message()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -99,6 +116,10 @@ func (s *RegistryServer) DeleteApi(ctx context.Context, req *rpc.DeleteApiReques
 		return nil, err
 	}
 
+	if err := checkReferences(ctx, db, name.Project(), name.String()); err != nil {
+		return nil, err
+	}
+
 	if err := db.DeleteApi(ctx, name); err != nil {
 		return nil, err
 	}
@@ -120,6 +141,10 @@ func (s *RegistryServer) GetApi(ctx context.Context, req *rpc.GetApiRequest) (*r
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := validateReadMask(&rpc.Api{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	api, err := db.GetApi(ctx, name)
 	if err != nil {
 		return nil, err
@@ -130,6 +155,7 @@ func (s *RegistryServer) GetApi(ctx context.Context, req *rpc.GetApiRequest) (*r
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	applyReadMask(message, req.GetReadMask())
 	return message, nil
 }
 
@@ -149,6 +175,10 @@ func (s *RegistryServer) ListApis(ctx context.Context, req *rpc.ListApisRequest)
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.Api{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseProjectWithLocation(req.GetParent())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -158,6 +188,7 @@ func (s *RegistryServer) ListApis(ctx context.Context, req *rpc.ListApisRequest)
 		Size:   req.GetPageSize(),
 		Filter: req.GetFilter(),
 		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -173,6 +204,7 @@ func (s *RegistryServer) ListApis(ctx context.Context, req *rpc.ListApisRequest)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.Apis[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -217,19 +249,44 @@ func (s *RegistryServer) UpdateApi(ctx context.Context, req *rpc.UpdateApiReques
 		return nil, err
 	}
 
+	previous := *api
 	if err := api.Update(req.GetApi(), models.ExpandMask(req.GetApi(), req.GetUpdateMask())); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	transitioned, err := checkApiLifecycle(project, api, &previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateApiReferences(ctx, db, api, &previous); err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveApi(ctx, api); err != nil {
 		return nil, err
 	}
 
+	// Only record a revision when the metadata has actually changed.
+	if !api.MetadataEqual(&previous) {
+		if err := db.SaveApiRevision(ctx, models.NewApiRevision(api)); err != nil {
+			return nil, err
+		}
+	}
+
 	message, err := api.Message()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	s.notify(ctx, rpc.Notification_UPDATED, name.String())
+	if transitioned {
+		s.notifyTransition(ctx, name.String(), previous.Availability, api.Availability)
+	}
 	return message, nil
 }
@@ -45,10 +45,9 @@ func TestCreateApi(t *testing.T) {
 				Parent: "projects/my-project/locations/global",
 				ApiId:  "my-api",
 				Api: &rpc.Api{
-					DisplayName:        "My Display Name",
-					Description:        "My Description",
-					Availability:       "My Availability",
-					RecommendedVersion: "My Version",
+					DisplayName:  "My Display Name",
+					Description:  "My Description",
+					Availability: "My Availability",
 					Labels: map[string]string{
 						"label-key": "label-value",
 					},
@@ -58,11 +57,10 @@ func TestCreateApi(t *testing.T) {
 				},
 			},
 			want: &rpc.Api{
-				Name:               "projects/my-project/locations/global/apis/my-api",
-				DisplayName:        "My Display Name",
-				Description:        "My Description",
-				Availability:       "My Availability",
-				RecommendedVersion: "My Version",
+				Name:         "projects/my-project/locations/global/apis/my-api",
+				DisplayName:  "My Display Name",
+				Description:  "My Description",
+				Availability: "My Availability",
 				Labels: map[string]string{
 					"label-key": "label-value",
 				},
@@ -157,6 +155,26 @@ func TestCreateApiResponseCodes(t *testing.T) {
 			},
 			want: codes.InvalidArgument,
 		},
+		{
+			desc: "recommended version not found",
+			seed: &rpc.Project{Name: "projects/my-project"},
+			req: &rpc.CreateApiRequest{
+				Parent: "projects/my-project/locations/global",
+				ApiId:  "valid-id",
+				Api:    &rpc.Api{RecommendedVersion: "apis/valid-id/versions/v1"},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "recommended deployment not found",
+			seed: &rpc.Project{Name: "projects/my-project"},
+			req: &rpc.CreateApiRequest{
+				Parent: "projects/my-project/locations/global",
+				ApiId:  "valid-id",
+				Api:    &rpc.Api{RecommendedDeployment: "apis/valid-id/deployments/d"},
+			},
+			want: codes.InvalidArgument,
+		},
 		{
 			desc: "long custom identifier",
 			seed: &rpc.Project{Name: "projects/my-project"},
@@ -288,11 +306,10 @@ func TestGetApi(t *testing.T) {
 		{
 			desc: "fully populated resource",
 			seed: &rpc.Api{
-				Name:               "projects/my-project/locations/global/apis/my-api",
-				DisplayName:        "My Display Name",
-				Description:        "My Description",
-				Availability:       "My Availability",
-				RecommendedVersion: "My Version",
+				Name:         "projects/my-project/locations/global/apis/my-api",
+				DisplayName:  "My Display Name",
+				Description:  "My Description",
+				Availability: "My Availability",
 				Labels: map[string]string{
 					"label-key": "label-value",
 				},
@@ -304,11 +321,10 @@ func TestGetApi(t *testing.T) {
 				Name: "projects/my-project/locations/global/apis/my-api",
 			},
 			want: &rpc.Api{
-				Name:               "projects/my-project/locations/global/apis/my-api",
-				DisplayName:        "My Display Name",
-				Description:        "My Description",
-				Availability:       "My Availability",
-				RecommendedVersion: "My Version",
+				Name:         "projects/my-project/locations/global/apis/my-api",
+				DisplayName:  "My Display Name",
+				Description:  "My Description",
+				Availability: "My Availability",
 				Labels: map[string]string{
 					"label-key": "label-value",
 				},
@@ -0,0 +1,219 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListArtifactRevisions handles the corresponding API request.
+func (s *RegistryServer) ListArtifactRevisions(ctx context.Context, req *rpc.ListArtifactRevisionsRequest) (*rpc.ListArtifactRevisionsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size %d: must not be negative", req.GetPageSize())
+	} else if req.GetPageSize() > 1000 {
+		req.PageSize = 1000
+	} else if req.GetPageSize() == 0 {
+		req.PageSize = 50
+	}
+
+	if err := validateReadMask(&rpc.Artifact{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
+	parent, err := names.ParseArtifact(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	listing, err := db.ListArtifactRevisions(ctx, parent, storage.PageOptions{
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListArtifactRevisionsResponse{
+		Artifacts:     make([]*rpc.Artifact, len(listing.Artifacts)),
+		NextPageToken: listing.Token,
+	}
+
+	tags, err := db.GetArtifactTags(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsByRev := artifactTagsByRevision(tags)
+	for i, artifact := range listing.Artifacts {
+		response.Artifacts[i] = artifact.BasicMessage(artifact.RevisionName(), tagsByRev[artifact.RevisionID])
+		applyReadMask(response.Artifacts[i], req.GetReadMask())
+	}
+
+	return response, nil
+}
+
+// DeleteArtifactRevision handles the corresponding API request.
+func (s *RegistryServer) DeleteArtifactRevision(ctx context.Context, req *rpc.DeleteArtifactRevisionRequest) (*rpc.Artifact, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	name, err := names.ParseArtifactRevision(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revision, err := db.GetArtifactRevision(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the retrieved revision ID, which is never a tag.
+	// This is necessary to ensure the actual revision is deleted.
+	name = name.Artifact().Revision(revision.RevisionID)
+
+	// Deleting the only revision would delete the artifact, which should be done with DeleteArtifact.
+	listing, err := db.ListArtifactRevisions(ctx, name.Artifact(), storage.PageOptions{Size: 2})
+	if err != nil {
+		return nil, err
+	} else if len(listing.Artifacts) < 2 {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot delete %q: it is the only revision of %q", name, name.Artifact())
+	}
+
+	if err := db.DeleteArtifactRevision(ctx, name); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, rpc.Notification_DELETED, name.String())
+
+	// return the latest revision of the current artifact
+	return s.getArtifact(ctx, name.Artifact())
+}
+
+// TagArtifactRevision handles the corresponding API request.
+func (s *RegistryServer) TagArtifactRevision(ctx context.Context, req *rpc.TagArtifactRevisionRequest) (*rpc.Artifact, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	if req.GetTag() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q, must not be empty", req.GetTag())
+	} else if len(req.GetTag()) > 40 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag %q, must be 40 characters or less", req.GetTag())
+	}
+
+	// Parse the requested artifact revision name, which may include a tag name.
+	name, err := names.ParseArtifactRevision(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revision, err := db.GetArtifactRevision(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the retrieved revision ID, which is never a tag.
+	// This is necessary to ensure the new tag is associated with a revision ID, not another tag.
+	name = name.Artifact().Revision(revision.RevisionID)
+
+	tag := models.NewArtifactRevisionTag(name, req.GetTag())
+	if err := db.SaveArtifactRevisionTag(ctx, tag); err != nil {
+		return nil, err
+	}
+
+	tags, err := artifactRevisionTags(ctx, db, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, rpc.Notification_UPDATED, name.String())
+	return revision.BasicMessage(tag.String(), tags), nil
+}
+
+// RollbackArtifact handles the corresponding API request.
+func (s *RegistryServer) RollbackArtifact(ctx context.Context, req *rpc.RollbackArtifactRequest) (*rpc.Artifact, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	if req.GetRevisionId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid revision ID %q, must not be empty", req.GetRevisionId())
+	}
+
+	parent, err := names.ParseArtifact(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// Get the target artifact revision to use as a base for the new rollback revision.
+	name := parent.Revision(req.GetRevisionId())
+	target, err := db.GetArtifactRevision(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := db.GetArtifactRevisionContents(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	project := names.Project{ProjectID: parent.ProjectID()}
+	if err := s.checkQuota(ctx, db, project, quotaRequest{blobBytes: int64(target.SizeInBytes)}); err != nil {
+		return nil, err
+	}
+
+	// Save a new rollback revision and a copy of the target revision contents.
+	rollback := target.NewRevision()
+	if err := db.SaveArtifactRevision(ctx, rollback); err != nil {
+		return nil, err
+	}
+
+	if err := db.SaveArtifactRevisionContents(ctx, rollback, blob.Contents); err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, rpc.Notification_CREATED, rollback.RevisionName())
+	return rollback.BasicMessage(rollback.RevisionName(), []string{}), nil
+}
+
+func artifactTagsByRevision(tags []*models.ArtifactRevisionTag) map[string][]string {
+	revTags := make(map[string][]string, len(tags))
+	for _, tag := range tags {
+		revTags[tag.RevisionID] = append(revTags[tag.RevisionID], tag.Tag)
+	}
+
+	return revTags
+}
@@ -0,0 +1,443 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const testArtifact = "projects/my-project/locations/global/artifacts/my-artifact"
+
+func seedArtifactWithRevisions(ctx context.Context, t *testing.T, server *RegistryServer) (first, second *rpc.Artifact) {
+	t.Helper()
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	createReq := &rpc.CreateArtifactRequest{
+		Parent:     "projects/my-project/locations/global",
+		ArtifactId: "my-artifact",
+		Artifact: &rpc.Artifact{
+			MimeType: "application/json",
+			Contents: []byte(`{"contents": "first"}`),
+		},
+	}
+
+	first, err := server.CreateArtifact(ctx, createReq)
+	if err != nil {
+		t.Fatalf("Setup: CreateArtifact(%+v) returned error: %s", createReq, err)
+	}
+
+	replaceReq := &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{
+			Name:     testArtifact,
+			MimeType: "application/json",
+			Contents: artifactContents,
+		},
+	}
+
+	second, err = server.ReplaceArtifact(ctx, replaceReq)
+	if err != nil {
+		t.Fatalf("Setup: ReplaceArtifact(%+v) returned error: %s", replaceReq, err)
+	}
+
+	return first, second
+}
+
+func TestReplaceArtifactRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	first, second := seedArtifactWithRevisions(ctx, t, server)
+
+	if first.GetRevisionId() == second.GetRevisionId() {
+		t.Errorf("ReplaceArtifact() returned unexpected revision_id %q, expected new revision", second.GetRevisionId())
+	}
+
+	if !first.GetCreateTime().AsTime().Equal(second.GetCreateTime().AsTime()) {
+		t.Errorf("ReplaceArtifact() returned unexpected create_time %v, expected no change (%v)", second.GetCreateTime().AsTime(), first.GetCreateTime().AsTime())
+	}
+
+	t.Run("replace without content changes", func(t *testing.T) {
+		req := &rpc.ReplaceArtifactRequest{
+			Artifact: &rpc.Artifact{
+				Name:     testArtifact,
+				MimeType: "application/json",
+				Contents: artifactContents,
+			},
+		}
+
+		got, err := server.ReplaceArtifact(ctx, req)
+		if err != nil {
+			t.Fatalf("ReplaceArtifact(%+v) returned error: %s", req, err)
+		}
+
+		if got.GetRevisionId() != second.GetRevisionId() {
+			t.Errorf("ReplaceArtifact(%+v) returned unexpected revision_id %q, expected no change (%q)", req, got.GetRevisionId(), second.GetRevisionId())
+		}
+	})
+
+	t.Run("replace specific revision", func(t *testing.T) {
+		req := &rpc.ReplaceArtifactRequest{
+			Artifact: &rpc.Artifact{
+				Name: fmt.Sprintf("%s@%s", testArtifact, first.GetRevisionId()),
+			},
+		}
+
+		if _, err := server.ReplaceArtifact(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("ReplaceArtifact(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("GetArtifactContents for original revision", func(t *testing.T) {
+		req := &rpc.GetArtifactContentsRequest{
+			Name: fmt.Sprintf("%s@%s", testArtifact, first.GetRevisionId()),
+		}
+
+		got, err := server.GetArtifactContents(ctx, req)
+		if err != nil {
+			t.Fatalf("GetArtifactContents(%+v) returned error: %s", req, err)
+		}
+
+		if want := []byte(`{"contents": "first"}`); !bytes.Equal(want, got.GetData()) {
+			t.Errorf("GetArtifactContents(%+v) returned unexpected contents %q, want %q", req, got.GetData(), want)
+		}
+	})
+}
+
+func TestTagArtifactRevision(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	first, _ := seedArtifactWithRevisions(ctx, t, server)
+
+	req := &rpc.TagArtifactRevisionRequest{
+		Name: fmt.Sprintf("%s@%s", testArtifact, first.GetRevisionId()),
+		Tag:  "my-tag",
+	}
+
+	got, err := server.TagArtifactRevision(ctx, req)
+	if err != nil {
+		t.Fatalf("TagArtifactRevision(%+v) returned error: %s", req, err)
+	}
+
+	if want := fmt.Sprintf("%s@my-tag", testArtifact); want != got.GetName() {
+		t.Errorf("TagArtifactRevision(%+v) returned unexpected name %q, want %q", req, got.GetName(), want)
+	}
+
+	if got.GetRevisionId() != first.GetRevisionId() {
+		t.Errorf("TagArtifactRevision(%+v) returned unexpected revision_id %q, want %q", req, got.GetRevisionId(), first.GetRevisionId())
+	}
+
+	t.Run("GetArtifact", func(t *testing.T) {
+		req := &rpc.GetArtifactRequest{
+			Name: got.GetName(),
+		}
+
+		got, err := server.GetArtifact(ctx, req)
+		if err != nil {
+			t.Fatalf("GetArtifact(%+v) returned error: %s", req, err)
+		}
+
+		if got.GetRevisionId() != first.GetRevisionId() {
+			t.Errorf("GetArtifact(%+v) returned unexpected revision_id %q, want %q", req, got.GetRevisionId(), first.GetRevisionId())
+		}
+
+		if want := []string{"my-tag"}; !cmp.Equal(want, got.GetRevisionTags()) {
+			t.Errorf("GetArtifact(%+v) returned unexpected revision_tags %v, want %v", req, got.GetRevisionTags(), want)
+		}
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		req := &rpc.TagArtifactRevisionRequest{
+			Name: fmt.Sprintf("%s@%s", testArtifact, first.GetRevisionId()),
+			Tag:  "",
+		}
+
+		if _, err := server.TagArtifactRevision(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("TagArtifactRevision(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("missing revision", func(t *testing.T) {
+		req := &rpc.TagArtifactRevisionRequest{
+			Name: fmt.Sprintf("%s@doesnotexist", testArtifact),
+			Tag:  "my-tag",
+		}
+
+		if _, err := server.TagArtifactRevision(ctx, req); status.Code(err) != codes.NotFound {
+			t.Fatalf("TagArtifactRevision(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+}
+
+func TestRollbackArtifact(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	first, second := seedArtifactWithRevisions(ctx, t, server)
+
+	req := &rpc.RollbackArtifactRequest{
+		Name:       testArtifact,
+		RevisionId: first.GetRevisionId(),
+	}
+
+	got, err := server.RollbackArtifact(ctx, req)
+	if err != nil {
+		t.Fatalf("RollbackArtifact(%+v) returned error: %s", req, err)
+	}
+
+	if id := got.GetRevisionId(); id == first.GetRevisionId() || id == second.GetRevisionId() {
+		t.Errorf("RollbackArtifact(%+v) returned unexpected revision_id %q, expected new revision", req, id)
+	}
+
+	if got.GetHash() != first.GetHash() {
+		t.Errorf("RollbackArtifact(%+v) returned unexpected hash %q, want %q", req, got.GetHash(), first.GetHash())
+	}
+
+	t.Run("GetArtifact", func(t *testing.T) {
+		req := &rpc.GetArtifactRequest{
+			Name: testArtifact,
+		}
+
+		latest, err := server.GetArtifact(ctx, req)
+		if err != nil {
+			t.Fatalf("GetArtifact(%+v) returned error: %s", req, err)
+		}
+
+		if latest.GetRevisionId() != got.GetRevisionId() {
+			t.Errorf("GetArtifact(%+v) returned unexpected revision_id %q, want %q", req, latest.GetRevisionId(), got.GetRevisionId())
+		}
+	})
+
+	t.Run("missing revision_id", func(t *testing.T) {
+		req := &rpc.RollbackArtifactRequest{
+			Name: testArtifact,
+		}
+
+		if _, err := server.RollbackArtifact(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("RollbackArtifact(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("nonexistent revision", func(t *testing.T) {
+		req := &rpc.RollbackArtifactRequest{
+			Name:       testArtifact,
+			RevisionId: "doesnotexist",
+		}
+
+		if _, err := server.RollbackArtifact(ctx, req); status.Code(err) != codes.NotFound {
+			t.Fatalf("RollbackArtifact(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+}
+
+func TestDeleteArtifactRevision(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	first, second := seedArtifactWithRevisions(ctx, t, server)
+
+	t.Run("one of multiple existing revisions", func(t *testing.T) {
+		req := &rpc.DeleteArtifactRevisionRequest{
+			Name: fmt.Sprintf("%s@%s", testArtifact, second.GetRevisionId()),
+		}
+
+		got, err := server.DeleteArtifactRevision(ctx, req)
+		if err != nil {
+			t.Fatalf("DeleteArtifactRevision(%+v) returned error: %s", req, err)
+		}
+
+		if got.GetRevisionId() != first.GetRevisionId() {
+			t.Errorf("DeleteArtifactRevision(%+v) returned unexpected revision_id %q, want %q", req, got.GetRevisionId(), first.GetRevisionId())
+		}
+
+		t.Run("GetArtifact", func(t *testing.T) {
+			req := &rpc.GetArtifactRequest{
+				Name: req.GetName(),
+			}
+
+			if _, err := server.GetArtifact(ctx, req); status.Code(err) != codes.NotFound {
+				t.Fatalf("GetArtifact(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+			}
+		})
+	})
+
+	t.Run("only remaining revision", func(t *testing.T) {
+		req := &rpc.DeleteArtifactRevisionRequest{
+			Name: fmt.Sprintf("%s@%s", testArtifact, first.GetRevisionId()),
+		}
+
+		if _, err := server.DeleteArtifactRevision(ctx, req); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("DeleteArtifactRevision(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+		}
+	})
+}
+
+func TestDeleteArtifactRevisionKeepsDeploymentArtifact(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/a/artifacts/x",
+			MimeType: "text/plain",
+			Contents: []byte("first"),
+		},
+		&rpc.ApiDeployment{
+			Name: "projects/my-project/locations/global/apis/a/deployments/d",
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	replaceReq := &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/a/artifacts/x",
+			MimeType: "text/plain",
+			Contents: []byte("second"),
+		},
+	}
+	second, err := server.ReplaceArtifact(ctx, replaceReq)
+	if err != nil {
+		t.Fatalf("Setup: ReplaceArtifact(%+v) returned error: %s", replaceReq, err)
+	}
+
+	// Store a deployment artifact with the same ID and revision ID as the api artifact.
+	db, err := server.getStorageClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to get storage client: %s", err)
+	}
+	defer db.Close()
+	key := second.GetName() + "@" + second.GetRevisionId()
+	artifact := new(models.Artifact)
+	if err := db.Get(ctx, db.NewKey(gorm.ArtifactEntityName, key), artifact); err != nil {
+		t.Fatalf("Setup: failed to get artifact record: %s", err)
+	}
+	blob := new(models.Blob)
+	if err := db.Get(ctx, db.NewKey(gorm.BlobEntityName, key), blob); err != nil {
+		t.Fatalf("Setup: failed to get blob record: %s", err)
+	}
+	artifact.DeploymentID = "d"
+	if _, err := db.Put(ctx, db.NewKey(gorm.ArtifactEntityName, artifact.RevisionName()), artifact); err != nil {
+		t.Fatalf("Setup: failed to put artifact record: %s", err)
+	}
+	if _, err := db.Put(ctx, db.NewKey(gorm.BlobEntityName, artifact.RevisionName()), blob); err != nil {
+		t.Fatalf("Setup: failed to put blob record: %s", err)
+	}
+
+	req := &rpc.DeleteArtifactRevisionRequest{Name: key}
+	if _, err := server.DeleteArtifactRevision(ctx, req); err != nil {
+		t.Fatalf("DeleteArtifactRevision(%+v) returned error: %s", req, err)
+	}
+
+	deployed := "projects/my-project/locations/global/apis/a/deployments/d/artifacts/x@" + second.GetRevisionId()
+	if _, err := server.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: deployed}); err != nil {
+		t.Errorf("GetArtifact(%q) returned error: %s", deployed, err)
+	}
+	if _, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: deployed}); err != nil {
+		t.Errorf("GetArtifactContents(%q) returned error: %s", deployed, err)
+	}
+}
+
+func TestListArtifactRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	first, second := seedArtifactWithRevisions(ctx, t, server)
+
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(new(rpc.Artifact), "name", "revision_tags", "update_time", "revision_create_time"),
+	}
+
+	var nextToken string
+	t.Run("first page", func(t *testing.T) {
+		req := &rpc.ListArtifactRevisionsRequest{
+			Name:     testArtifact,
+			PageSize: 1,
+		}
+
+		got, err := server.ListArtifactRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifactRevisions(%+v) returned error: %s", req, err)
+		}
+
+		if count := len(got.GetArtifacts()); count != 1 {
+			t.Fatalf("ListArtifactRevisions(%+v) returned %d artifacts, expected exactly one", req, count)
+		}
+
+		// Check that the most recent revision is returned.
+		if !cmp.Equal(second, got.GetArtifacts()[0], opts) {
+			t.Errorf("ListArtifactRevisions(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(second, got.GetArtifacts()[0], opts))
+		}
+
+		if want := fmt.Sprintf("%s@%s", testArtifact, second.GetRevisionId()); got.GetArtifacts()[0].GetName() != want {
+			t.Errorf("ListArtifactRevisions(%+v) returned unexpected name %q, want %q", req, got.GetArtifacts()[0].GetName(), want)
+		}
+
+		if got.GetNextPageToken() == "" {
+			t.Errorf("ListArtifactRevisions(%+v) returned empty next_page_token, expected another page", req)
+		}
+
+		nextToken = got.GetNextPageToken()
+	})
+
+	if t.Failed() {
+		t.Fatal("Cannot test final page after failure on first page")
+	}
+
+	t.Run("final page", func(t *testing.T) {
+		req := &rpc.ListArtifactRevisionsRequest{
+			Name:      testArtifact,
+			PageToken: nextToken,
+		}
+
+		got, err := server.ListArtifactRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListArtifactRevisions(%+v) returned error: %s", req, err)
+		}
+
+		if count := len(got.GetArtifacts()); count != 1 {
+			t.Fatalf("ListArtifactRevisions(%+v) returned %d artifacts, expected exactly one", req, count)
+		}
+
+		// Check that the original revision is returned.
+		if !cmp.Equal(first, got.GetArtifacts()[0], opts) {
+			t.Errorf("ListArtifactRevisions(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(first, got.GetArtifacts()[0], opts))
+		}
+
+		if got.GetNextPageToken() != "" {
+			t.Errorf("ListArtifactRevisions(%+v) returned next_page_token, expected no next page", req)
+		}
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		req := &rpc.ListArtifactRevisionsRequest{
+			Name:     testArtifact,
+			PageSize: -1,
+		}
+
+		if _, err := server.ListArtifactRevisions(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("ListArtifactRevisions(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+}
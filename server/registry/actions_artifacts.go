@@ -98,16 +98,21 @@ func (s *RegistryServer) CreateArtifact(ctx context.Context, req *rpc.CreateArti
 	if err != nil {
 		return nil, err
 	}
-	if err := db.SaveArtifact(ctx, artifact); err != nil {
+
+	project := names.Project{ProjectID: name.ProjectID()}
+	if err := s.checkQuota(ctx, db, project, quotaRequest{blobBytes: int64(artifact.SizeInBytes)}); err != nil {
+		return nil, err
+	}
+	if err := db.SaveArtifactRevision(ctx, artifact); err != nil {
 		return nil, err
 	}
 
-	if err := db.SaveArtifactContents(ctx, artifact, req.Artifact.GetContents()); err != nil {
+	if err := db.SaveArtifactRevisionContents(ctx, artifact, req.Artifact.GetContents()); err != nil {
 		return nil, err
 	}
 
-	s.notify(ctx, rpc.Notification_CREATED, name.String())
-	return artifact.Message(), nil
+	s.notify(ctx, rpc.Notification_CREATED, artifact.RevisionName())
+	return artifact.BasicMessage(name.String(), []string{}), nil
 }
 
 // DeleteArtifact handles the corresponding API request.
@@ -138,44 +143,94 @@ func (s *RegistryServer) DeleteArtifact(ctx context.Context, req *rpc.DeleteArti
 
 // GetArtifact handles the corresponding API request.
 func (s *RegistryServer) GetArtifact(ctx context.Context, req *rpc.GetArtifactRequest) (*rpc.Artifact, error) {
+	if err := validateReadMask(&rpc.Artifact{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
+	var (
+		artifact *rpc.Artifact
+		err      error
+	)
+	if name, nameErr := names.ParseArtifact(req.GetName()); nameErr == nil {
+		artifact, err = s.getArtifact(ctx, name)
+	} else if name, nameErr := names.ParseArtifactRevision(req.GetName()); nameErr == nil {
+		artifact, err = s.getArtifactRevision(ctx, name)
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an artifact or revision", req.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applyReadMask(artifact, req.GetReadMask())
+	return artifact, nil
+}
+
+func (s *RegistryServer) getArtifact(ctx context.Context, name names.Artifact) (*rpc.Artifact, error) {
 	db, err := s.getStorageClient(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 	defer db.Close()
 
-	name, err := names.ParseArtifact(req.GetName())
+	artifact, err := db.GetArtifact(ctx, name)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
-	artifact, err := db.GetArtifact(ctx, name)
+	tags, err := artifactRevisionTags(ctx, db, name.Revision(artifact.RevisionID))
 	if err != nil {
 		return nil, err
 	}
 
-	return artifact.Message(), nil
+	return artifact.BasicMessage(name.String(), tags), nil
 }
 
-// GetArtifactContents handles the corresponding API request.
-func (s *RegistryServer) GetArtifactContents(ctx context.Context, req *rpc.GetArtifactContentsRequest) (*httpbody.HttpBody, error) {
+func (s *RegistryServer) getArtifactRevision(ctx context.Context, name names.ArtifactRevision) (*rpc.Artifact, error) {
 	db, err := s.getStorageClient(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Unavailable, err.Error())
 	}
 	defer db.Close()
 
-	name, err := names.ParseArtifact(req.GetName())
+	revision, err := db.GetArtifactRevision(ctx, name)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 
-	artifact, err := db.GetArtifact(ctx, name)
+	tags, err := artifactRevisionTags(ctx, db, name.Artifact().Revision(revision.RevisionID))
 	if err != nil {
 		return nil, err
 	}
 
-	blob, err := db.GetArtifactContents(ctx, name)
+	return revision.BasicMessage(name.String(), tags), nil
+}
+
+// GetArtifactContents handles the corresponding API request.
+func (s *RegistryServer) GetArtifactContents(ctx context.Context, req *rpc.GetArtifactContentsRequest) (*httpbody.HttpBody, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	var artifact *models.Artifact
+	var revisionName names.ArtifactRevision
+	if name, err := names.ParseArtifact(req.GetName()); err == nil {
+		if artifact, err = db.GetArtifact(ctx, name); err != nil {
+			return nil, err
+		}
+		revisionName = name.Revision(artifact.RevisionID)
+	} else if name, err := names.ParseArtifactRevision(req.GetName()); err == nil {
+		if artifact, err = db.GetArtifactRevision(ctx, name); err != nil {
+			return nil, err
+		}
+		revisionName = name
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an artifact or revision", req.GetName())
+	}
+
+	blob, err := db.GetArtifactRevisionContents(ctx, revisionName)
 	if err != nil {
 		return nil, err
 	}
@@ -202,6 +257,10 @@ func (s *RegistryServer) ListArtifacts(ctx context.Context, req *rpc.ListArtifac
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.Artifact{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := parseArtifactParent(req.GetParent())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -214,24 +273,28 @@ func (s *RegistryServer) ListArtifacts(ctx context.Context, req *rpc.ListArtifac
 			Size:   req.GetPageSize(),
 			Filter: req.GetFilter(),
 			Token:  req.GetPageToken(),
+			Fields: readMaskFields(req.GetReadMask()),
 		})
 	case names.Api:
 		listing, err = db.ListApiArtifacts(ctx, parent, storage.PageOptions{
 			Size:   req.GetPageSize(),
 			Filter: req.GetFilter(),
 			Token:  req.GetPageToken(),
+			Fields: readMaskFields(req.GetReadMask()),
 		})
 	case names.Version:
 		listing, err = db.ListVersionArtifacts(ctx, parent, storage.PageOptions{
 			Size:   req.GetPageSize(),
 			Filter: req.GetFilter(),
 			Token:  req.GetPageToken(),
+			Fields: readMaskFields(req.GetReadMask()),
 		})
 	case names.Spec:
 		listing, err = db.ListSpecArtifacts(ctx, parent, storage.PageOptions{
 			Size:   req.GetPageSize(),
 			Filter: req.GetFilter(),
 			Token:  req.GetPageToken(),
+			Fields: readMaskFields(req.GetReadMask()),
 		})
 	}
 	if err != nil {
@@ -245,6 +308,7 @@ func (s *RegistryServer) ListArtifacts(ctx context.Context, req *rpc.ListArtifac
 
 	for i, artifact := range listing.Artifacts {
 		response.Artifacts[i] = artifact.Message()
+		applyReadMask(response.Artifacts[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -264,7 +328,8 @@ func (s *RegistryServer) ReplaceArtifact(ctx context.Context, req *rpc.ReplaceAr
 	}
 
 	// Replacement should only succeed on artifacts that currently exist.
-	if _, err := db.GetArtifact(ctx, name); err != nil {
+	current, err := db.GetArtifact(ctx, name)
+	if err != nil {
 		return nil, err
 	}
 
@@ -272,14 +337,48 @@ func (s *RegistryServer) ReplaceArtifact(ctx context.Context, req *rpc.ReplaceAr
 	if err != nil {
 		return nil, err
 	}
-	if err := db.SaveArtifact(ctx, artifact); err != nil {
+
+	// Changed contents are saved as a new revision; otherwise the current revision is updated.
+	artifact.CreateTime = current.CreateTime
+	if artifact.Hash == current.Hash {
+		artifact.RevisionID = current.RevisionID
+		artifact.RevisionCreateTime = current.RevisionCreateTime
+	} else {
+		project := names.Project{ProjectID: name.ProjectID()}
+		if err := s.checkQuota(ctx, db, project, quotaRequest{blobBytes: int64(artifact.SizeInBytes)}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.SaveArtifactRevision(ctx, artifact); err != nil {
+		return nil, err
+	}
+
+	if err := db.SaveArtifactRevisionContents(ctx, artifact, req.Artifact.GetContents()); err != nil {
+		return nil, err
+	}
+
+	tags, err := artifactRevisionTags(ctx, db, name.Revision(artifact.RevisionID))
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, rpc.Notification_UPDATED, artifact.RevisionName())
+	return artifact.BasicMessage(name.String(), tags), nil
+}
+
+func artifactRevisionTags(ctx context.Context, db *storage.Client, name names.ArtifactRevision) ([]string, error) {
+	allTags, err := db.GetArtifactTags(ctx, name.Artifact())
+	if err != nil {
 		return nil, err
 	}
 
-	if err := db.SaveArtifactContents(ctx, artifact, req.Artifact.GetContents()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	tags := make([]string, 0)
+	for _, tag := range allTags {
+		if tag.RevisionID == name.RevisionID {
+			tags = append(tags, tag.Tag)
+		}
 	}
 
-	s.notify(ctx, rpc.Notification_UPDATED, name.String())
-	return artifact.Message(), nil
+	return tags, nil
 }
@@ -77,7 +77,7 @@ func TestCreateArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "revision_create_time", "update_time"),
 			}
 
 			if !cmp.Equal(test.want, created, opts) {
@@ -310,7 +310,7 @@ func TestGetArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "revision_create_time", "update_time"),
 			}
 
 			if !cmp.Equal(test.want, got, opts) {
@@ -568,7 +568,7 @@ func TestListArtifacts(t *testing.T) {
 			opts := cmp.Options{
 				protocmp.Transform(),
 				protocmp.IgnoreFields(new(rpc.ListArtifactsResponse), "next_page_token"),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "revision_create_time", "update_time"),
 				protocmp.SortRepeated(func(a, b *rpc.Artifact) bool {
 					return a.GetName() < b.GetName()
 				}),
@@ -749,7 +749,7 @@ func TestListArtifactsSequence(t *testing.T) {
 
 	opts := cmp.Options{
 		protocmp.Transform(),
-		protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+		protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "revision_create_time", "update_time"),
 		cmpopts.SortSlices(func(a, b *rpc.Artifact) bool {
 			return a.GetName() < b.GetName()
 		}),
@@ -841,7 +841,7 @@ func TestReplaceArtifact(t *testing.T) {
 
 			opts := cmp.Options{
 				protocmp.Transform(),
-				protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+				protocmp.IgnoreFields(new(rpc.Artifact), "revision_id", "create_time", "revision_create_time", "update_time"),
 			}
 
 			if !cmp.Equal(test.want, updated, opts) {
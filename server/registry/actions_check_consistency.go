@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// CheckConsistency handles the corresponding API request.
+func (s *RegistryServer) CheckConsistency(ctx context.Context, req *rpc.CheckConsistencyRequest) (*longrunning.Operation, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	var projectID string
+	if req.GetProject() != "" {
+		name, err := names.ParseProject(req.GetProject())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, err := db.GetProject(ctx, name); err != nil {
+			return nil, err
+		}
+		projectID = name.ProjectID
+	}
+
+	problems, err := db.CheckConsistency(ctx, projectID, req.GetRepair())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.CheckConsistencyResponse{}
+	for _, p := range problems {
+		response.Problems = append(response.Problems, &rpc.CheckConsistencyResponse_Problem{
+			Kind:        rpc.CheckConsistencyResponse_Problem_Kind(rpc.CheckConsistencyResponse_Problem_Kind_value[p.Kind]),
+			Entity:      p.Entity,
+			Key:         p.Key,
+			Description: p.Description,
+			Repaired:    p.Repaired,
+		})
+	}
+
+	metadata, _ := anypb.New(&rpc.CheckConsistencyMetadata{})
+	result, _ := anypb.New(response)
+	return &longrunning.Operation{
+		Name:     "checkConsistency",
+		Metadata: metadata,
+		Done:     true,
+		Result:   &longrunning.Operation_Response{Response: result},
+	}, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func checkConsistency(ctx context.Context, t *testing.T, server *RegistryServer, req *rpc.CheckConsistencyRequest) *rpc.CheckConsistencyResponse {
+	t.Helper()
+	op, err := server.CheckConsistency(ctx, req)
+	if err != nil {
+		t.Fatalf("CheckConsistency(%+v) returned error: %s", req, err)
+	}
+	response := new(rpc.CheckConsistencyResponse)
+	unpackOperation(t, op, response)
+	return response
+}
+
+func TestCheckConsistency(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			MimeType: "text/plain",
+			Contents: []byte("contents"),
+		},
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v2/specs/s",
+			MimeType: "text/plain",
+			Contents: []byte("contents"),
+		},
+		&rpc.ApiDeployment{
+			Name:            "projects/my-project/locations/global/apis/a/deployments/d",
+			ApiSpecRevision: "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+		},
+		&rpc.ApiSpec{
+			Name: "projects/other-project/locations/global/apis/a/versions/v1/specs/s",
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CheckConsistencyRequest{}
+	if got := checkConsistency(ctx, t, server, req); len(got.GetProblems()) != 0 {
+		t.Fatalf("CheckConsistency(%+v) found problems in a consistent registry: %+v", req, got.GetProblems())
+	}
+
+	spec, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: "projects/my-project/locations/global/apis/a/versions/v1/specs/s"})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec() returned error: %s", err)
+	}
+	specKey := spec.GetName() + "@" + spec.GetRevisionId()
+	orphanSpec, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: "projects/my-project/locations/global/apis/a/versions/v2/specs/s"})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec() returned error: %s", err)
+	}
+	orphanKey := orphanSpec.GetName() + "@" + orphanSpec.GetRevisionId()
+
+	db, err := server.getStorageClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to get storage client: %s", err)
+	}
+	defer db.Close()
+
+	// Corrupt the stored hash of a spec revision.
+	record := new(models.Spec)
+	if err := db.Get(ctx, db.NewKey(gorm.SpecEntityName, specKey), record); err != nil {
+		t.Fatalf("Setup: failed to get spec record: %s", err)
+	}
+	record.Hash = "bad"
+	if _, err := db.Put(ctx, db.NewKey(gorm.SpecEntityName, specKey), record); err != nil {
+		t.Fatalf("Setup: failed to put spec record: %s", err)
+	}
+
+	// Delete a version, leaving its spec and the spec's contents behind.
+	version := "projects/my-project/locations/global/apis/a/versions/v2"
+	if err := db.Delete(ctx, db.NewQuery(gorm.VersionEntityName).Require("key", version)); err != nil {
+		t.Fatalf("Setup: failed to delete version record: %s", err)
+	}
+	if err := db.Delete(ctx, db.NewQuery(gorm.VersionRevisionEntityName).Require("VersionID", "v2")); err != nil {
+		t.Fatalf("Setup: failed to delete version revision records: %s", err)
+	}
+
+	// Tag a revision that doesn't exist.
+	tag := &models.SpecRevisionTag{ProjectID: "my-project", ApiID: "a", VersionID: "v1", SpecID: "s", RevisionID: "missing", Tag: "t"}
+	if _, err := db.Put(ctx, db.NewKey(gorm.SpecRevisionTagEntityName, tag.String()), tag); err != nil {
+		t.Fatalf("Setup: failed to put tag record: %s", err)
+	}
+
+	// Recommend a version that doesn't exist.
+	api := new(models.Api)
+	apiKey := "projects/my-project/locations/global/apis/a"
+	if err := db.Get(ctx, db.NewKey(gorm.ApiEntityName, apiKey), api); err != nil {
+		t.Fatalf("Setup: failed to get api record: %s", err)
+	}
+	api.RecommendedVersion = version
+	if _, err := db.Put(ctx, db.NewKey(gorm.ApiEntityName, apiKey), api); err != nil {
+		t.Fatalf("Setup: failed to put api record: %s", err)
+	}
+
+	want := []*rpc.CheckConsistencyResponse_Problem{
+		{Kind: rpc.CheckConsistencyResponse_Problem_ORPHAN, Entity: gorm.SpecEntityName, Key: orphanKey},
+		{Kind: rpc.CheckConsistencyResponse_Problem_DANGLING_TAG, Entity: gorm.SpecRevisionTagEntityName, Key: tag.String()},
+		{Kind: rpc.CheckConsistencyResponse_Problem_HASH_MISMATCH, Entity: gorm.SpecEntityName, Key: specKey},
+		{Kind: rpc.CheckConsistencyResponse_Problem_ORPHAN, Entity: gorm.BlobEntityName, Key: orphanKey},
+		{Kind: rpc.CheckConsistencyResponse_Problem_BAD_REFERENCE, Entity: gorm.ApiEntityName, Key: apiKey},
+	}
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(new(rpc.CheckConsistencyResponse_Problem), "description", "repaired"),
+		cmpopts.EquateEmpty(),
+	}
+
+	t.Run("project", func(t *testing.T) {
+		req := &rpc.CheckConsistencyRequest{Project: "projects/other-project"}
+		if got := checkConsistency(ctx, t, server, req); len(got.GetProblems()) != 0 {
+			t.Errorf("CheckConsistency(%+v) found problems in a consistent project: %+v", req, got.GetProblems())
+		}
+	})
+
+	t.Run("check", func(t *testing.T) {
+		req := &rpc.CheckConsistencyRequest{Project: "projects/my-project"}
+		got := checkConsistency(ctx, t, server, req)
+		if diff := cmp.Diff(want, got.GetProblems(), opts); diff != "" {
+			t.Errorf("CheckConsistency(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+		}
+		for _, p := range got.GetProblems() {
+			if p.GetRepaired() {
+				t.Errorf("CheckConsistency(%+v) repaired %+v without repair", req, p)
+			}
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		req := &rpc.CheckConsistencyRequest{Repair: true}
+		got := checkConsistency(ctx, t, server, req)
+		if diff := cmp.Diff(want, got.GetProblems(), opts); diff != "" {
+			t.Errorf("CheckConsistency(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+		}
+		for _, p := range got.GetProblems() {
+			if !p.GetRepaired() {
+				t.Errorf("CheckConsistency(%+v) didn't repair %+v", req, p)
+			}
+		}
+
+		req = &rpc.CheckConsistencyRequest{}
+		if got := checkConsistency(ctx, t, server, req); len(got.GetProblems()) != 0 {
+			t.Errorf("CheckConsistency(%+v) found problems after repair: %+v", req, got.GetProblems())
+		}
+		if _, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.GetName()}); err != nil {
+			t.Errorf("GetApiSpecContents(%q) returned error after repair: %s", spec.GetName(), err)
+		}
+	})
+}
+
+func TestCheckConsistencyErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	tests := []struct {
+		desc string
+		req  *rpc.CheckConsistencyRequest
+		want codes.Code
+	}{
+		{
+			desc: "invalid project",
+			req:  &rpc.CheckConsistencyRequest{Project: "invalid"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing project",
+			req:  &rpc.CheckConsistencyRequest{Project: "projects/missing"},
+			want: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.CheckConsistency(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("CheckConsistency(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
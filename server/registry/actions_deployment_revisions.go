@@ -41,14 +41,19 @@ func (s *RegistryServer) ListApiDeploymentRevisions(ctx context.Context, req *rp
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.ApiDeployment{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseDeployment(req.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	listing, err := db.ListDeploymentRevisions(ctx, parent, storage.PageOptions{
-		Size:  req.GetPageSize(),
-		Token: req.GetPageToken(),
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -70,6 +75,7 @@ func (s *RegistryServer) ListApiDeploymentRevisions(ctx context.Context, req *rp
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.ApiDeployments[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -100,6 +106,19 @@ func (s *RegistryServer) DeleteApiDeploymentRevision(ctx context.Context, req *r
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := checkReferences(ctx, db, name.Deployment().Project(), name.String()); err != nil {
+		return nil, err
+	}
+
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	if !req.GetForce() {
+		if err := checkProtectedDeploymentTags(ctx, db, name.Deployment(), name.RevisionID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := db.DeleteDeploymentRevision(ctx, name); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -148,7 +167,22 @@ func (s *RegistryServer) TagApiDeploymentRevision(ctx context.Context, req *rpc.
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	// An existing tag is moved to the revision, unless it is protected.
 	tag := models.NewDeploymentRevisionTag(name, req.GetTag())
+	tag.Protected = req.GetProtected()
+	if existing, err := db.GetDeploymentRevisionTag(ctx, name.Deployment().Revision(req.GetTag())); err == nil {
+		changed := existing.RevisionID != tag.RevisionID || !tag.Protected
+		if existing.Protected && changed && !req.GetForce() {
+			return nil, protectedTagError(existing.String())
+		}
+		tag.CreateTime = existing.CreateTime
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+
 	if err := db.SaveDeploymentRevisionTag(ctx, tag); err != nil {
 		return nil, err
 	}
@@ -197,6 +231,10 @@ func (s *RegistryServer) RollbackApiDeployment(ctx context.Context, req *rpc.Rol
 		return nil, err
 	}
 
+	if err := s.enforceDeploymentRetention(ctx, db, parent); err != nil {
+		return nil, err
+	}
+
 	message, err := rollback.BasicMessage(rollback.RevisionName(), []string{})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
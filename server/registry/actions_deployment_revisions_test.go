@@ -30,13 +30,15 @@ import (
 
 var (
 	// Example deployment api_spec_revision.
-	deploymentApiSpecRevision = "/projects/p/apis/a/versions/v/specs/s@12345678"
+	deploymentApiSpecRevision = "apis/my-api/versions/v/specs/s"
+	// The spec named by deploymentApiSpecRevision, which must be seeded before it is used.
+	deploymentSpec = &rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/my-api/versions/v/specs/s"}
 )
 
 func TestTagApiDeploymentRevision(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
-	if err := seeder.SeedDeployments(ctx, server, &rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/d"}); err != nil {
+	if err := seeder.SeedRegistry(ctx, server, &rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/d"}, deploymentSpec); err != nil {
 		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 	}
 
@@ -164,7 +166,7 @@ func TestTagApiDeploymentRevision(t *testing.T) {
 func TestRollbackApiDeployment(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
-	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}); err != nil {
+	if err := seeder.SeedRegistry(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}, deploymentSpec); err != nil {
 		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 	}
 
@@ -234,7 +236,7 @@ func TestRollbackApiDeployment(t *testing.T) {
 func TestDeleteApiDeploymentRevision(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
-	if err := seeder.SeedDeployments(ctx, server, &rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/d"}); err != nil {
+	if err := seeder.SeedRegistry(ctx, server, &rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/d"}, deploymentSpec); err != nil {
 		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 	}
 
@@ -287,7 +289,7 @@ func TestDeleteApiDeploymentRevision(t *testing.T) {
 func TestListApiDeploymentRevisions(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
-	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}); err != nil {
+	if err := seeder.SeedRegistry(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}, deploymentSpec); err != nil {
 		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 	}
 
@@ -398,7 +400,7 @@ func TestListApiDeploymentRevisions(t *testing.T) {
 func TestUpdateApiDeploymentRevisions(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
-	if err := seeder.SeedApis(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}); err != nil {
+	if err := seeder.SeedRegistry(ctx, server, &rpc.Api{Name: "projects/my-project/locations/global/apis/my-api"}, deploymentSpec); err != nil {
 		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 	}
 
@@ -469,6 +471,35 @@ func TestUpdateApiDeploymentRevisions(t *testing.T) {
 		}
 	})
 
+	t.Run("modify revision with api_spec_revision reference to a revision", func(t *testing.T) {
+		spec, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: deploymentSpec.GetName()})
+		if err != nil {
+			t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", deploymentSpec.GetName(), err)
+		}
+		req := &rpc.UpdateApiDeploymentRequest{
+			ApiDeployment: &rpc.ApiDeployment{
+				Name:            created.GetName(),
+				ApiSpecRevision: fmt.Sprintf("%s@%s", deploymentApiSpecRevision, spec.GetRevisionId()),
+			},
+		}
+		want := proto.Clone(created).(*rpc.ApiDeployment)
+		want.ApiSpecRevision = req.GetApiDeployment().GetApiSpecRevision()
+
+		got, err := server.UpdateApiDeployment(ctx, req)
+		if err != nil {
+			t.Fatalf("UpdateApiDeployment(%+v) returned error: %s", req, err)
+		}
+
+		if !cmp.Equal(want, got, opts) {
+			t.Errorf("UpdateApiDeployment(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, got, opts))
+		}
+
+		req.ApiDeployment.ApiSpecRevision = deploymentApiSpecRevision + "@missing"
+		if _, err := server.UpdateApiDeployment(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UpdateApiDeployment(%+v) returned unexpected status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+
 	t.Run("modify specific revision", func(t *testing.T) {
 		req := &rpc.UpdateApiDeploymentRequest{
 			ApiDeployment: &rpc.ApiDeployment{
@@ -24,7 +24,9 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // CreateApiDeployment handles the corresponding API request.
@@ -68,6 +70,10 @@ func (s *RegistryServer) createDeployment(ctx context.Context, name names.Deploy
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := validateDeploymentReferences(ctx, db, deployment, ""); err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveDeploymentRevision(ctx, deployment); err != nil {
 		return nil, err
 	}
@@ -99,6 +105,19 @@ func (s *RegistryServer) DeleteApiDeployment(ctx context.Context, req *rpc.Delet
 		return nil, err
 	}
 
+	if err := checkReferences(ctx, db, name.Project(), name.String()); err != nil {
+		return nil, err
+	}
+
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	if !req.GetForce() {
+		if err := checkProtectedDeploymentTags(ctx, db, name, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := db.DeleteDeployment(ctx, name); err != nil {
 		return nil, err
 	}
@@ -109,13 +128,27 @@ func (s *RegistryServer) DeleteApiDeployment(ctx context.Context, req *rpc.Delet
 
 // GetApiDeployment handles the corresponding API request.
 func (s *RegistryServer) GetApiDeployment(ctx context.Context, req *rpc.GetApiDeploymentRequest) (*rpc.ApiDeployment, error) {
-	if name, err := names.ParseDeployment(req.GetName()); err == nil {
-		return s.getApiDeployment(ctx, name)
-	} else if name, err := names.ParseDeploymentRevision(req.GetName()); err == nil {
-		return s.getApiDeploymentRevision(ctx, name)
+	if err := validateReadMask(&rpc.ApiDeployment{}, req.GetReadMask()); err != nil {
+		return nil, err
 	}
 
-	return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API deployment or revision", req.GetName())
+	var (
+		deployment *rpc.ApiDeployment
+		err        error
+	)
+	if name, nameErr := names.ParseDeployment(req.GetName()); nameErr == nil {
+		deployment, err = s.getApiDeployment(ctx, name)
+	} else if name, nameErr := names.ParseDeploymentRevision(req.GetName()); nameErr == nil {
+		deployment, err = s.getApiDeploymentRevision(ctx, name)
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API deployment or revision", req.GetName())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applyReadMask(deployment, req.GetReadMask())
+	return deployment, nil
 }
 
 func (s *RegistryServer) getApiDeployment(ctx context.Context, name names.Deployment) (*rpc.ApiDeployment, error) {
@@ -184,6 +217,10 @@ func (s *RegistryServer) ListApiDeployments(ctx context.Context, req *rpc.ListAp
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.ApiDeployment{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseApi(req.GetParent())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -193,6 +230,7 @@ func (s *RegistryServer) ListApiDeployments(ctx context.Context, req *rpc.ListAp
 		Size:   req.GetPageSize(),
 		Filter: req.GetFilter(),
 		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -214,6 +252,7 @@ func (s *RegistryServer) ListApiDeployments(ctx context.Context, req *rpc.ListAp
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.ApiDeployments[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -259,8 +298,21 @@ func (s *RegistryServer) UpdateApiDeployment(ctx context.Context, req *rpc.Updat
 	}
 
 	// Apply the update to the deployment - possibly changing the revision ID.
+	previousRevisionID := deployment.RevisionID
+	previousSpec := deployment.ApiSpecRevision
 	maskExpansion := models.ExpandMask(req.GetApiDeployment(), req.GetUpdateMask())
-	if err := deployment.Update(req.GetApiDeployment(), maskExpansion); err != nil {
+	body := req.GetApiDeployment()
+	specUpdated := len(fieldmaskpb.Intersect(maskExpansion, &fieldmaskpb.FieldMask{Paths: []string{apiSpecRevisionField}}).GetPaths()) > 0
+	if spec := body.GetApiSpecRevision(); specUpdated && spec != "" && spec != previousSpec {
+		// Resolve tags before the update so that a tag of the current spec revision isn't a change.
+		resolved, err := resolveSpecReference(ctx, db, name.Project(), spec)
+		if err != nil {
+			return nil, err
+		}
+		body = proto.Clone(body).(*rpc.ApiDeployment)
+		body.ApiSpecRevision = resolved
+	}
+	if err := deployment.Update(body, maskExpansion); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -269,6 +321,13 @@ func (s *RegistryServer) UpdateApiDeployment(ctx context.Context, req *rpc.Updat
 		return nil, err
 	}
 
+	// New revisions may push older ones outside the project's retention policy.
+	if deployment.RevisionID != previousRevisionID {
+		if err := s.enforceDeploymentRetention(ctx, db, name); err != nil {
+			return nil, err
+		}
+	}
+
 	tags, err := deploymentRevisionTags(ctx, db, name.Revision(deployment.RevisionID))
 	if err != nil {
 		return nil, err
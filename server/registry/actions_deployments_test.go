@@ -391,6 +391,7 @@ func TestGetApiDeploymentResponseCodes(t *testing.T) {
 func TestListApiDeployments(t *testing.T) {
 	tests := []struct {
 		desc      string
+		specs     []*rpc.ApiSpec
 		seed      []*rpc.ApiDeployment
 		req       *rpc.ListApiDeploymentsRequest
 		want      *rpc.ListApiDeploymentsResponse
@@ -418,13 +419,16 @@ func TestListApiDeployments(t *testing.T) {
 		},
 		{
 			desc: "with specs containing multiple revisions",
+			specs: []*rpc.ApiSpec{
+				{Name: "projects/my-project/locations/global/apis/a1/versions/v1/specs/s"},
+			},
 			seed: []*rpc.ApiDeployment{
 				{
 					Name: "projects/my-project/locations/global/apis/a1/deployments/d1",
 				},
 				{
 					Name:            "projects/my-project/locations/global/apis/a1/deployments/d1",
-					ApiSpecRevision: "some-revision",
+					ApiSpecRevision: "apis/a1/versions/v1/specs/s",
 				},
 				{
 					Name: "projects/my-project/locations/global/apis/a1/deployments/d2",
@@ -437,7 +441,7 @@ func TestListApiDeployments(t *testing.T) {
 				ApiDeployments: []*rpc.ApiDeployment{
 					{
 						Name:            "projects/my-project/locations/global/apis/a1/deployments/d1",
-						ApiSpecRevision: "some-revision",
+						ApiSpecRevision: "apis/a1/versions/v1/specs/s",
 					},
 					{
 						Name: "projects/my-project/locations/global/apis/a1/deployments/d2",
@@ -561,7 +565,14 @@ func TestListApiDeployments(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			ctx := context.Background()
 			server := defaultTestServer(t)
-			if err := seeder.SeedDeployments(ctx, server, test.seed...); err != nil {
+			resources := make([]seeder.RegistryResource, 0, len(test.specs)+len(test.seed))
+			for _, spec := range test.specs {
+				resources = append(resources, spec)
+			}
+			for _, deployment := range test.seed {
+				resources = append(resources, deployment)
+			}
+			if err := seeder.SeedRegistry(ctx, server, resources...); err != nil {
 				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
 			}
 
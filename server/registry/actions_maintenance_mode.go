@@ -0,0 +1,33 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+)
+
+// SetMaintenanceMode handles the corresponding API request.
+func (s *RegistryServer) SetMaintenanceMode(ctx context.Context, req *rpc.SetMaintenanceModeRequest) (*rpc.MaintenanceMode, error) {
+	mode := s.maintenance.set(req.GetReadOnly(), req.GetMessage())
+	if mode.GetReadOnly() {
+		log.FromContext(ctx).Warnf("Entered read-only maintenance mode: %s", mode.GetMessage())
+	} else {
+		log.FromContext(ctx).Info("Left read-only maintenance mode.")
+	}
+	return mode, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ExportProject handles the corresponding API request.
+func (s *RegistryServer) ExportProject(ctx context.Context, req *rpc.ExportProjectRequest) (*longrunning.Operation, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	name, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var archive bytes.Buffer
+	manifest, err := db.ExportProject(ctx, name, &archive)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, _ := anypb.New(&rpc.ExportProjectMetadata{})
+	response, _ := anypb.New(&rpc.ExportProjectResponse{
+		Archive:      archive.Bytes(),
+		RecordCounts: manifest.Records,
+	})
+	return &longrunning.Operation{
+		Name:     "export",
+		Metadata: metadata,
+		Done:     true,
+		Result:   &longrunning.Operation_Response{Response: response},
+	}, nil
+}
+
+// ImportProject handles the corresponding API request.
+func (s *RegistryServer) ImportProject(ctx context.Context, req *rpc.ImportProjectRequest) (*longrunning.Operation, error) {
+	if len(req.GetArchive()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid archive: must be provided")
+	}
+
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	name, counts, err := db.ImportProject(ctx, bytes.NewReader(req.GetArchive()), req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := db.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	message, err := project.Message()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	s.notify(ctx, rpc.Notification_CREATED, name.String())
+
+	metadata, _ := anypb.New(&rpc.ImportProjectMetadata{})
+	response, _ := anypb.New(&rpc.ImportProjectResponse{
+		Project:      message,
+		RecordCounts: counts,
+	})
+	return &longrunning.Operation{
+		Name:     "import",
+		Metadata: metadata,
+		Done:     true,
+		Result:   &longrunning.Operation_Response{Response: response},
+	}, nil
+}
+
+// DownloadProjectArchive handles the corresponding API request.
+func (s *RegistryServer) DownloadProjectArchive(req *rpc.DownloadProjectArchiveRequest, stream rpc.Admin_DownloadProjectArchiveServer) error {
+	ctx := stream.Context()
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	name, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	w := &archiveChunkWriter{stream: stream}
+	manifest, err := db.ExportProject(ctx, name, w)
+	if err != nil {
+		return err
+	}
+
+	// The last chunk carries the record counts, which are only known once the archive is written.
+	return stream.Send(&rpc.ProjectArchiveChunk{
+		Data:         w.buf,
+		RecordCounts: manifest.Records,
+	})
+}
+
+// archiveChunkWriter sends everything written to it as archive chunks of at most contentsChunkSize bytes.
+// Bytes that don't fill a chunk are held until the next write so that callers can send them in the last chunk.
+type archiveChunkWriter struct {
+	stream rpc.Admin_DownloadProjectArchiveServer
+	buf    []byte
+}
+
+func (w *archiveChunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) > contentsChunkSize {
+		if err := w.stream.Send(&rpc.ProjectArchiveChunk{Data: w.buf[:contentsChunkSize]}); err != nil {
+			return 0, err
+		}
+		w.buf = append([]byte(nil), w.buf[contentsChunkSize:]...)
+	}
+	return len(p), nil
+}
+
+// UploadProjectArchive handles the corresponding API request.
+func (s *RegistryServer) UploadProjectArchive(stream rpc.Admin_UploadProjectArchiveServer) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "invalid upload: at least one request must be sent")
+	} else if err != nil {
+		return err
+	}
+
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	r := &archiveChunkReader{stream: stream, buf: first.GetChunk()}
+	name, counts, err := db.ImportProject(ctx, r, first.GetProjectId())
+	if r.err != nil {
+		return r.err
+	} else if err != nil {
+		return err
+	}
+
+	project, err := db.GetProject(ctx, name)
+	if err != nil {
+		return err
+	}
+	message, err := project.Message()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	s.notify(ctx, rpc.Notification_CREATED, name.String())
+
+	return stream.SendAndClose(&rpc.ImportProjectResponse{
+		Project:      message,
+		RecordCounts: counts,
+	})
+}
+
+// archiveChunkReader reads the chunks of an uploaded archive as they are received.
+// Stream errors are kept so that they can be returned instead of archive errors.
+type archiveChunkReader struct {
+	stream rpc.Admin_UploadProjectArchiveServer
+	buf    []byte
+	err    error
+}
+
+func (r *archiveChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err == io.EOF {
+			return 0, io.EOF
+		} else if err != nil {
+			r.err = err
+			return 0, err
+		}
+		if req.GetProjectId() != "" {
+			r.err = status.Error(codes.InvalidArgument, "invalid project_id: must only be set in the first request")
+			return 0, r.err
+		}
+		r.buf = req.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,408 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func exportProject(ctx context.Context, t *testing.T, server *RegistryServer, name string) *rpc.ExportProjectResponse {
+	t.Helper()
+	op, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: name})
+	if err != nil {
+		t.Fatalf("ExportProject(%q) returned error: %s", name, err)
+	}
+	response := new(rpc.ExportProjectResponse)
+	unpackOperation(t, op, response)
+	return response
+}
+
+func unpackOperation(t *testing.T, op *longrunning.Operation, response proto.Message) {
+	t.Helper()
+	if !op.GetDone() {
+		t.Fatalf("Operation %q is not done", op.GetName())
+	}
+	if err := op.GetResponse().UnmarshalTo(response); err != nil {
+		t.Fatalf("Operation %q has unexpected response: %s", op.GetName(), err)
+	}
+}
+
+func TestExportImportProject(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			MimeType: "text/plain",
+			Contents: []byte("first"),
+		},
+		&rpc.ApiDeployment{
+			Name:            "projects/my-project/locations/global/apis/a/deployments/d",
+			ApiSpecRevision: "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+		},
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/apis/a/artifacts/x",
+			MimeType: "text/plain",
+			Contents: []byte("artifact"),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	spec := "projects/my-project/locations/global/apis/a/versions/v1/specs/s"
+	updated, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec:    &rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	})
+	if err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+	revision := spec + "@" + updated.GetRevisionId()
+	if _, err := server.TagApiSpecRevision(ctx, &rpc.TagApiSpecRevisionRequest{Name: revision, Tag: "stable"}); err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision() returned error: %s", err)
+	}
+
+	exported := exportProject(ctx, t, server, "projects/my-project")
+	wantCounts := map[string]int64{
+		"Project":               1,
+		"Api":                   1,
+		"ApiRevision":           1,
+		"Version":               1,
+		"VersionRevision":       1,
+		"Spec":                  2,
+		"SpecRevisionTag":       1,
+		"Deployment":            1,
+		"DeploymentRevisionTag": 0,
+		"Artifact":              1,
+		"ArtifactRevisionTag":   0,
+		"Blob":                  3,
+	}
+	for kind, want := range wantCounts {
+		if got := exported.GetRecordCounts()[kind]; got != want {
+			t.Errorf("ExportProject() exported %d %s records, want %d", got, kind, want)
+		}
+	}
+
+	op, err := server.ImportProject(ctx, &rpc.ImportProjectRequest{
+		ProjectId: "my-copy",
+		Archive:   exported.GetArchive(),
+	})
+	if err != nil {
+		t.Fatalf("ImportProject() returned error: %s", err)
+	}
+	imported := new(rpc.ImportProjectResponse)
+	unpackOperation(t, op, imported)
+	if imported.GetProject().GetName() != "projects/my-copy" {
+		t.Errorf("ImportProject() imported project %q, want %q", imported.GetProject().GetName(), "projects/my-copy")
+	}
+	if diff := cmp.Diff(exported.GetRecordCounts(), imported.GetRecordCounts()); diff != "" {
+		t.Errorf("ImportProject() returned unexpected record counts (-exported +imported):\n%s", diff)
+	}
+
+	copySpec := strings.Replace(spec, "my-project", "my-copy", 1)
+	revisions, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: copySpec})
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%q) returned error: %s", copySpec, err)
+	}
+	if got := len(revisions.GetApiSpecs()); got != 2 {
+		t.Errorf("ListApiSpecRevisions(%q) returned %d revisions, want 2", copySpec, got)
+	}
+
+	for name, want := range map[string]string{
+		copySpec + "@stable": "second",
+		copySpec + "@" + revisions.GetApiSpecs()[1].GetRevisionId(): "first",
+	} {
+		contents, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: name})
+		if err != nil {
+			t.Fatalf("GetApiSpecContents(%q) returned error: %s", name, err)
+		}
+		if got := string(contents.GetData()); got != want {
+			t.Errorf("GetApiSpecContents(%q) returned %q, want %q", name, got, want)
+		}
+	}
+
+	deployment, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{
+		Name: "projects/my-copy/locations/global/apis/a/deployments/d",
+	})
+	if err != nil {
+		t.Fatalf("GetApiDeployment() returned error: %s", err)
+	}
+	if got, want := deployment.GetApiSpecRevision(), copySpec; got != want {
+		t.Errorf("GetApiDeployment() returned spec revision %q, want %q", got, want)
+	}
+
+	contents, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{
+		Name: "projects/my-copy/locations/global/apis/a/artifacts/x",
+	})
+	if err != nil {
+		t.Fatalf("GetArtifactContents() returned error: %s", err)
+	}
+	if got := string(contents.GetData()); got != "artifact" {
+		t.Errorf("GetArtifactContents() returned %q, want %q", got, "artifact")
+	}
+
+	// Exporting the copy should produce the same records as the original.
+	copied := exportProject(ctx, t, server, "projects/my-copy")
+	if diff := cmp.Diff(exported.GetRecordCounts(), copied.GetRecordCounts(), protocmp.Transform()); diff != "" {
+		t.Errorf("ExportProject() returned unexpected record counts for copy (-original +copy):\n%s", diff)
+	}
+}
+
+func TestImportProjectErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	archive := exportProject(ctx, t, server, "projects/my-project").GetArchive()
+
+	tests := []struct {
+		desc string
+		req  *rpc.ImportProjectRequest
+		want codes.Code
+	}{
+		{
+			desc: "existing project",
+			req:  &rpc.ImportProjectRequest{Archive: archive},
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "invalid project id",
+			req:  &rpc.ImportProjectRequest{ProjectId: "Invalid/ID", Archive: archive},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing archive",
+			req:  &rpc.ImportProjectRequest{ProjectId: "my-copy"},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "malformed archive",
+			req:  &rpc.ImportProjectRequest{ProjectId: "my-copy", Archive: []byte("not an archive")},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "truncated archive",
+			req:  &rpc.ImportProjectRequest{ProjectId: "my-copy", Archive: archive[:len(archive)/2]},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := server.ImportProject(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("ImportProject(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+			if test.req.GetProjectId() == "my-copy" {
+				if _, err := server.GetProject(ctx, &rpc.GetProjectRequest{Name: "projects/my-copy"}); status.Code(err) != codes.NotFound {
+					t.Errorf("GetProject(%q) returned status code %q after failed import, want %q", "projects/my-copy", status.Code(err), codes.NotFound)
+				}
+			}
+		})
+	}
+}
+
+func TestExportProjectErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	for name, want := range map[string]codes.Code{
+		"projects/missing":         codes.NotFound,
+		"projects/my-project/apis": codes.InvalidArgument,
+	} {
+		if _, err := server.ExportProject(ctx, &rpc.ExportProjectRequest{Name: name}); status.Code(err) != want {
+			t.Errorf("ExportProject(%q) returned status code %q, want %q: %v", name, status.Code(err), want, err)
+		}
+	}
+}
+
+// archiveDownloadStream is a fake server stream that records the archive chunks it is sent.
+type archiveDownloadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*rpc.ProjectArchiveChunk
+}
+
+func (s *archiveDownloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *archiveDownloadStream) Send(chunk *rpc.ProjectArchiveChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+// archiveUploadStream is a fake client stream that sends a fixed list of archive requests.
+type archiveUploadStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*rpc.UploadProjectArchiveRequest
+	resp *rpc.ImportProjectResponse
+}
+
+func (s *archiveUploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *archiveUploadStream) Recv() (*rpc.UploadProjectArchiveRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *archiveUploadStream) SendAndClose(resp *rpc.ImportProjectResponse) error {
+	s.resp = resp
+	return nil
+}
+
+func archiveUploadRequests(projectID string, archive []byte, chunkSize int) []*rpc.UploadProjectArchiveRequest {
+	reqs := []*rpc.UploadProjectArchiveRequest{{ProjectId: projectID}}
+	for offset := 0; offset < len(archive); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(archive) {
+			end = len(archive)
+		}
+		reqs = append(reqs, &rpc.UploadProjectArchiveRequest{Chunk: archive[offset:end]})
+	}
+	return reqs
+}
+
+func TestDownloadUploadProjectArchive(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	// Random contents don't compress, so the archive spans several chunks.
+	contents := make([]byte, 2*contentsChunkSize)
+	rand.New(rand.NewSource(1)).Read(contents)
+	err := seeder.SeedRegistry(ctx, server, &rpc.ApiSpec{
+		Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+		MimeType: "application/octet-stream",
+		Contents: contents,
+	})
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	download := &archiveDownloadStream{ctx: ctx}
+	if err := server.DownloadProjectArchive(&rpc.DownloadProjectArchiveRequest{Name: "projects/my-project"}, download); err != nil {
+		t.Fatalf("DownloadProjectArchive() returned error: %s", err)
+	}
+	if len(download.chunks) < 2 {
+		t.Fatalf("DownloadProjectArchive() sent %d chunks, want at least 2", len(download.chunks))
+	}
+
+	var archive bytes.Buffer
+	for i, chunk := range download.chunks {
+		if len(chunk.GetData()) > contentsChunkSize {
+			t.Errorf("DownloadProjectArchive() sent chunk %d with %d bytes, want at most %d", i, len(chunk.GetData()), contentsChunkSize)
+		}
+		if last := i == len(download.chunks)-1; last != (chunk.GetRecordCounts() != nil) {
+			t.Errorf("DownloadProjectArchive() sent chunk %d with record counts %v, want counts only in the last chunk", i, chunk.GetRecordCounts())
+		}
+		archive.Write(chunk.GetData())
+	}
+	counts := download.chunks[len(download.chunks)-1].GetRecordCounts()
+	if diff := cmp.Diff(exportProject(ctx, t, server, "projects/my-project").GetRecordCounts(), counts); diff != "" {
+		t.Errorf("DownloadProjectArchive() returned unexpected record counts (-exported +downloaded):\n%s", diff)
+	}
+
+	upload := &archiveUploadStream{ctx: ctx, reqs: archiveUploadRequests("my-copy", archive.Bytes(), 1000)}
+	if err := server.UploadProjectArchive(upload); err != nil {
+		t.Fatalf("UploadProjectArchive() returned error: %s", err)
+	}
+	if got := upload.resp.GetProject().GetName(); got != "projects/my-copy" {
+		t.Errorf("UploadProjectArchive() imported project %q, want %q", got, "projects/my-copy")
+	}
+	if diff := cmp.Diff(counts, upload.resp.GetRecordCounts()); diff != "" {
+		t.Errorf("UploadProjectArchive() returned unexpected record counts (-downloaded +uploaded):\n%s", diff)
+	}
+
+	got, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{
+		Name: "projects/my-copy/locations/global/apis/a/versions/v1/specs/s",
+	})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents() returned error: %s", err)
+	}
+	if !bytes.Equal(got.GetData(), contents) {
+		t.Errorf("GetApiSpecContents() returned %d bytes that differ from the uploaded %d bytes", len(got.GetData()), len(contents))
+	}
+}
+
+func TestUploadProjectArchiveErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	archive := exportProject(ctx, t, server, "projects/my-project").GetArchive()
+
+	tests := []struct {
+		desc string
+		reqs []*rpc.UploadProjectArchiveRequest
+		want codes.Code
+	}{
+		{
+			desc: "no requests",
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "existing project",
+			reqs: archiveUploadRequests("", archive, 100),
+			want: codes.AlreadyExists,
+		},
+		{
+			desc: "project id after first request",
+			reqs: append(archiveUploadRequests("my-copy", archive[:10], 100), &rpc.UploadProjectArchiveRequest{ProjectId: "other"}),
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "truncated archive",
+			reqs: archiveUploadRequests("my-copy", archive[:len(archive)/2], 100),
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			upload := &archiveUploadStream{ctx: ctx, reqs: test.reqs}
+			if err := server.UploadProjectArchive(upload); status.Code(err) != test.want {
+				t.Errorf("UploadProjectArchive() returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+			if _, err := server.GetProject(ctx, &rpc.GetProjectRequest{Name: "projects/my-copy"}); status.Code(err) != codes.NotFound {
+				t.Errorf("GetProject(%q) returned status code %q after failed upload, want %q", "projects/my-copy", status.Code(err), codes.NotFound)
+			}
+		})
+	}
+}
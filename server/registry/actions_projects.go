@@ -53,13 +53,29 @@ func (s *RegistryServer) createProject(ctx context.Context, name names.Project,
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	project := models.NewProject(name, body)
+	if err := validateRetentionPolicy(body.GetRevisionRetentionPolicy()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateLifecycles(body); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	project, err := models.NewProject(name, body)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err := db.SaveProject(ctx, project); err != nil {
 		return nil, err
 	}
 
+	message, err := project.Message()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	s.notify(ctx, rpc.Notification_CREATED, name.String())
-	return project.Message(), nil
+	return message, nil
 }
 
 // DeleteProject handles the corresponding API request.
@@ -101,12 +117,21 @@ func (s *RegistryServer) GetProject(ctx context.Context, req *rpc.GetProjectRequ
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := validateReadMask(&rpc.Project{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	project, err := db.GetProject(ctx, name)
 	if err != nil {
 		return nil, err
 	}
 
-	return project.Message(), nil
+	message, err := project.Message()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	applyReadMask(message, req.GetReadMask())
+	return message, nil
 }
 
 // ListProjects handles the corresponding API request.
@@ -125,10 +150,15 @@ func (s *RegistryServer) ListProjects(ctx context.Context, req *rpc.ListProjects
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.Project{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	listing, err := db.ListProjects(ctx, storage.PageOptions{
 		Size:   req.GetPageSize(),
 		Filter: req.GetFilter(),
 		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -140,7 +170,11 @@ func (s *RegistryServer) ListProjects(ctx context.Context, req *rpc.ListProjects
 	}
 
 	for i, project := range listing.Projects {
-		response.Projects[i] = project.Message()
+		response.Projects[i], err = project.Message()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		applyReadMask(response.Projects[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -162,6 +196,14 @@ func (s *RegistryServer) UpdateProject(ctx context.Context, req *rpc.UpdateProje
 		return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask %v: %s", req.GetUpdateMask(), err)
 	}
 
+	if err := validateRetentionPolicy(req.GetProject().GetRevisionRetentionPolicy()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateLifecycles(req.GetProject()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	name, err := names.ParseProject(req.GetProject().GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -185,11 +227,18 @@ func (s *RegistryServer) UpdateProject(ctx context.Context, req *rpc.UpdateProje
 		return nil, err
 	}
 
-	project.Update(req.GetProject(), models.ExpandMask(req.GetProject(), req.GetUpdateMask()))
+	if err := project.Update(req.GetProject(), models.ExpandMask(req.GetProject(), req.GetUpdateMask())); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err := db.SaveProject(ctx, project); err != nil {
 		return nil, err
 	}
 
+	message, err := project.Message()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	s.notify(ctx, rpc.Notification_UPDATED, name.String())
-	return project.Message(), nil
+	return message, nil
 }
@@ -0,0 +1,151 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// revisionTagMutex serializes changes to revision tags so that checking
+// whether a tag is protected and moving or deleting it can't interleave.
+var revisionTagMutex sync.Mutex
+
+// ListRevisionTags handles the corresponding API request.
+func (s *RegistryServer) ListRevisionTags(ctx context.Context, req *rpc.ListRevisionTagsRequest) (*rpc.ListRevisionTagsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	response := &rpc.ListRevisionTagsResponse{
+		Tags: make([]*rpc.RevisionTag, 0),
+	}
+	if name, nameErr := names.ParseSpec(req.GetName()); nameErr == nil {
+		if _, err := db.GetSpec(ctx, name); err != nil {
+			return nil, err
+		}
+		tags, err := db.GetSpecTags(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			response.Tags = append(response.Tags, tag.Message())
+		}
+	} else if name, nameErr := names.ParseDeployment(req.GetName()); nameErr == nil {
+		if _, err := db.GetDeployment(ctx, name); err != nil {
+			return nil, err
+		}
+		tags, err := db.GetDeploymentTags(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			response.Tags = append(response.Tags, tag.Message())
+		}
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be a spec or deployment", req.GetName())
+	}
+
+	sort.Slice(response.Tags, func(i, j int) bool {
+		return response.Tags[i].GetTag() < response.Tags[j].GetTag()
+	})
+	return response, nil
+}
+
+// DeleteRevisionTag handles the corresponding API request.
+func (s *RegistryServer) DeleteRevisionTag(ctx context.Context, req *rpc.DeleteRevisionTagRequest) (*emptypb.Empty, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	if name, nameErr := names.ParseSpecRevision(req.GetName()); nameErr == nil {
+		tag, err := db.GetSpecRevisionTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if tag.Protected && !req.GetForce() {
+			return nil, protectedTagError(tag.String())
+		}
+		if err := db.DeleteSpecRevisionTag(ctx, name); err != nil {
+			return nil, err
+		}
+		s.notify(ctx, rpc.Notification_UPDATED, name.Spec().Revision(tag.RevisionID).String())
+	} else if name, nameErr := names.ParseDeploymentRevision(req.GetName()); nameErr == nil {
+		tag, err := db.GetDeploymentRevisionTag(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if tag.Protected && !req.GetForce() {
+			return nil, protectedTagError(tag.String())
+		}
+		if err := db.DeleteDeploymentRevisionTag(ctx, name); err != nil {
+			return nil, err
+		}
+		s.notify(ctx, rpc.Notification_UPDATED, name.Deployment().Revision(tag.RevisionID).String())
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tag name %q, must be a spec or deployment followed by @ and the tag", req.GetName())
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func protectedTagError(name string) error {
+	return status.Errorf(codes.FailedPrecondition, "tag %q is protected, set force to move, unprotect or delete it", name)
+}
+
+// checkProtectedSpecTags returns an error if deleting a spec revision would delete a protected tag.
+// If revisionID is empty, the tags of every revision of the spec are checked.
+func checkProtectedSpecTags(ctx context.Context, db *storage.Client, name names.Spec, revisionID string) error {
+	tags, err := db.GetSpecTags(ctx, name)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if tag.Protected && (revisionID == "" || tag.RevisionID == revisionID) {
+			return protectedTagError(tag.String())
+		}
+	}
+	return nil
+}
+
+// checkProtectedDeploymentTags returns an error if deleting a deployment revision would delete a protected tag.
+// If revisionID is empty, the tags of every revision of the deployment are checked.
+func checkProtectedDeploymentTags(ctx context.Context, db *storage.Client, name names.Deployment, revisionID string) error {
+	tags, err := db.GetDeploymentTags(ctx, name)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if tag.Protected && (revisionID == "" || tag.RevisionID == revisionID) {
+			return protectedTagError(tag.String())
+		}
+	}
+	return nil
+}
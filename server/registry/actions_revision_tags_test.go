@@ -0,0 +1,258 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestSpecRevisionTags(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const spec = "projects/my-project/locations/global/apis/a/versions/v1/specs/s"
+	if err := seeder.SeedSpecs(ctx, server,
+		&rpc.ApiSpec{Name: spec, Contents: []byte("first")},
+		&rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	revisions, err := server.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: spec})
+	if err != nil {
+		t.Fatalf("Setup: ListApiSpecRevisions(%q) returned error: %s", spec, err)
+	}
+	second, first := revisions.GetApiSpecs()[0].GetRevisionId(), revisions.GetApiSpecs()[1].GetRevisionId()
+
+	for _, req := range []*rpc.TagApiSpecRevisionRequest{
+		{Name: spec + "@" + first, Tag: "stable", Protected: true},
+		{Name: spec + "@" + second, Tag: "latest"},
+		{Name: spec + "@" + first, Tag: "latest"},
+	} {
+		if _, err := server.TagApiSpecRevision(ctx, req); err != nil {
+			t.Fatalf("TagApiSpecRevision(%+v) returned error: %s", req, err)
+		}
+	}
+
+	t.Run("list", func(t *testing.T) {
+		got, err := server.ListRevisionTags(ctx, &rpc.ListRevisionTagsRequest{Name: spec})
+		if err != nil {
+			t.Fatalf("ListRevisionTags(%q) returned error: %s", spec, err)
+		}
+		want := []*rpc.RevisionTag{
+			{Name: spec + "@latest", Tag: "latest", RevisionId: first},
+			{Name: spec + "@stable", Tag: "stable", RevisionId: first, Protected: true},
+		}
+		opts := cmp.Options{protocmp.Transform(), protocmp.IgnoreFields(&rpc.RevisionTag{}, "create_time", "update_time")}
+		if !cmp.Equal(want, got.GetTags(), opts) {
+			t.Errorf("ListRevisionTags(%q) returned unexpected diff (-want +got):\n%s", spec, cmp.Diff(want, got.GetTags(), opts))
+		}
+	})
+
+	t.Run("protected", func(t *testing.T) {
+		for _, req := range []*rpc.TagApiSpecRevisionRequest{
+			{Name: spec + "@" + second, Tag: "stable", Protected: true},
+			{Name: spec + "@" + first, Tag: "stable"},
+		} {
+			if _, err := server.TagApiSpecRevision(ctx, req); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("TagApiSpecRevision(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+			}
+		}
+
+		req := &rpc.DeleteRevisionTagRequest{Name: spec + "@stable"}
+		if _, err := server.DeleteRevisionTag(ctx, req); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("DeleteRevisionTag(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+		}
+
+		move := &rpc.TagApiSpecRevisionRequest{Name: spec + "@" + second, Tag: "stable", Protected: true, Force: true}
+		if _, err := server.TagApiSpecRevision(ctx, move); err != nil {
+			t.Fatalf("TagApiSpecRevision(%+v) returned error: %s", move, err)
+		}
+		got, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec + "@stable"})
+		if err != nil {
+			t.Fatalf("GetApiSpec(%q) returned error: %s", spec+"@stable", err)
+		}
+		if got.GetRevisionId() != second {
+			t.Errorf("GetApiSpec(%q) returned revision %q, want moved tag on %q", spec+"@stable", got.GetRevisionId(), second)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, req := range []*rpc.DeleteRevisionTagRequest{
+			{Name: spec + "@latest"},
+			{Name: spec + "@stable", Force: true},
+		} {
+			if _, err := server.DeleteRevisionTag(ctx, req); err != nil {
+				t.Fatalf("DeleteRevisionTag(%+v) returned error: %s", req, err)
+			}
+			if _, err := server.DeleteRevisionTag(ctx, req); status.Code(err) != codes.NotFound {
+				t.Errorf("DeleteRevisionTag(%+v) of a deleted tag returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+			}
+		}
+
+		got, err := server.ListRevisionTags(ctx, &rpc.ListRevisionTagsRequest{Name: spec})
+		if err != nil {
+			t.Fatalf("ListRevisionTags(%q) returned error: %s", spec, err)
+		}
+		if len(got.GetTags()) != 0 {
+			t.Errorf("ListRevisionTags(%q) returned %d tags, want none", spec, len(got.GetTags()))
+		}
+
+		// Deleting tags doesn't delete the revisions that they were on.
+		if _, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec + "@" + first}); err != nil {
+			t.Errorf("GetApiSpec(%q) returned error: %s", spec+"@"+first, err)
+		}
+	})
+}
+
+func TestDeploymentRevisionTags(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const deployment = "projects/my-project/locations/global/apis/a/deployments/d"
+	if err := seeder.SeedDeployments(ctx, server, &rpc.ApiDeployment{Name: deployment}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	revision, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deployment})
+	if err != nil {
+		t.Fatalf("Setup: GetApiDeployment(%q) returned error: %s", deployment, err)
+	}
+	name := deployment + "@" + revision.GetRevisionId()
+
+	tag := &rpc.TagApiDeploymentRevisionRequest{Name: name, Tag: "prod", Protected: true}
+	if _, err := server.TagApiDeploymentRevision(ctx, tag); err != nil {
+		t.Fatalf("TagApiDeploymentRevision(%+v) returned error: %s", tag, err)
+	}
+
+	got, err := server.ListRevisionTags(ctx, &rpc.ListRevisionTagsRequest{Name: deployment})
+	if err != nil {
+		t.Fatalf("ListRevisionTags(%q) returned error: %s", deployment, err)
+	}
+	if len(got.GetTags()) != 1 || got.GetTags()[0].GetName() != deployment+"@prod" || !got.GetTags()[0].GetProtected() {
+		t.Errorf("ListRevisionTags(%q) returned %v, want protected tag %q", deployment, got.GetTags(), deployment+"@prod")
+	}
+
+	unprotect := &rpc.TagApiDeploymentRevisionRequest{Name: name, Tag: "prod"}
+	if _, err := server.TagApiDeploymentRevision(ctx, unprotect); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("TagApiDeploymentRevision(%+v) returned status code %q, want %q: %v", unprotect, status.Code(err), codes.FailedPrecondition, err)
+	}
+
+	del := &rpc.DeleteRevisionTagRequest{Name: deployment + "@prod", Force: true}
+	if _, err := server.DeleteRevisionTag(ctx, del); err != nil {
+		t.Errorf("DeleteRevisionTag(%+v) returned error: %s", del, err)
+	}
+
+	for _, name := range []string{deployment + "/missing", "projects/my-project/locations/global/apis/a"} {
+		req := &rpc.ListRevisionTagsRequest{Name: name}
+		if _, err := server.ListRevisionTags(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListRevisionTags(%q) returned status code %q, want %q: %v", name, status.Code(err), codes.InvalidArgument, err)
+		}
+	}
+	missing := &rpc.ListRevisionTagsRequest{Name: "projects/my-project/locations/global/apis/a/deployments/missing"}
+	if _, err := server.ListRevisionTags(ctx, missing); status.Code(err) != codes.NotFound {
+		t.Errorf("ListRevisionTags(%q) returned status code %q, want %q: %v", missing.GetName(), status.Code(err), codes.NotFound, err)
+	}
+}
+
+func TestDeleteProtectedRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const (
+		spec       = "projects/my-project/locations/global/apis/a/versions/v1/specs/s"
+		deployment = "projects/my-project/locations/global/apis/a/deployments/d"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: spec, Contents: []byte("first"), RevisionTags: []string{"stable"}},
+		&rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+		&rpc.ApiDeployment{Name: deployment, EndpointUri: "https://first.example.com", RevisionTags: []string{"prod"}},
+		&rpc.ApiDeployment{Name: deployment, EndpointUri: "https://second.example.com"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	protectSpec := &rpc.TagApiSpecRevisionRequest{Name: spec + "@stable", Tag: "stable", Protected: true}
+	stable, err := server.TagApiSpecRevision(ctx, protectSpec)
+	if err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision(%+v) returned error: %s", protectSpec, err)
+	}
+	protectDeployment := &rpc.TagApiDeploymentRevisionRequest{Name: deployment + "@prod", Tag: "prod", Protected: true}
+	prod, err := server.TagApiDeploymentRevision(ctx, protectDeployment)
+	if err != nil {
+		t.Fatalf("Setup: TagApiDeploymentRevision(%+v) returned error: %s", protectDeployment, err)
+	}
+
+	t.Run("without force", func(t *testing.T) {
+		for _, name := range []string{spec + "@stable", spec + "@" + stable.GetRevisionId()} {
+			req := &rpc.DeleteApiSpecRevisionRequest{Name: name}
+			if _, err := server.DeleteApiSpecRevision(ctx, req); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("DeleteApiSpecRevision(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+			}
+		}
+		if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("DeleteApiSpec(%q) returned status code %q, want %q: %v", spec, status.Code(err), codes.FailedPrecondition, err)
+		}
+		for _, name := range []string{deployment + "@prod", deployment + "@" + prod.GetRevisionId()} {
+			req := &rpc.DeleteApiDeploymentRevisionRequest{Name: name}
+			if _, err := server.DeleteApiDeploymentRevision(ctx, req); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("DeleteApiDeploymentRevision(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+			}
+		}
+		if _, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: deployment}); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("DeleteApiDeployment(%q) returned status code %q, want %q: %v", deployment, status.Code(err), codes.FailedPrecondition, err)
+		}
+
+		got, err := server.ListRevisionTags(ctx, &rpc.ListRevisionTagsRequest{Name: spec})
+		if err != nil {
+			t.Fatalf("ListRevisionTags(%q) returned error: %s", spec, err)
+		}
+		if len(got.GetTags()) != 1 {
+			t.Errorf("ListRevisionTags(%q) returned %d tags after rejected deletes, want 1", spec, len(got.GetTags()))
+		}
+	})
+
+	t.Run("unprotected revisions", func(t *testing.T) {
+		latest, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec})
+		if err != nil {
+			t.Fatalf("GetApiSpec(%q) returned error: %s", spec, err)
+		}
+		req := &rpc.DeleteApiSpecRevisionRequest{Name: spec + "@" + latest.GetRevisionId()}
+		if _, err := server.DeleteApiSpecRevision(ctx, req); err != nil {
+			t.Errorf("DeleteApiSpecRevision(%+v) returned error: %s", req, err)
+		}
+	})
+
+	t.Run("with force", func(t *testing.T) {
+		req := &rpc.DeleteApiDeploymentRevisionRequest{Name: deployment + "@prod", Force: true}
+		if _, err := server.DeleteApiDeploymentRevision(ctx, req); err != nil {
+			t.Errorf("DeleteApiDeploymentRevision(%+v) returned error: %s", req, err)
+		}
+		if _, err := server.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deployment + "@prod"}); status.Code(err) != codes.NotFound {
+			t.Errorf("GetApiDeployment(%q) returned status code %q after forced delete, want %q: %v", deployment+"@prod", status.Code(err), codes.NotFound, err)
+		}
+
+		if _, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec, Force: true}); err != nil {
+			t.Errorf("DeleteApiSpec(%q) returned error: %s", spec, err)
+		}
+		if _, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: deployment, Force: true}); err != nil {
+			t.Errorf("DeleteApiDeployment(%q) returned error: %s", deployment, err)
+		}
+	})
+}
@@ -41,14 +41,19 @@ func (s *RegistryServer) ListApiSpecRevisions(ctx context.Context, req *rpc.List
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.ApiSpec{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseSpec(req.GetName())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	listing, err := db.ListSpecRevisions(ctx, parent, storage.PageOptions{
-		Size:  req.GetPageSize(),
-		Token: req.GetPageToken(),
+		Size:   req.GetPageSize(),
+		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -70,6 +75,7 @@ func (s *RegistryServer) ListApiSpecRevisions(ctx context.Context, req *rpc.List
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.ApiSpecs[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -100,6 +106,19 @@ func (s *RegistryServer) DeleteApiSpecRevision(ctx context.Context, req *rpc.Del
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := checkReferences(ctx, db, name.Spec().Project(), name.String()); err != nil {
+		return nil, err
+	}
+
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	if !req.GetForce() {
+		if err := checkProtectedSpecTags(ctx, db, name.Spec(), name.RevisionID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := db.DeleteSpecRevision(ctx, name); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -148,7 +167,22 @@ func (s *RegistryServer) TagApiSpecRevision(ctx context.Context, req *rpc.TagApi
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	// An existing tag is moved to the revision, unless it is protected.
 	tag := models.NewSpecRevisionTag(name, req.GetTag())
+	tag.Protected = req.GetProtected()
+	if existing, err := db.GetSpecRevisionTag(ctx, name.Spec().Revision(req.GetTag())); err == nil {
+		changed := existing.RevisionID != tag.RevisionID || !tag.Protected
+		if existing.Protected && changed && !req.GetForce() {
+			return nil, protectedTagError(existing.String())
+		}
+		tag.CreateTime = existing.CreateTime
+	} else if !isNotFound(err) {
+		return nil, err
+	}
+
 	if err := db.SaveSpecRevisionTag(ctx, tag); err != nil {
 		return nil, err
 	}
@@ -191,14 +225,21 @@ func (s *RegistryServer) RollbackApiSpec(ctx context.Context, req *rpc.RollbackA
 		return nil, err
 	}
 
-	// Save a new rollback revision based on the target revision.
-	rollback := target.NewRevision()
-	if err := db.SaveSpecRevision(ctx, rollback); err != nil {
+	blob, err := db.GetSpecRevisionContents(ctx, name)
+	if err != nil {
 		return nil, err
 	}
 
-	blob, err := db.GetSpecRevisionContents(ctx, name)
-	if err != nil {
+	if err := s.checkQuota(ctx, db, parent.Project(), quotaRequest{
+		specRevisions: 1,
+		blobBytes:     int64(target.SizeInBytes),
+	}); err != nil {
+		return nil, err
+	}
+
+	// Save a new rollback revision based on the target revision.
+	rollback := target.NewRevision()
+	if err := db.SaveSpecRevision(ctx, rollback); err != nil {
 		return nil, err
 	}
 
@@ -208,6 +249,10 @@ func (s *RegistryServer) RollbackApiSpec(ctx context.Context, req *rpc.RollbackA
 		return nil, err
 	}
 
+	if err := s.enforceSpecRetention(ctx, db, parent); err != nil {
+		return nil, err
+	}
+
 	message, err := rollback.BasicMessage(rollback.RevisionName(), []string{})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
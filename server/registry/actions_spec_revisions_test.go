@@ -322,6 +322,7 @@ func TestListApiSpecRevisions(t *testing.T) {
 
 	secondWant := &rpc.ApiSpec{
 		Name:               fmt.Sprintf("%s@%s", secondRevision.GetName(), secondRevision.GetRevisionId()),
+		MimeType:           secondRevision.GetMimeType(),
 		Hash:               secondRevision.GetHash(),
 		SizeBytes:          secondRevision.GetSizeBytes(),
 		CreateTime:         secondRevision.GetCreateTime(),
@@ -448,6 +449,7 @@ func TestUpdateApiSpecRevisions(t *testing.T) {
 			},
 		}
 		want := proto.Clone(created).(*rpc.ApiSpec)
+		want.MimeType = "application/x.openapi;version=3.0.0"
 		want.SizeBytes = int32(len(req.ApiSpec.GetContents()))
 		want.Hash = sha256hash(req.ApiSpec.GetContents())
 
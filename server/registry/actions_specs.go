@@ -66,11 +66,28 @@ func (s *RegistryServer) createSpec(ctx context.Context, name names.Spec, body *
 		return nil, err
 	}
 
+	// Specs uploaded without a MIME type are given one based on their contents.
+	if body.GetMimeType() == "" {
+		body.MimeType = detectSpecMimeType(body.GetContents())
+	}
+
+	if err := s.checkSpecContents(ctx, db, name, body.GetMimeType(), body.GetContents()); err != nil {
+		return nil, err
+	}
+
 	spec, err := models.NewSpec(name, body)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Stored sizes are of uncompressed contents, so they're also charged for gzipped specs.
+	if err := s.checkQuota(ctx, db, name.Project(), quotaRequest{
+		specRevisions: 1,
+		blobBytes:     int64(spec.SizeInBytes),
+	}); err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveSpecRevision(ctx, spec); err != nil {
 		return nil, err
 	}
@@ -106,6 +123,19 @@ func (s *RegistryServer) DeleteApiSpec(ctx context.Context, req *rpc.DeleteApiSp
 		return nil, err
 	}
 
+	if err := checkReferences(ctx, db, name.Project(), name.String()); err != nil {
+		return nil, err
+	}
+
+	revisionTagMutex.Lock()
+	defer revisionTagMutex.Unlock()
+
+	if !req.GetForce() {
+		if err := checkProtectedSpecTags(ctx, db, name, ""); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := db.DeleteSpec(ctx, name); err != nil {
 		return nil, err
 	}
@@ -116,13 +146,27 @@ func (s *RegistryServer) DeleteApiSpec(ctx context.Context, req *rpc.DeleteApiSp
 
 // GetApiSpec handles the corresponding API request.
 func (s *RegistryServer) GetApiSpec(ctx context.Context, req *rpc.GetApiSpecRequest) (*rpc.ApiSpec, error) {
-	if name, err := names.ParseSpec(req.GetName()); err == nil {
-		return s.getApiSpec(ctx, name)
-	} else if name, err := names.ParseSpecRevision(req.GetName()); err == nil {
-		return s.getApiSpecRevision(ctx, name)
+	if err := validateReadMask(&rpc.ApiSpec{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
+	var (
+		spec *rpc.ApiSpec
+		err  error
+	)
+	if name, nameErr := names.ParseSpec(req.GetName()); nameErr == nil {
+		spec, err = s.getApiSpec(ctx, name)
+	} else if name, nameErr := names.ParseSpecRevision(req.GetName()); nameErr == nil {
+		spec, err = s.getApiSpecRevision(ctx, name)
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API spec or revision", req.GetName())
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API spec or revision", req.GetName())
+	applyReadMask(spec, req.GetReadMask())
+	return spec, nil
 }
 
 func (s *RegistryServer) getApiSpec(ctx context.Context, name names.Spec) (*rpc.ApiSpec, error) {
@@ -203,20 +247,15 @@ func (s *RegistryServer) GetApiSpecContents(ctx context.Context, req *rpc.GetApi
 	if err != nil {
 		return nil, err
 	}
-	if strings.Contains(spec.MimeType, "+gzip") {
-		contents, err := models.GUnzippedBytes(blob.Contents)
+	contents, mimeType := blob.Contents, spec.MimeType
+	if strings.Contains(mimeType, "+gzip") {
+		contents, err = models.GUnzippedBytes(contents)
 		if err != nil {
 			return nil, status.Errorf(codes.FailedPrecondition, "failed to unzip contents with gzip MIME type: %s", err)
 		}
-		return &httpbody.HttpBody{
-			ContentType: strings.Replace(spec.MimeType, "+gzip", "", 1),
-			Data:        contents,
-		}, nil
-	}
-	return &httpbody.HttpBody{
-		ContentType: spec.MimeType,
-		Data:        blob.Contents,
-	}, nil
+		mimeType = strings.Replace(mimeType, "+gzip", "", 1)
+	}
+	return formatSpecContents(contents, mimeType, req.GetFormat(), req.GetPath())
 }
 
 // ListApiSpecs handles the corresponding API request.
@@ -235,6 +274,10 @@ func (s *RegistryServer) ListApiSpecs(ctx context.Context, req *rpc.ListApiSpecs
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.ApiSpec{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseVersion(req.GetParent())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -244,6 +287,7 @@ func (s *RegistryServer) ListApiSpecs(ctx context.Context, req *rpc.ListApiSpecs
 		Size:   req.GetPageSize(),
 		Filter: req.GetFilter(),
 		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -265,6 +309,7 @@ func (s *RegistryServer) ListApiSpecs(ctx context.Context, req *rpc.ListApiSpecs
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.ApiSpecs[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -310,23 +355,55 @@ func (s *RegistryServer) UpdateApiSpec(ctx context.Context, req *rpc.UpdateApiSp
 	}
 
 	// Apply the update to the spec - possibly changing the revision ID.
+	previousRevisionID := spec.RevisionID
 	maskExpansion := models.ExpandMask(req.GetApiSpec(), req.GetUpdateMask())
 	if err := spec.Update(req.GetApiSpec(), maskExpansion); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	contentsUpdated := len(fieldmaskpb.Intersect(maskExpansion, &fieldmaskpb.FieldMask{Paths: []string{"contents"}}).GetPaths()) > 0
+	if contentsUpdated {
+		// Specs updated without a MIME type are given one based on their contents.
+		if spec.MimeType == "" {
+			spec.MimeType = detectSpecMimeType(req.ApiSpec.GetContents())
+		}
+
+		if err := s.checkSpecContents(ctx, db, name, spec.MimeType, req.ApiSpec.GetContents()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unchanged contents don't create a revision or store another blob.
+	var quota quotaRequest
+	if spec.RevisionID != previousRevisionID {
+		quota.specRevisions = 1
+		if contentsUpdated {
+			quota.blobBytes = int64(spec.SizeInBytes)
+		}
+	}
+	if err := s.checkQuota(ctx, db, name.Project(), quota); err != nil {
+		return nil, err
+	}
+
 	// Save the updated/current spec. This creates a new revision or updates the previous one.
 	if err := db.SaveSpecRevision(ctx, spec); err != nil {
 		return nil, err
 	}
 
 	// If the spec contents were updated, save a new blob.
-	if len(fieldmaskpb.Intersect(maskExpansion, &fieldmaskpb.FieldMask{Paths: []string{"contents"}}).GetPaths()) > 0 {
+	if contentsUpdated {
 		if err := db.SaveSpecRevisionContents(ctx, spec, req.ApiSpec.GetContents()); err != nil {
 			return nil, err
 		}
 	}
 
+	// New revisions may push older ones outside the project's retention policy.
+	if spec.RevisionID != previousRevisionID {
+		if err := s.enforceSpecRetention(ctx, db, name); err != nil {
+			return nil, err
+		}
+	}
+
 	tags, err := revisionTags(ctx, db, name.Revision(spec.RevisionID))
 	if err != nil {
 		return nil, err
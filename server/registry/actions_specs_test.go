@@ -528,6 +528,7 @@ func TestListApiSpecs(t *testing.T) {
 				ApiSpecs: []*rpc.ApiSpec{
 					{
 						Name:      "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec1",
+						MimeType:  "application/x.openapi;version=3.0.0",
 						Hash:      sha256hash(specContents),
 						SizeBytes: int32(len(specContents)),
 					},
@@ -699,6 +700,53 @@ func TestListApiSpecs(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "annotation filtering",
+			seed: []*rpc.ApiSpec{
+				{
+					Name:        "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec1",
+					Annotations: map[string]string{"owner": "payments"},
+				},
+				{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec2"},
+			},
+			req: &rpc.ListApiSpecsRequest{
+				Parent: "projects/my-project/locations/global/apis/my-api/versions/v1",
+				Filter: "has(annotations.owner) && annotations.owner == 'payments'",
+			},
+			want: &rpc.ListApiSpecsResponse{
+				ApiSpecs: []*rpc.ApiSpec{
+					{
+						Name:        "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec1",
+						Annotations: map[string]string{"owner": "payments"},
+					},
+				},
+			},
+		},
+		{
+			desc: "revision tag filtering",
+			seed: []*rpc.ApiSpec{
+				{
+					Name:         "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec1",
+					RevisionTags: []string{"prod"},
+				},
+				{
+					Name:         "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec2",
+					RevisionTags: []string{"test"},
+				},
+			},
+			req: &rpc.ListApiSpecsRequest{
+				Parent: "projects/my-project/locations/global/apis/-/versions/-",
+				Filter: "'prod' in revision_tags",
+			},
+			want: &rpc.ListApiSpecsResponse{
+				ApiSpecs: []*rpc.ApiSpec{
+					{
+						Name:         "projects/my-project/locations/global/apis/my-api/versions/v1/specs/spec1",
+						RevisionTags: []string{"prod"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -1255,3 +1303,75 @@ func TestDeleteApiSpecResponseCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestListApiSpecsParentFilters(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.Api{
+			Name:   "projects/my-project/locations/global/apis/payments",
+			Labels: map[string]string{"team": "payments"},
+		},
+		&rpc.ApiVersion{
+			Name:  "projects/my-project/locations/global/apis/payments/versions/v1.2.0",
+			State: "production",
+		},
+		&rpc.ApiVersion{
+			Name:  "projects/my-project/locations/global/apis/payments/versions/v2.0.0-beta",
+			State: "staging",
+		},
+		&rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/payments/versions/v1.2.0/specs/openapi"},
+		&rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/payments/versions/v2.0.0-beta/specs/openapi"},
+		&rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/search/versions/v1.0.0/specs/openapi"},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{
+			filter: "has(api.labels.team) && api.labels.team == 'payments'",
+			want: []string{
+				"projects/my-project/locations/global/apis/payments/versions/v1.2.0/specs/openapi",
+				"projects/my-project/locations/global/apis/payments/versions/v2.0.0-beta/specs/openapi",
+			},
+		},
+		{
+			filter: "version.state == 'production'",
+			want: []string{
+				"projects/my-project/locations/global/apis/payments/versions/v1.2.0/specs/openapi",
+			},
+		},
+		{
+			filter: "semver_compare(version_id, 'v1.1') > 0 && project.project_id.matches('^my-')",
+			want: []string{
+				"projects/my-project/locations/global/apis/payments/versions/v1.2.0/specs/openapi",
+				"projects/my-project/locations/global/apis/payments/versions/v2.0.0-beta/specs/openapi",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			req := &rpc.ListApiSpecsRequest{
+				Parent: "projects/my-project/locations/global/apis/-/versions/-",
+				Filter: test.filter,
+			}
+			got, err := server.ListApiSpecs(ctx, req)
+			if err != nil {
+				t.Fatalf("ListApiSpecs(%+v) returned error: %s", req, err)
+			}
+
+			gotNames := make([]string, len(got.GetApiSpecs()))
+			for i, spec := range got.GetApiSpecs() {
+				gotNames[i] = spec.GetName()
+			}
+			if diff := cmp.Diff(test.want, gotNames, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("ListApiSpecs(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+			}
+		})
+	}
+}
@@ -16,15 +16,85 @@ package registry
 
 import (
 	"context"
+	"runtime"
+	"runtime/debug"
+	"time"
 
 	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // GetStatus handles the corresponding API request.
+// Database errors are reported in the returned status so that the rest of it is still available.
 func (s *RegistryServer) GetStatus(ctx context.Context, req *emptypb.Empty) (*rpc.Status, error) {
-	status := &rpc.Status{
-		Message: "running",
+	notifications := s.notifications.status()
+	notifications.Enabled = s.notifyEnabled
+
+	response := &rpc.Status{
+		Message:       "running",
+		Build:         buildStatus(),
+		Database:      &rpc.Status_Database{Driver: s.database},
+		StartTime:     timestamppb.New(s.startTime),
+		Uptime:        durationpb.New(time.Since(s.startTime).Round(time.Second)),
+		Notifications: notifications,
+		Maintenance:   s.maintenance.status(),
+	}
+	if err := s.getDatabaseStatus(ctx, response); err != nil {
+		response.Database.Error = err.Error()
+	}
+	return response, nil
+}
+
+// getDatabaseStatus adds the database status and project counts to a server status.
+func (s *RegistryServer) getDatabaseStatus(ctx context.Context, response *rpc.Status) error {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	database, err := db.GetDatabaseStatus(ctx)
+	if err != nil {
+		return err
+	}
+	response.Database.Version = database.Version
+	response.Database.SchemaVersion = database.SchemaVersion
+	response.Database.SchemaCurrent = database.SchemaCurrent
+	response.Database.Pool = &rpc.Status_Database_Pool{
+		MaxOpenConnections: int32(database.Pool.MaxOpenConnections),
+		OpenConnections:    int32(database.Pool.OpenConnections),
+		InUse:              int32(database.Pool.InUse),
+		Idle:               int32(database.Pool.Idle),
+		WaitCount:          database.Pool.WaitCount,
+		WaitDuration:       durationpb.New(database.Pool.WaitDuration),
+	}
+
+	counts, err := db.ListProjectCounts(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range counts {
+		response.Projects = append(response.Projects, &rpc.Status_Project{
+			Name:          c.Project.String(),
+			Apis:          c.Apis,
+			Specs:         c.Specs,
+			SpecRevisions: c.SpecRevisions,
+			BlobBytes:     c.BlobBytes,
+		})
+	}
+	return nil
+}
+
+// buildStatus returns the build information that is embedded in the server binary.
+func buildStatus() *rpc.Status_Build {
+	build := &rpc.Status_Build{GoVersion: runtime.Version()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		build.Version = info.Main.Version
+		build.Path = info.Path
 	}
-	return status, nil
+	return build
 }
@@ -19,26 +19,112 @@ import (
 	"testing"
 
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestGetStatus(t *testing.T) {
 	ctx := context.Background()
 	server := defaultTestServer(t)
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			Contents: []byte("first"),
+		},
+		&rpc.ApiSpec{
+			Name: "projects/my-project/locations/global/apis/b/versions/v1/specs/s",
+		},
+		&rpc.Artifact{
+			Name:     "projects/my-project/locations/global/artifacts/x",
+			Contents: []byte("artifact"),
+		},
+		&rpc.Project{
+			Name: "projects/empty-project",
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			Contents: []byte("second"),
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
 
 	req := &emptypb.Empty{}
 	want := &rpc.Status{
 		Message: "running",
+		Database: &rpc.Status_Database{
+			Driver:        "sqlite3",
+			SchemaCurrent: true,
+		},
+		Notifications: &rpc.Status_Notifications{},
+		Maintenance:   &rpc.MaintenanceMode{Message: defaultMaintenanceMessage},
+		Projects: []*rpc.Status_Project{
+			{
+				Name: "projects/empty-project",
+			},
+			{
+				Name:          "projects/my-project",
+				Apis:          2,
+				Specs:         2,
+				SpecRevisions: 3,
+				BlobBytes:     int64(len("first") + len("second") + len("artifact")),
+			},
+		},
+	}
+
+	got, err := server.GetStatus(ctx, req)
+	if err != nil {
+		t.Fatalf("GetStatus(%+v) returned error: %s", req, err)
+	}
+
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(new(rpc.Status), "build", "start_time", "uptime"),
+		protocmp.IgnoreFields(new(rpc.Status_Database), "version", "schema_version", "pool"),
+		protocmp.IgnoreFields(new(rpc.MaintenanceMode), "update_time"),
+	}
+	if !cmp.Equal(want, got, opts) {
+		t.Errorf("GetStatus(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, got, opts))
 	}
 
+	if got.GetBuild().GetGoVersion() == "" {
+		t.Errorf("GetStatus(%+v) returned no Go version", req)
+	}
+	if got.GetDatabase().GetVersion() == "" || got.GetDatabase().GetSchemaVersion() == "" {
+		t.Errorf("GetStatus(%+v) returned incomplete database status: %+v", req, got.GetDatabase())
+	}
+}
+
+func TestGetStatusDatabaseError(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.database = "unsupported"
+
+	req := &emptypb.Empty{}
 	got, err := server.GetStatus(ctx, req)
 	if err != nil {
 		t.Fatalf("GetStatus(%+v) returned error: %s", req, err)
 	}
 
-	if !cmp.Equal(want, got, protocmp.Transform()) {
-		t.Errorf("GetStatus(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, got, protocmp.Transform()))
+	if got.GetDatabase().GetError() == "" {
+		t.Errorf("GetStatus(%+v) returned no database error: %+v", req, got.GetDatabase())
+	}
+	if got.GetDatabase().GetDriver() != "unsupported" {
+		t.Errorf("GetStatus(%+v) returned database driver %q, want %q", req, got.GetDatabase().GetDriver(), "unsupported")
+	}
+	if got.GetBuild().GetGoVersion() == "" || got.GetUptime() == nil || got.GetNotifications() == nil || got.GetMaintenance() == nil {
+		t.Errorf("GetStatus(%+v) returned incomplete status: %+v", req, got)
+	}
+	if len(got.GetProjects()) > 0 {
+		t.Errorf("GetStatus(%+v) returned projects without a database: %+v", req, got.GetProjects())
 	}
 }
@@ -0,0 +1,132 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// contentsChunkSize is the maximum number of bytes sent in each chunk of a download.
+// It is well below the default gRPC message size limit of 4 MB.
+const contentsChunkSize = 1 << 20
+
+// DownloadApiSpecContents handles the corresponding API request.
+func (s *RegistryServer) DownloadApiSpecContents(req *rpc.DownloadApiSpecContentsRequest, stream rpc.Registry_DownloadApiSpecContentsServer) error {
+	body, err := s.GetApiSpecContents(stream.Context(), &rpc.GetApiSpecContentsRequest{Name: req.GetName()})
+	if err != nil {
+		return err
+	}
+
+	return sendContentsChunks(stream, body)
+}
+
+// DownloadArtifactContents handles the corresponding API request.
+func (s *RegistryServer) DownloadArtifactContents(req *rpc.DownloadArtifactContentsRequest, stream rpc.Registry_DownloadArtifactContentsServer) error {
+	body, err := s.GetArtifactContents(stream.Context(), &rpc.GetArtifactContentsRequest{Name: req.GetName()})
+	if err != nil {
+		return err
+	}
+
+	return sendContentsChunks(stream, body)
+}
+
+type contentsChunkSender interface {
+	Send(*rpc.ContentsChunk) error
+}
+
+// sendContentsChunks sends contents in order, with metadata for the complete contents in the first chunk.
+// The first chunk is sent even if the contents are empty.
+func sendContentsChunks(stream contentsChunkSender, body *httpbody.HttpBody) error {
+	data := body.GetData()
+	chunk := &rpc.ContentsChunk{
+		MimeType:  body.GetContentType(),
+		SizeBytes: int64(len(data)),
+		Hash:      fmt.Sprintf("%x", sha256.Sum256(data)),
+	}
+
+	for offset := 0; ; {
+		end := offset + contentsChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		chunk.Data = data[offset:end]
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+
+		if offset = end; offset >= len(data) {
+			return nil
+		}
+		chunk = &rpc.ContentsChunk{}
+	}
+}
+
+// UploadApiSpecContents handles the corresponding API request.
+func (s *RegistryServer) UploadApiSpecContents(stream rpc.Registry_UploadApiSpecContentsServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "invalid upload: at least one request must be sent")
+	} else if err != nil {
+		return err
+	}
+
+	if first.GetApiSpec() == nil {
+		return status.Errorf(codes.InvalidArgument, "invalid api_spec %+v: body must be provided in the first request", first.GetApiSpec())
+	} else if len(first.ApiSpec.GetContents()) > 0 {
+		return status.Error(codes.InvalidArgument, "invalid api_spec: contents must be sent in chunks")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(first.GetChunk())
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		buf.Write(req.GetChunk())
+	}
+
+	contents := buf.Bytes()
+	if want := first.GetHash(); want != "" {
+		if got := fmt.Sprintf("%x", sha256.Sum256(contents)); !strings.EqualFold(got, want) {
+			return status.Errorf(codes.InvalidArgument, "invalid hash %q: received contents have hash %q", want, got)
+		}
+	}
+
+	spec := proto.Clone(first.GetApiSpec()).(*rpc.ApiSpec)
+	spec.Contents = contents
+	updated, err := s.UpdateApiSpec(stream.Context(), &rpc.UpdateApiSpecRequest{
+		ApiSpec:      spec,
+		AllowMissing: first.GetAllowMissing(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(updated)
+}
@@ -0,0 +1,289 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uploadStream is a fake client stream that sends a fixed list of requests.
+type uploadStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*rpc.UploadApiSpecContentsRequest
+	resp *rpc.ApiSpec
+}
+
+func (s *uploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *uploadStream) Recv() (*rpc.UploadApiSpecContentsRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *rpc.ApiSpec) error {
+	s.resp = resp
+	return nil
+}
+
+// downloadStream is a fake server stream that records the chunks it is sent.
+type downloadStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks []*rpc.ContentsChunk
+}
+
+func (s *downloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *downloadStream) Send(chunk *rpc.ContentsChunk) error {
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+// largeContents returns contents that span several download chunks.
+func largeContents() []byte {
+	return bytes.Repeat([]byte("0123456789abcdef"), (2*contentsChunkSize+100)/16)
+}
+
+func uploadRequests(spec *rpc.ApiSpec, contents []byte, chunkSize int) []*rpc.UploadApiSpecContentsRequest {
+	reqs := []*rpc.UploadApiSpecContentsRequest{{
+		ApiSpec:      spec,
+		AllowMissing: true,
+		Hash:         fmt.Sprintf("%x", sha256.Sum256(contents)),
+	}}
+	for offset := 0; offset < len(contents); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(contents) {
+			end = len(contents)
+		}
+		reqs = append(reqs, &rpc.UploadApiSpecContentsRequest{Chunk: contents[offset:end]})
+	}
+	return reqs
+}
+
+func TestUploadApiSpecContents(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedVersions(ctx, server, &rpc.ApiVersion{
+		Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+	}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	contents := largeContents()
+	spec := &rpc.ApiSpec{
+		Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+		MimeType: "text/plain",
+	}
+	stream := &uploadStream{ctx: ctx, reqs: uploadRequests(spec, contents, 100000)}
+	if err := server.UploadApiSpecContents(stream); err != nil {
+		t.Fatalf("UploadApiSpecContents() returned error: %s", err)
+	}
+
+	if got, want := stream.resp.GetSizeBytes(), int32(len(contents)); got != want {
+		t.Errorf("UploadApiSpecContents() returned size_bytes %d, want %d", got, want)
+	}
+	if got, want := stream.resp.GetMimeType(), spec.GetMimeType(); got != want {
+		t.Errorf("UploadApiSpecContents() returned mime_type %q, want %q", got, want)
+	}
+
+	body, err := server.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{Name: spec.GetName()})
+	if err != nil {
+		t.Fatalf("GetApiSpecContents(%q) returned error: %s", spec.GetName(), err)
+	}
+	if !bytes.Equal(body.GetData(), contents) {
+		t.Errorf("GetApiSpecContents(%q) returned %d bytes that don't match the %d uploaded", spec.GetName(), len(body.GetData()), len(contents))
+	}
+}
+
+func TestUploadApiSpecContentsErrors(t *testing.T) {
+	spec := &rpc.ApiSpec{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"}
+	tests := []struct {
+		desc string
+		reqs []*rpc.UploadApiSpecContentsRequest
+		want codes.Code
+	}{
+		{
+			desc: "no requests",
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "missing spec",
+			reqs: []*rpc.UploadApiSpecContentsRequest{{Chunk: []byte("hello")}},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "contents in spec",
+			reqs: []*rpc.UploadApiSpecContentsRequest{{
+				ApiSpec: &rpc.ApiSpec{Name: spec.GetName(), Contents: []byte("hello")},
+			}},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "hash mismatch",
+			reqs: []*rpc.UploadApiSpecContentsRequest{
+				{ApiSpec: spec, AllowMissing: true, Hash: fmt.Sprintf("%x", sha256.Sum256([]byte("hello")))},
+				{Chunk: []byte("goodbye")},
+			},
+			want: codes.InvalidArgument,
+		},
+		{
+			desc: "spec not found",
+			reqs: []*rpc.UploadApiSpecContentsRequest{
+				{ApiSpec: spec},
+				{Chunk: []byte("hello")},
+			},
+			want: codes.NotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedVersions(ctx, server, &rpc.ApiVersion{
+				Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+			}); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			stream := &uploadStream{ctx: ctx, reqs: test.reqs}
+			if err := server.UploadApiSpecContents(stream); status.Code(err) != test.want {
+				t.Errorf("UploadApiSpecContents() returned status code %q, want %q: %v", status.Code(err), test.want, err)
+			}
+		})
+	}
+}
+
+func TestDownloadApiSpecContents(t *testing.T) {
+	tests := []struct {
+		desc       string
+		contents   []byte
+		wantChunks int
+	}{
+		{
+			desc:       "empty contents",
+			wantChunks: 1,
+		},
+		{
+			desc:       "small contents",
+			contents:   specContents,
+			wantChunks: 1,
+		},
+		{
+			desc:       "large contents",
+			contents:   largeContents(),
+			wantChunks: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			spec := &rpc.ApiSpec{
+				Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+				MimeType: "text/plain",
+				Contents: test.contents,
+			}
+			if err := seeder.SeedSpecs(ctx, server, spec); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			req := &rpc.DownloadApiSpecContentsRequest{Name: spec.GetName()}
+			stream := &downloadStream{ctx: ctx}
+			if err := server.DownloadApiSpecContents(req, stream); err != nil {
+				t.Fatalf("DownloadApiSpecContents(%+v) returned error: %s", req, err)
+			}
+
+			checkChunks(t, stream.chunks, test.wantChunks, spec.GetMimeType(), test.contents)
+		})
+	}
+}
+
+func TestDownloadApiSpecContentsErrors(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	req := &rpc.DownloadApiSpecContentsRequest{Name: "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"}
+	if err := server.DownloadApiSpecContents(req, &downloadStream{ctx: ctx}); status.Code(err) != codes.NotFound {
+		t.Errorf("DownloadApiSpecContents(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+	}
+}
+
+func TestDownloadArtifactContents(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	artifact := &rpc.Artifact{
+		Name:     "projects/my-project/locations/global/artifacts/my-artifact",
+		MimeType: "text/plain",
+		Contents: largeContents(),
+	}
+	if err := seeder.SeedArtifacts(ctx, server, artifact); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.DownloadArtifactContentsRequest{Name: artifact.GetName()}
+	stream := &downloadStream{ctx: ctx}
+	if err := server.DownloadArtifactContents(req, stream); err != nil {
+		t.Fatalf("DownloadArtifactContents(%+v) returned error: %s", req, err)
+	}
+
+	checkChunks(t, stream.chunks, 3, artifact.GetMimeType(), artifact.GetContents())
+}
+
+func checkChunks(t *testing.T, chunks []*rpc.ContentsChunk, wantChunks int, mimeType string, contents []byte) {
+	t.Helper()
+	if len(chunks) != wantChunks {
+		t.Fatalf("Download returned %d chunks, want %d", len(chunks), wantChunks)
+	}
+
+	first := chunks[0]
+	if first.GetMimeType() != mimeType {
+		t.Errorf("Download returned mime_type %q, want %q", first.GetMimeType(), mimeType)
+	}
+	if first.GetSizeBytes() != int64(len(contents)) {
+		t.Errorf("Download returned size_bytes %d, want %d", first.GetSizeBytes(), len(contents))
+	}
+	if want := fmt.Sprintf("%x", sha256.Sum256(contents)); first.GetHash() != want {
+		t.Errorf("Download returned hash %q, want %q", first.GetHash(), want)
+	}
+
+	var data []byte
+	for _, chunk := range chunks {
+		data = append(data, chunk.GetData()...)
+	}
+	if !bytes.Equal(data, contents) {
+		t.Errorf("Download returned %d bytes that don't match the %d expected", len(data), len(contents))
+	}
+}
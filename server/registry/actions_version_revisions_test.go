@@ -0,0 +1,99 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestListApiVersionRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedVersions(ctx, server, &rpc.ApiVersion{
+		Name:  "projects/my-project/locations/global/apis/my-api/versions/v1",
+		State: "Staging",
+	}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	for _, state := range []string{"Production", "Production"} {
+		req := &rpc.UpdateApiVersionRequest{
+			ApiVersion: &rpc.ApiVersion{
+				Name:  "projects/my-project/locations/global/apis/my-api/versions/v1",
+				State: state,
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"state"}},
+		}
+		if _, err := server.UpdateApiVersion(ctx, req); err != nil {
+			t.Fatalf("Setup: UpdateApiVersion(%+v) returned error: %s", req, err)
+		}
+	}
+
+	t.Run("all revisions", func(t *testing.T) {
+		req := &rpc.ListApiVersionRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+		}
+
+		got, err := server.ListApiVersionRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("ListApiVersionRevisions(%+v) returned error: %s", req, err)
+		}
+
+		// Unchanged metadata should not be recorded as a new revision.
+		if count := len(got.GetApiVersions()); count != 2 {
+			t.Fatalf("ListApiVersionRevisions(%+v) returned %d revisions, want 2", req, count)
+		}
+
+		// Check that the most recent revision is returned first.
+		for i, want := range []string{"Production", "Staging"} {
+			revision := got.GetApiVersions()[i]
+			if revision.GetState() != want {
+				t.Errorf("ListApiVersionRevisions(%+v) returned state %q at position %d, want %q", req, revision.GetState(), i, want)
+			}
+			if !strings.HasPrefix(revision.GetName(), "projects/my-project/locations/global/apis/my-api/versions/v1@") {
+				t.Errorf("ListApiVersionRevisions(%+v) returned unexpected revision name %q", req, revision.GetName())
+			}
+		}
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		req := &rpc.ListApiVersionRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/my-api/versions/doesnt-exist",
+		}
+
+		if _, err := server.ListApiVersionRevisions(ctx, req); status.Code(err) != codes.NotFound {
+			t.Errorf("ListApiVersionRevisions(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		req := &rpc.ListApiVersionRevisionsRequest{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1",
+			PageSize: -1,
+		}
+
+		if _, err := server.ListApiVersionRevisions(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListApiVersionRevisions(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+		}
+	})
+}
@@ -68,10 +68,23 @@ func (s *RegistryServer) createApiVersion(ctx context.Context, name names.Versio
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := checkVersionLifecycle(project, version, nil); err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveVersion(ctx, version); err != nil {
 		return nil, err
 	}
 
+	if err := db.SaveVersionRevision(ctx, models.NewVersionRevision(version)); err != nil {
+		return nil, err
+	}
+
 	message, err := version.Message()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -99,6 +112,10 @@ func (s *RegistryServer) DeleteApiVersion(ctx context.Context, req *rpc.DeleteAp
 		return nil, err
 	}
 
+	if err := checkReferences(ctx, db, name.Project(), name.String()); err != nil {
+		return nil, err
+	}
+
 	if err := db.DeleteVersion(ctx, name); err != nil {
 		return nil, err
 	}
@@ -120,6 +137,10 @@ func (s *RegistryServer) GetApiVersion(ctx context.Context, req *rpc.GetApiVersi
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := validateReadMask(&rpc.ApiVersion{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	version, err := db.GetVersion(ctx, name)
 	if err != nil {
 		return nil, err
@@ -130,6 +151,7 @@ func (s *RegistryServer) GetApiVersion(ctx context.Context, req *rpc.GetApiVersi
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	applyReadMask(message, req.GetReadMask())
 	return message, nil
 }
 
@@ -149,6 +171,10 @@ func (s *RegistryServer) ListApiVersions(ctx context.Context, req *rpc.ListApiVe
 		req.PageSize = 50
 	}
 
+	if err := validateReadMask(&rpc.ApiVersion{}, req.GetReadMask()); err != nil {
+		return nil, err
+	}
+
 	parent, err := names.ParseApi(req.GetParent())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -158,6 +184,7 @@ func (s *RegistryServer) ListApiVersions(ctx context.Context, req *rpc.ListApiVe
 		Size:   req.GetPageSize(),
 		Filter: req.GetFilter(),
 		Token:  req.GetPageToken(),
+		Fields: readMaskFields(req.GetReadMask()),
 	})
 	if err != nil {
 		return nil, err
@@ -173,6 +200,7 @@ func (s *RegistryServer) ListApiVersions(ctx context.Context, req *rpc.ListApiVe
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
+		applyReadMask(response.ApiVersions[i], req.GetReadMask())
 	}
 
 	return response, nil
@@ -217,19 +245,40 @@ func (s *RegistryServer) UpdateApiVersion(ctx context.Context, req *rpc.UpdateAp
 		return nil, err
 	}
 
+	previous := *version
 	if err := version.Update(req.GetApiVersion(), models.ExpandMask(req.GetApiVersion(), req.GetUpdateMask())); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return nil, err
+	}
+
+	transitioned, err := checkVersionLifecycle(project, version, &previous)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := db.SaveVersion(ctx, version); err != nil {
 		return nil, err
 	}
 
+	// Only record a revision when the metadata has actually changed.
+	if !version.MetadataEqual(&previous) {
+		if err := db.SaveVersionRevision(ctx, models.NewVersionRevision(version)); err != nil {
+			return nil, err
+		}
+	}
+
 	message, err := version.Message()
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	s.notify(ctx, rpc.Notification_UPDATED, name.String())
+	if transitioned {
+		s.notifyTransition(ctx, name.String(), previous.State, version.State)
+	}
 	return message, nil
 }
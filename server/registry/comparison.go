@@ -0,0 +1,199 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	breakingchangedetector "github.com/apigee/registry/cmd/registry/breaking-change-detector"
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/diff"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/ghodss/yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxCachedComparisons is the number of spec comparisons that are kept in memory.
+const maxCachedComparisons = 1000
+
+// comparison is the result of comparing two spec revisions.
+type comparison struct {
+	diff    *rpc.Diff
+	details *rpc.ChangeDetails
+}
+
+// comparisonCache holds the results of recent spec comparisons, keyed by the hashes of the compared contents.
+// Revisions are immutable, so cached results never need to be invalidated.
+type comparisonCache struct {
+	mutex   sync.Mutex
+	results map[string]comparison
+	keys    []string // In insertion order, for eviction of the oldest results.
+}
+
+func (c *comparisonCache) get(key string) (comparison, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	result, ok := c.results[key]
+	return result, ok
+}
+
+func (c *comparisonCache) put(key string, result comparison) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.results == nil {
+		c.results = make(map[string]comparison)
+	}
+	if _, ok := c.results[key]; ok {
+		return
+	}
+	if len(c.keys) >= maxCachedComparisons {
+		delete(c.results, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.keys = append(c.keys, key)
+	c.results[key] = result
+}
+
+// comparisonKey identifies the comparison of two spec revisions in the cache.
+// MIME types are included because they determine how the same contents are parsed.
+func comparisonKey(base, revision *models.Spec) string {
+	return fmt.Sprintf("%q:%s:%q:%s", base.MimeType, base.Hash, revision.MimeType, revision.Hash)
+}
+
+// CompareApiSpecRevisions handles the corresponding API request.
+func (s *RegistryServer) CompareApiSpecRevisions(ctx context.Context, req *rpc.CompareApiSpecRevisionsRequest) (*rpc.CompareApiSpecRevisionsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	revision, contents, err := specRevisionContents(ctx, db, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	base, baseContents, err := specRevisionContents(ctx, db, req.GetBase())
+	if err != nil {
+		return nil, err
+	}
+
+	key := comparisonKey(base, revision)
+	result, ok := s.comparisons.get(key)
+	if !ok {
+		d, err := compareSpecContents(base, baseContents, revision, contents)
+		if err != nil {
+			return nil, err
+		}
+		result = comparison{diff: d, details: breakingchangedetector.GetChangeDetails(d)}
+		if base.Hash != "" && revision.Hash != "" {
+			s.comparisons.put(key, result)
+		}
+	}
+
+	return &rpc.CompareApiSpecRevisionsResponse{
+		Name:          revision.RevisionName(),
+		Base:          base.RevisionName(),
+		Diff:          proto.Clone(result.diff).(*rpc.Diff),
+		ChangeDetails: proto.Clone(result.details).(*rpc.ChangeDetails),
+	}, nil
+}
+
+// specRevisionContents returns a spec revision and its uncompressed contents.
+// The name can be a spec, which refers to its current revision, or a spec revision.
+func specRevisionContents(ctx context.Context, db *storage.Client, name string) (*models.Spec, []byte, error) {
+	var revisionName names.SpecRevision
+	if spec, err := names.ParseSpec(name); err == nil {
+		current, err := db.GetSpec(ctx, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		revisionName = spec.Revision(current.RevisionID)
+	} else if revisionName, err = names.ParseSpecRevision(name); err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be an API spec or revision", name)
+	}
+
+	revision, err := db.GetSpecRevision(ctx, revisionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	blob, err := db.GetSpecRevisionContents(ctx, revisionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	contents := blob.Contents
+	if strings.Contains(revision.MimeType, "+gzip") {
+		contents, err = models.GUnzippedBytes(contents)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "failed to unzip contents with gzip MIME type: %s", err)
+		}
+	}
+	return revision, contents, nil
+}
+
+// compareSpecContents returns the changes from the base contents to the revision contents.
+func compareSpecContents(base *models.Spec, baseContents []byte, revision *models.Spec, contents []byte) (*rpc.Diff, error) {
+	baseDoc, err := openAPIv3Contents(base.MimeType, baseContents)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "can't compare %q: %s", base.RevisionName(), err)
+	}
+	doc, err := openAPIv3Contents(revision.MimeType, contents)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "can't compare %q: %s", revision.RevisionName(), err)
+	}
+	d, err := diff.GetDiff(baseDoc, doc)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to compare %q with %q: %s", revision.RevisionName(), base.RevisionName(), err)
+	}
+	return d, nil
+}
+
+// openAPIv3Contents returns spec contents as an OpenAPI v3 document, converting OpenAPI v2 documents.
+func openAPIv3Contents(mimeType string, contents []byte) ([]byte, error) {
+	if mimeType == "" {
+		mimeType = detectSpecMimeType(contents)
+	}
+
+	switch {
+	case core.IsOpenAPIv3(mimeType):
+		return contents, nil
+	case core.IsOpenAPIv2(mimeType):
+		j, err := yaml.YAMLToJSON(contents)
+		if err != nil {
+			return nil, err
+		}
+		var doc openapi2.T
+		if err := json.Unmarshal(j, &doc); err != nil {
+			return nil, err
+		}
+		v3, err := openapi2conv.ToV3(&doc)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v3)
+	default:
+		return nil, fmt.Errorf("comparisons are only supported for OpenAPI v2 and v3 specs, not MIME type %q", mimeType)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const (
+	comparedSpecV1 = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: The pets.
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	comparedSpecV2 = `openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.1
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        id:
+          type: integer
+`
+	comparedSwagger = `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: The pets.
+`
+)
+
+func TestCompareApiSpecRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const (
+		version = "projects/my-project/locations/global/apis/a/versions/v1"
+		spec    = version + "/specs/openapi.yaml"
+		swagger = version + "/specs/swagger.yaml"
+		proto   = version + "/specs/service.proto"
+		// mislabeled has the same contents as the current revision of spec.
+		mislabeled = version + "/specs/mislabeled.yaml"
+	)
+	if err := seeder.SeedSpecs(ctx, server,
+		&rpc.ApiSpec{Name: spec, Contents: []byte(comparedSpecV1), RevisionTags: []string{"stable"}},
+		&rpc.ApiSpec{Name: spec, Contents: []byte(comparedSpecV2)},
+		&rpc.ApiSpec{Name: swagger, Contents: []byte(comparedSwagger)},
+		&rpc.ApiSpec{Name: proto, Contents: []byte(`syntax = "proto3";`)},
+		&rpc.ApiSpec{Name: mislabeled, MimeType: "text/plain", Contents: []byte(comparedSpecV2)},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	stable, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec + "@stable"})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec+"@stable", err)
+	}
+	current, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec, err)
+	}
+
+	req := &rpc.CompareApiSpecRevisionsRequest{Name: spec, Base: spec + "@stable"}
+	got, err := server.CompareApiSpecRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("CompareApiSpecRevisions(%+v) returned error: %s", req, err)
+	}
+	want := &rpc.CompareApiSpecRevisionsResponse{
+		Name: spec + "@" + current.GetRevisionId(),
+		Base: spec + "@" + stable.GetRevisionId(),
+		Diff: &rpc.Diff{
+			Additions: []string{"components.schemas.Pet.properties.id"},
+			Deletions: []string{"paths./pets", "endpoints.{GET /pets}"},
+			Modifications: map[string]*rpc.Diff_ValueChange{
+				"info.version": {From: "1.0.0", To: "1.0.1"},
+			},
+		},
+	}
+	opts := cmp.Options{protocmp.Transform(), protocmp.IgnoreFields(want, "change_details")}
+	if !cmp.Equal(want, got, opts) {
+		t.Errorf("CompareApiSpecRevisions(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, got, opts))
+	}
+	if breaking := got.GetChangeDetails().GetBreakingChanges().GetDeletions(); len(breaking) != 1 || breaking[0] != "paths./pets" {
+		t.Errorf("CompareApiSpecRevisions(%+v) returned breaking deletions %v, want [paths./pets]", req, breaking)
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		key := comparisonKey(
+			&models.Spec{MimeType: stable.GetMimeType(), Hash: stable.GetHash()},
+			&models.Spec{MimeType: current.GetMimeType(), Hash: current.GetHash()},
+		)
+		if _, ok := server.comparisons.get(key); !ok {
+			t.Errorf("CompareApiSpecRevisions(%+v) didn't cache its result", req)
+		}
+		again, err := server.CompareApiSpecRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("CompareApiSpecRevisions(%+v) returned error: %s", req, err)
+		}
+		if !cmp.Equal(got, again, protocmp.Transform()) {
+			t.Errorf("CompareApiSpecRevisions(%+v) returned unexpected diff from cache (-want +got):\n%s", req, cmp.Diff(got, again, protocmp.Transform()))
+		}
+	})
+
+	t.Run("cached contents with another mime type", func(t *testing.T) {
+		req := &rpc.CompareApiSpecRevisionsRequest{Name: mislabeled, Base: spec + "@stable"}
+		if _, err := server.CompareApiSpecRevisions(ctx, req); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("CompareApiSpecRevisions(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+		}
+	})
+
+	t.Run("openapi v2", func(t *testing.T) {
+		req := &rpc.CompareApiSpecRevisionsRequest{Name: spec + "@stable", Base: swagger}
+		got, err := server.CompareApiSpecRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("CompareApiSpecRevisions(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetDiff().GetAdditions()) == 0 {
+			t.Errorf("CompareApiSpecRevisions(%+v) returned no additions, want the added schema", req)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			req  *rpc.CompareApiSpecRevisionsRequest
+			want codes.Code
+		}{
+			{req: &rpc.CompareApiSpecRevisionsRequest{Name: spec}, want: codes.InvalidArgument},
+			{req: &rpc.CompareApiSpecRevisionsRequest{Name: version, Base: spec}, want: codes.InvalidArgument},
+			{req: &rpc.CompareApiSpecRevisionsRequest{Name: spec, Base: spec + "@missing"}, want: codes.NotFound},
+			{req: &rpc.CompareApiSpecRevisionsRequest{Name: proto, Base: spec}, want: codes.FailedPrecondition},
+		}
+		for _, test := range tests {
+			if _, err := server.CompareApiSpecRevisions(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("CompareApiSpecRevisions(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		}
+	})
+}
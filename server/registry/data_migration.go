@@ -0,0 +1,146 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/internal/storage"
+)
+
+// DataMigration configures a copy of all stored data from one database to another.
+type DataMigration struct {
+	FromDriver string
+	FromDSN    string
+	ToDriver   string
+	ToDSN      string
+	// BatchSize is the maximum number of records copied at a time.
+	// If unset or zero, a default of 100 is used.
+	BatchSize int
+	// CheckpointFile records progress so that an interrupted migration can be resumed.
+	// If unset, migrations start from the beginning every time.
+	CheckpointFile string
+}
+
+// MigrateData copies every record, including revision IDs and timestamps, from one database to another.
+// After copying, the record counts and contents of both databases are compared and any difference is
+// returned as an error. The checkpoint file is removed once a migration has been verified.
+func MigrateData(ctx context.Context, m DataMigration) error {
+	logger := log.FromContext(ctx)
+
+	checkpoint, err := readDataCheckpoint(m.CheckpointFile)
+	if err != nil {
+		return err
+	}
+	if len(checkpoint) > 0 {
+		logger.Infof("Resuming migration from %s", m.CheckpointFile)
+	}
+
+	from, err := storage.NewClient(ctx, m.FromDriver, m.FromDSN)
+	if err != nil {
+		return fmt.Errorf("failed to open source database: %s", err)
+	}
+	defer from.Close()
+
+	to, err := storage.NewClient(ctx, m.ToDriver, m.ToDSN)
+	if err != nil {
+		return fmt.Errorf("failed to open destination database: %s", err)
+	}
+	defer to.Close()
+	if err := to.EnsureTables(); err != nil {
+		return fmt.Errorf("failed to create destination tables: %s", err)
+	}
+
+	copied := make(map[string]int)
+	err = storage.CopyData(ctx, from, to, storage.DataCopyOptions{
+		BatchSize:  m.BatchSize,
+		Checkpoint: checkpoint,
+		OnBatch: func(kind string, n int, checkpoint storage.DataCheckpoint) error {
+			copied[kind] += n
+			logger.Debugf("Copied %d %s records", copied[kind], kind)
+			return writeDataCheckpoint(m.CheckpointFile, checkpoint)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	want, err := from.DataDigests(ctx, m.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to verify source database: %s", err)
+	}
+	got, err := to.DataDigests(ctx, m.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to verify destination database: %s", err)
+	}
+
+	var mismatched []string
+	for i := range want {
+		logger.Infof("Copied %s: %d records (%d in this run)", want[i].Kind, want[i].Count, copied[want[i].Kind])
+		if got[i] != want[i] {
+			logger.Errorf("Verification failed for %s: source has %d records with digest %s, destination has %d records with digest %s",
+				want[i].Kind, want[i].Count, want[i].Digest, got[i].Count, got[i].Digest)
+			mismatched = append(mismatched, want[i].Kind)
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("destination database differs from source for %s", strings.Join(mismatched, ", "))
+	}
+
+	if m.CheckpointFile != "" {
+		if err := os.Remove(m.CheckpointFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func readDataCheckpoint(path string) (storage.DataCheckpoint, error) {
+	checkpoint := make(storage.DataCheckpoint)
+	if path == "" {
+		return checkpoint, nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %s", path, err)
+	}
+	return checkpoint, nil
+}
+
+// writeDataCheckpoint replaces the checkpoint file so that it is never left partially written.
+func writeDataCheckpoint(path string, checkpoint storage.DataCheckpoint) error {
+	if path == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", b, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func seedDataMigration(ctx context.Context, t *testing.T, server *RegistryServer) {
+	t.Helper()
+	err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			MimeType: "text/plain",
+			Contents: []byte("first"),
+		},
+		&rpc.ApiDeployment{
+			Name: "projects/my-project/locations/global/apis/a/deployments/d",
+		},
+		&rpc.Artifact{
+			Name:     "projects/other-project/locations/global/artifacts/x",
+			Contents: []byte("artifact"),
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := server.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+			Contents: []byte("second"),
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"contents"}},
+	}); err != nil {
+		t.Fatalf("Setup: UpdateApiSpec() returned error: %s", err)
+	}
+}
+
+func TestMigrateData(t *testing.T) {
+	ctx := context.Background()
+	source := defaultTestServer(t)
+	seedDataMigration(ctx, t, source)
+	destination, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+
+	checkpoint := t.TempDir() + "/checkpoint"
+	err = MigrateData(ctx, DataMigration{
+		FromDriver:     source.database,
+		FromDSN:        source.dbConfig,
+		ToDriver:       destination.database,
+		ToDSN:          destination.dbConfig,
+		BatchSize:      1,
+		CheckpointFile: checkpoint,
+	})
+	if err != nil {
+		t.Fatalf("MigrateData() returned error: %s", err)
+	}
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Errorf("MigrateData() left checkpoint file %s behind: %v", checkpoint, err)
+	}
+
+	req := &rpc.ListApiSpecRevisionsRequest{Name: "projects/my-project/locations/global/apis/a/versions/v1/specs/s"}
+	want, err := source.ListApiSpecRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%+v) returned error: %s", req, err)
+	}
+	got, err := destination.ListApiSpecRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%+v) returned error: %s", req, err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ListApiSpecRevisions(%+v) returned unexpected diff after migration (-source +destination):\n%s", req, diff)
+	}
+
+	artifact := &rpc.GetArtifactContentsRequest{Name: "projects/other-project/locations/global/artifacts/x"}
+	if contents, err := destination.GetArtifactContents(ctx, artifact); err != nil {
+		t.Errorf("GetArtifactContents(%+v) returned error: %s", artifact, err)
+	} else if string(contents.GetData()) != "artifact" {
+		t.Errorf("GetArtifactContents(%+v) returned %q, want %q", artifact, contents.GetData(), "artifact")
+	}
+}
+
+func TestMigrateDataResume(t *testing.T) {
+	ctx := context.Background()
+	source := defaultTestServer(t)
+	seedDataMigration(ctx, t, source)
+	destination, err := serverWithSQLite(t)
+	if err != nil {
+		t.Fatalf("Setup: failed to get server with SQLite: %s", err)
+	}
+
+	// A checkpoint claiming that all projects were copied should cause them to be skipped,
+	// which verification should then detect.
+	checkpoint := t.TempDir() + "/checkpoint"
+	if err := ioutil.WriteFile(checkpoint, []byte(`{"Project": "projects/zzz"}`), 0644); err != nil {
+		t.Fatalf("Setup: failed to write checkpoint: %s", err)
+	}
+	migration := DataMigration{
+		FromDriver:     source.database,
+		FromDSN:        source.dbConfig,
+		ToDriver:       destination.database,
+		ToDSN:          destination.dbConfig,
+		CheckpointFile: checkpoint,
+	}
+	if err := MigrateData(ctx, migration); err == nil || !strings.Contains(err.Error(), "Project") {
+		t.Fatalf("MigrateData() with skipped projects returned %v, want verification error for Project", err)
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Fatalf("MigrateData() removed checkpoint file after failed verification: %s", err)
+	}
+
+	// Resuming without the bad checkpoint entry copies the remaining records.
+	if err := ioutil.WriteFile(checkpoint, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Setup: failed to write checkpoint: %s", err)
+	}
+	if err := MigrateData(ctx, migration); err != nil {
+		t.Fatalf("MigrateData() returned error: %s", err)
+	}
+	if _, err := destination.GetProject(ctx, &rpc.GetProjectRequest{Name: "projects/my-project"}); err != nil {
+		t.Errorf("GetProject() returned error after migration: %s", err)
+	}
+}
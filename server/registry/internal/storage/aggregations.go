@@ -0,0 +1,472 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AggregateOptions contains custom arguments for aggregation requests.
+type AggregateOptions struct {
+	// Filter is the filter string that aggregated resources must match, as described at https://google.aip.dev/160.
+	Filter string
+	// GroupBy lists the fields whose values are used to group resources.
+	// Fields can be string fields of a resource or values of its labels or annotations, as in "labels.team".
+	GroupBy []string
+}
+
+// AggregateGroup describes the resources that have the same values for the fields in a GroupBy list.
+type AggregateGroup struct {
+	// Values are the values of the grouped fields, in the order of the GroupBy list.
+	Values []string
+	// Count is the number of resources in the group.
+	Count int64
+	// SizeBytes is the total size of the resources in the group, for resources that have contents.
+	SizeBytes int64
+}
+
+// aggregatePageSize is the page size used to list resources that can't be aggregated in SQL.
+const aggregatePageSize = 1000
+
+// The following maps list the resource fields that can be grouped in SQL, with their storage columns.
+// Other fields, including labels and annotations, are grouped by listing resources.
+var (
+	apiColumns = map[string]string{
+		"project_id":             "project_id",
+		"api_id":                 "api_id",
+		"display_name":           "display_name",
+		"description":            "description",
+		"availability":           "availability",
+		"recommended_version":    "recommended_version",
+		"recommended_deployment": "recommended_deployment",
+	}
+	versionColumns = map[string]string{
+		"project_id":   "project_id",
+		"api_id":       "api_id",
+		"version_id":   "version_id",
+		"display_name": "display_name",
+		"description":  "description",
+		"state":        "state",
+	}
+	specColumns = map[string]string{
+		"project_id":  "project_id",
+		"api_id":      "api_id",
+		"version_id":  "version_id",
+		"spec_id":     "spec_id",
+		"revision_id": "revision_id",
+		"filename":    "file_name",
+		"description": "description",
+		"mime_type":   "mime_type",
+		"hash":        "hash",
+		"source_uri":  "source_uri",
+	}
+	deploymentColumns = map[string]string{
+		"project_id":           "project_id",
+		"api_id":               "api_id",
+		"deployment_id":        "deployment_id",
+		"revision_id":          "revision_id",
+		"display_name":         "display_name",
+		"description":          "description",
+		"api_spec_revision":    "api_spec_revision",
+		"endpoint_uri":         "endpoint_uri",
+		"external_channel_uri": "external_channel_uri",
+		"intended_audience":    "intended_audience",
+		"access_guidance":      "access_guidance",
+	}
+	artifactColumns = map[string]string{
+		"project_id":    "project_id",
+		"api_id":        "api_id",
+		"version_id":    "version_id",
+		"spec_id":       "spec_id",
+		"deployment_id": "deployment_id",
+		"artifact_id":   "artifact_id",
+		"revision_id":   "revision_id",
+		"mime_type":     "mime_type",
+		"hash":          "hash",
+	}
+)
+
+func (d *Client) AggregateApis(ctx context.Context, parent names.Project, opts AggregateOptions) ([]AggregateGroup, error) {
+	if err := validateGroupBy(opts.GroupBy, apiFields); err != nil {
+		return nil, err
+	}
+
+	if columns, ok := aggregateColumns(opts, apiColumns); ok {
+		if err := d.checkAggregateParent(ctx, parent.ProjectID, "", "", ""); err != nil {
+			return nil, err
+		}
+		q := d.NewQuery(gorm.ApiEntityName)
+		if parent.ProjectID != "-" {
+			q = q.Require("ProjectID", parent.ProjectID)
+		}
+		return d.groupCount(ctx, q, columns, "")
+	}
+
+	a := newAggregator(opts.GroupBy)
+	page := PageOptions{Size: aggregatePageSize, Filter: opts.Filter}
+	for {
+		listing, err := d.ListApis(ctx, parent, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range listing.Apis {
+			m, err := apiMap(api)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			a.add(m, 0)
+		}
+		if listing.Token == "" {
+			return a.result(), nil
+		}
+		page.Token = listing.Token
+	}
+}
+
+func (d *Client) AggregateVersions(ctx context.Context, parent names.Api, opts AggregateOptions) ([]AggregateGroup, error) {
+	if err := validateGroupBy(opts.GroupBy, versionFields); err != nil {
+		return nil, err
+	}
+
+	if columns, ok := aggregateColumns(opts, versionColumns); ok {
+		if err := d.checkAggregateParent(ctx, parent.ProjectID, parent.ApiID, "", ""); err != nil {
+			return nil, err
+		}
+		q := d.NewQuery(gorm.VersionEntityName)
+		if parent.ProjectID != "-" {
+			q = q.Require("ProjectID", parent.ProjectID)
+		}
+		if parent.ApiID != "-" {
+			q = q.Require("ApiID", parent.ApiID)
+		}
+		return d.groupCount(ctx, q, columns, "")
+	}
+
+	a := newAggregator(opts.GroupBy)
+	page := PageOptions{Size: aggregatePageSize, Filter: opts.Filter}
+	for {
+		listing, err := d.ListVersions(ctx, parent, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range listing.Versions {
+			m, err := versionMap(version)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			a.add(m, 0)
+		}
+		if listing.Token == "" {
+			return a.result(), nil
+		}
+		page.Token = listing.Token
+	}
+}
+
+func (d *Client) AggregateSpecs(ctx context.Context, parent names.Version, opts AggregateOptions) ([]AggregateGroup, error) {
+	if err := validateGroupBy(opts.GroupBy, specFields); err != nil {
+		return nil, err
+	}
+
+	if columns, ok := aggregateColumns(opts, specColumns); ok {
+		if err := d.checkAggregateParent(ctx, parent.ProjectID, parent.ApiID, parent.VersionID, ""); err != nil {
+			return nil, err
+		}
+		q := d.NewQuery(gorm.SpecEntityName)
+		if parent.ProjectID != "-" {
+			q = q.Require("ProjectID", parent.ProjectID)
+		}
+		if parent.ApiID != "-" {
+			q = q.Require("ApiID", parent.ApiID)
+		}
+		if parent.VersionID != "-" {
+			q = q.Require("VersionID", parent.VersionID)
+		}
+		return d.groupCount(ctx, q, columns, "size_in_bytes")
+	}
+
+	a := newAggregator(opts.GroupBy)
+	page := PageOptions{Size: aggregatePageSize, Filter: opts.Filter}
+	for {
+		listing, err := d.ListSpecs(ctx, parent, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range listing.Specs {
+			m, err := specMap(spec, nil)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			a.add(m, int64(spec.SizeInBytes))
+		}
+		if listing.Token == "" {
+			return a.result(), nil
+		}
+		page.Token = listing.Token
+	}
+}
+
+func (d *Client) AggregateDeployments(ctx context.Context, parent names.Api, opts AggregateOptions) ([]AggregateGroup, error) {
+	if err := validateGroupBy(opts.GroupBy, deploymentFields); err != nil {
+		return nil, err
+	}
+
+	if columns, ok := aggregateColumns(opts, deploymentColumns); ok {
+		if err := d.checkAggregateParent(ctx, parent.ProjectID, parent.ApiID, "", ""); err != nil {
+			return nil, err
+		}
+		q := d.NewQuery(gorm.DeploymentEntityName)
+		if parent.ProjectID != "-" {
+			q = q.Require("ProjectID", parent.ProjectID)
+		}
+		if parent.ApiID != "-" {
+			q = q.Require("ApiID", parent.ApiID)
+		}
+		return d.groupCount(ctx, q, columns, "")
+	}
+
+	a := newAggregator(opts.GroupBy)
+	page := PageOptions{Size: aggregatePageSize, Filter: opts.Filter}
+	for {
+		listing, err := d.ListDeployments(ctx, parent, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range listing.Deployments {
+			m, err := deploymentMap(deployment, nil)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			a.add(m, 0)
+		}
+		if listing.Token == "" {
+			return a.result(), nil
+		}
+		page.Token = listing.Token
+	}
+}
+
+// AggregateArtifacts aggregates the artifacts owned by a parent resource.
+// Empty IDs in the parent name restrict results to artifacts that are not owned by a resource of
+// the corresponding type, as in GetRecentArtifactRevisions, so project artifacts are aggregated
+// with a parent like `names.Spec{ProjectID: "my-project"}`.
+func (d *Client) AggregateArtifacts(ctx context.Context, parent names.Spec, opts AggregateOptions) ([]AggregateGroup, error) {
+	if err := validateGroupBy(opts.GroupBy, artifactFields); err != nil {
+		return nil, err
+	}
+
+	if columns, ok := aggregateColumns(opts, artifactColumns); ok {
+		if err := d.checkAggregateParent(ctx, parent.ProjectID, parent.ApiID, parent.VersionID, parent.SpecID); err != nil {
+			return nil, err
+		}
+		q := d.NewQuery(gorm.ArtifactEntityName)
+		for _, id := range []struct {
+			name, value string
+		}{
+			{"ProjectID", parent.ProjectID},
+			{"ApiID", parent.ApiID},
+			{"VersionID", parent.VersionID},
+			{"SpecID", parent.SpecID},
+		} {
+			if id.value == "-" {
+				// Match the listings, which only include artifacts owned by a resource of each wildcard type.
+				q = q.Exclude(id.name, "")
+			} else {
+				q = q.Require(id.name, id.value)
+			}
+		}
+		return d.groupCount(ctx, q, columns, "size_in_bytes")
+	}
+
+	list := func(page PageOptions) (ArtifactList, error) {
+		switch {
+		case parent.ApiID == "":
+			return d.ListProjectArtifacts(ctx, parent.Project(), page)
+		case parent.VersionID == "":
+			return d.ListApiArtifacts(ctx, parent.Api(), page)
+		case parent.SpecID == "":
+			return d.ListVersionArtifacts(ctx, parent.Version(), page)
+		default:
+			return d.ListSpecArtifacts(ctx, parent, page)
+		}
+	}
+
+	a := newAggregator(opts.GroupBy)
+	page := PageOptions{Size: aggregatePageSize, Filter: opts.Filter}
+	for {
+		listing, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, artifact := range listing.Artifacts {
+			m, err := artifactMap(artifact)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			a.add(m, int64(artifact.SizeInBytes))
+		}
+		if listing.Token == "" {
+			return a.result(), nil
+		}
+		page.Token = listing.Token
+	}
+}
+
+// checkAggregateParent returns an error if the most specific parent of an aggregated collection doesn't exist.
+// Parents with wildcard or empty IDs aren't checked.
+func (d *Client) checkAggregateParent(ctx context.Context, projectID, apiID, versionID, specID string) error {
+	var err error
+	switch {
+	case projectID == "-":
+	case apiID == "-" || apiID == "":
+		_, err = d.GetProject(ctx, names.Project{ProjectID: projectID})
+	case versionID == "-" || versionID == "":
+		_, err = d.GetApi(ctx, names.Api{ProjectID: projectID, ApiID: apiID})
+	case specID == "-" || specID == "":
+		_, err = d.GetVersion(ctx, names.Version{ProjectID: projectID, ApiID: apiID, VersionID: versionID})
+	default:
+		_, err = d.GetSpec(ctx, names.Spec{ProjectID: projectID, ApiID: apiID, VersionID: versionID, SpecID: specID})
+	}
+	return err
+}
+
+func (d *Client) groupCount(ctx context.Context, q *gorm.Query, columns []string, sum string) ([]AggregateGroup, error) {
+	groups, err := d.GroupCount(ctx, q, columns, sum)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result := make([]AggregateGroup, len(groups))
+	for i, g := range groups {
+		result[i] = AggregateGroup{Values: g.Values, Count: g.Count, SizeBytes: g.Sum}
+	}
+	sortGroups(result)
+	return result, nil
+}
+
+// validateGroupBy returns an error if a group_by field isn't a string field of a resource
+// or the value of a key of one of its string maps.
+func validateGroupBy(groupBy []string, fields []filtering.Field) error {
+	types := make(map[string]filtering.FieldType, len(fields))
+	for _, f := range fields {
+		types[f.Name] = f.Type
+	}
+	for _, g := range groupBy {
+		name, key := splitGroupBy(g)
+		t, ok := types[name]
+		switch {
+		case ok && key == "" && t == filtering.String:
+		case ok && key != "" && t == filtering.StringMap:
+		default:
+			return status.Errorf(codes.InvalidArgument, "invalid group_by field %q", g)
+		}
+	}
+	return nil
+}
+
+// aggregateColumns returns the storage columns for the group_by fields in opts,
+// or false if the aggregation can't be computed in SQL.
+func aggregateColumns(opts AggregateOptions, columns map[string]string) ([]string, bool) {
+	if opts.Filter != "" {
+		return nil, false
+	}
+	result := make([]string, 0, len(opts.GroupBy))
+	for _, g := range opts.GroupBy {
+		column, ok := columns[g]
+		if !ok {
+			return nil, false
+		}
+		result = append(result, column)
+	}
+	return result, true
+}
+
+func splitGroupBy(field string) (name, key string) {
+	parts := strings.SplitN(field, ".", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// aggregator groups resources in memory when an aggregation can't be computed in SQL.
+type aggregator struct {
+	groupBy []string
+	groups  map[string]*AggregateGroup
+}
+
+func newAggregator(groupBy []string) *aggregator {
+	a := &aggregator{
+		groupBy: groupBy,
+		groups:  make(map[string]*AggregateGroup),
+	}
+	if len(groupBy) == 0 {
+		// Like SQL, an ungrouped aggregation has a single group, even if it is empty.
+		a.groups[""] = &AggregateGroup{Values: []string{}}
+	}
+	return a
+}
+
+// add adds a resource to its group, using the resource map that is used for filtering.
+// Missing labels and annotations are grouped as empty values.
+func (a *aggregator) add(m map[string]interface{}, size int64) {
+	values := make([]string, len(a.groupBy))
+	for i, g := range a.groupBy {
+		name, key := splitGroupBy(g)
+		if key == "" {
+			values[i], _ = m[name].(string)
+		} else {
+			values[i] = m[name].(map[string]string)[key]
+		}
+	}
+
+	k := strings.Join(values, "\x00")
+	g, ok := a.groups[k]
+	if !ok {
+		g = &AggregateGroup{Values: values}
+		a.groups[k] = g
+	}
+	g.Count++
+	g.SizeBytes += size
+}
+
+func (a *aggregator) result() []AggregateGroup {
+	result := make([]AggregateGroup, 0, len(a.groups))
+	for _, g := range a.groups {
+		result = append(result, *g)
+	}
+	sortGroups(result)
+	return result
+}
+
+// sortGroups orders groups by their values.
+func sortGroups(groups []AggregateGroup) {
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Values, groups[j].Values
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ApiRevisionList contains a page of api metadata revisions.
+type ApiRevisionList struct {
+	Revisions []models.ApiRevision
+	Token     string
+}
+
+func (d *Client) ListApiRevisions(ctx context.Context, parent names.Api, opts PageOptions) (ApiRevisionList, error) {
+	normal := parent.Normal()
+	q := d.NewQuery(gorm.ApiRevisionEntityName)
+	q = q.Require("ProjectID", normal.ProjectID)
+	q = q.Require("ApiID", normal.ApiID)
+	q = q.Descending("RevisionCreateTime")
+
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return ApiRevisionList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	q = q.ApplyOffset(token.Offset)
+
+	q = q.Omit(omittedColumns(opts)...)
+	it := d.Run(ctx, q)
+	response := ApiRevisionList{
+		Revisions: make([]models.ApiRevision, 0, opts.Size),
+	}
+
+	revision := new(models.ApiRevision)
+	for _, err = it.Next(revision); err == nil; _, err = it.Next(revision) {
+		token.Offset++
+
+		response.Revisions = append(response.Revisions, *revision)
+		if len(response.Revisions) == int(opts.Size) {
+			break
+		}
+	}
+	if err != nil && err != iterator.Done {
+		return response, status.Error(codes.Internal, err.Error())
+	}
+
+	if err == nil {
+		response.Token, err = encodeToken(token)
+		if err != nil {
+			return response, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return response, nil
+}
+
+func (d *Client) SaveApiRevision(ctx context.Context, revision *models.ApiRevision) error {
+	k := d.NewKey(gorm.ApiRevisionEntityName, revision.Name())
+	if _, err := d.Put(ctx, k, revision); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
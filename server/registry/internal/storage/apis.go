@@ -42,7 +42,10 @@ var apiFields = []filtering.Field{
 	{Name: "update_time", Type: filtering.Timestamp},
 	{Name: "availability", Type: filtering.String},
 	{Name: "recommended_version", Type: filtering.String},
+	{Name: "recommended_deployment", Type: filtering.String},
 	{Name: "labels", Type: filtering.StringMap},
+	{Name: "annotations", Type: filtering.StringMap},
+	projectParentField,
 }
 
 func (d *Client) ListApis(ctx context.Context, parent names.Project, opts PageOptions) (ApiList, error) {
@@ -73,7 +76,9 @@ func (d *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 		return ApiList{}, err
 	}
 
+	q = q.Omit(omittedColumns(opts)...)
 	it := d.Run(ctx, q)
+	parents := d.newParentBindings(filter)
 	response := ApiList{
 		Apis: make([]models.Api, 0, opts.Size),
 	}
@@ -84,6 +89,9 @@ func (d *Client) ListApis(ctx context.Context, parent names.Project, opts PageOp
 		if err != nil {
 			return response, status.Error(codes.Internal, err.Error())
 		}
+		if err := parents.bind(ctx, apiMap, api.ProjectID, "", ""); err != nil {
+			return response, err
+		}
 
 		match, err := filter.Matches(apiMap)
 		if err != nil {
@@ -118,17 +126,24 @@ func apiMap(api models.Api) (map[string]interface{}, error) {
 		return nil, err
 	}
 
+	annotations, err := api.AnnotationsMap()
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
-		"name":                api.Name(),
-		"project_id":          api.ProjectID,
-		"api_id":              api.ApiID,
-		"display_name":        api.DisplayName,
-		"description":         api.Description,
-		"create_time":         api.CreateTime,
-		"update_time":         api.UpdateTime,
-		"availability":        api.Availability,
-		"recommended_version": api.RecommendedVersion,
-		"labels":              labels,
+		"name":                   api.Name(),
+		"project_id":             api.ProjectID,
+		"api_id":                 api.ApiID,
+		"display_name":           api.DisplayName,
+		"description":            api.Description,
+		"create_time":            api.CreateTime,
+		"update_time":            api.UpdateTime,
+		"availability":           api.Availability,
+		"recommended_version":    api.RecommendedVersion,
+		"recommended_deployment": api.RecommendedDeployment,
+		"labels":                 labels,
+		"annotations":            annotations,
 	}, nil
 }
 
@@ -156,12 +171,15 @@ func (d *Client) SaveApi(ctx context.Context, api *models.Api) error {
 func (d *Client) DeleteApi(ctx context.Context, name names.Api) error {
 	for _, entityName := range []string{
 		gorm.ApiEntityName,
+		gorm.ApiRevisionEntityName,
 		gorm.VersionEntityName,
+		gorm.VersionRevisionEntityName,
 		gorm.SpecEntityName,
 		gorm.SpecRevisionTagEntityName,
 		gorm.DeploymentEntityName,
 		gorm.DeploymentRevisionTagEntityName,
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 		gorm.BlobEntityName,
 	} {
 		q := d.NewQuery(entityName)
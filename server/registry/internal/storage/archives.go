@@ -0,0 +1,376 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ArchiveFormat identifies project archives in their manifests.
+const ArchiveFormat = "apigee-registry-project-archive"
+
+// archiveVersion is the newest archive layout that can be written and read.
+const archiveVersion = 1
+
+// archiveManifestName is the name of the first file in every archive.
+const archiveManifestName = "manifest.json"
+
+// ArchiveManifest describes the contents of a project archive.
+type ArchiveManifest struct {
+	Format     string           `json:"format"`
+	Version    int              `json:"version"`
+	Project    string           `json:"project"`
+	CreateTime time.Time        `json:"create_time"`
+	Records    map[string]int64 `json:"records"`
+}
+
+// archivedEntities lists the entities saved in project archives, parents first.
+// Request records are not archived because they only matter to the server that received them.
+var archivedEntities = []struct {
+	kind string
+	new  func() interface{}
+}{
+	{gorm.ProjectEntityName, func() interface{} { return new(models.Project) }},
+	{gorm.ApiEntityName, func() interface{} { return new(models.Api) }},
+	{gorm.ApiRevisionEntityName, func() interface{} { return new(models.ApiRevision) }},
+	{gorm.VersionEntityName, func() interface{} { return new(models.Version) }},
+	{gorm.VersionRevisionEntityName, func() interface{} { return new(models.VersionRevision) }},
+	{gorm.SpecEntityName, func() interface{} { return new(models.Spec) }},
+	{gorm.SpecRevisionTagEntityName, func() interface{} { return new(models.SpecRevisionTag) }},
+	{gorm.DeploymentEntityName, func() interface{} { return new(models.Deployment) }},
+	{gorm.DeploymentRevisionTagEntityName, func() interface{} { return new(models.DeploymentRevisionTag) }},
+	{gorm.ArtifactEntityName, func() interface{} { return new(models.Artifact) }},
+	{gorm.ArtifactRevisionTagEntityName, func() interface{} { return new(models.ArtifactRevisionTag) }},
+	{gorm.BlobEntityName, func() interface{} { return new(models.Blob) }},
+}
+
+// ExportProject writes every stored record of a project to w as a gzipped tar archive.
+// The archive holds a manifest followed by one file of JSON lines per entity kind.
+func (d *Client) ExportProject(ctx context.Context, name names.Project, w io.Writer) (ArchiveManifest, error) {
+	if _, err := d.GetProject(ctx, name); err != nil {
+		return ArchiveManifest{}, err
+	}
+
+	manifest := ArchiveManifest{
+		Format:     ArchiveFormat,
+		Version:    archiveVersion,
+		Project:    name.String(),
+		CreateTime: time.Now().Round(time.Microsecond).UTC(),
+		Records:    make(map[string]int64, len(archivedEntities)),
+	}
+
+	// Records are spooled to temporary files because tar headers need each file's size
+	// before its contents, and whole projects may be too large to hold in memory.
+	files := make([]*os.File, len(archivedEntities))
+	defer func() {
+		for _, f := range files {
+			if f != nil {
+				f.Close()
+				os.Remove(f.Name())
+			}
+		}
+	}()
+	for i, e := range archivedEntities {
+		f, err := os.CreateTemp("", "registry-export-*.jsonl")
+		if err != nil {
+			return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+		}
+		files[i] = f
+		bw := bufio.NewWriter(f)
+		enc := json.NewEncoder(bw)
+		err = d.eachRecord(ctx, d.projectQuery(e.kind, name.ProjectID), e.new, func(v interface{}) error {
+			manifest.Records[e.kind]++
+			return enc.Encode(v)
+		})
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+	}
+	if err := writeArchiveFile(tw, archiveManifestName, bytes.NewReader(b), int64(len(b)), manifest.CreateTime); err != nil {
+		return ArchiveManifest{}, err
+	}
+	for i, e := range archivedEntities {
+		size, err := files[i].Seek(0, io.SeekCurrent)
+		if err == nil {
+			_, err = files[i].Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+		}
+		if err := writeArchiveFile(tw, e.kind+".jsonl", files[i], size, manifest.CreateTime); err != nil {
+			return ArchiveManifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+	}
+	if err := zw.Close(); err != nil {
+		return ArchiveManifest{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return manifest, nil
+}
+
+// recordBatchSize is the number of records read at a time by eachRecord.
+const recordBatchSize = 100
+
+// projectQuery returns a query for the stored records of kind that belong to a project,
+// or for every stored record of kind if projectID is empty.
+func (d *Client) projectQuery(kind, projectID string) *gorm.Query {
+	q := d.NewQuery(kind)
+	if projectID != "" {
+		q = q.Require("ProjectID", projectID)
+	}
+	return q
+}
+
+// eachRecord calls fn with every stored record that matches a query, in key order.
+// Records are read in batches so that large tables aren't held in memory.
+func (d *Client) eachRecord(ctx context.Context, q *gorm.Query, newValue func() interface{}, fn func(interface{}) error) error {
+	for after := ""; ; {
+		it, err := d.RunBatch(ctx, q, after, recordBatchSize)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		v := newValue()
+		for _, err = it.Next(v); err == nil; _, err = it.Next(v) {
+			if err := fn(v); err != nil {
+				return err
+			}
+			count++
+		}
+		if err != iterator.Done {
+			return err
+		}
+		if count < recordBatchSize {
+			return nil
+		}
+		after = it.Cursor
+	}
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, r io.Reader, size int64, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if _, err := io.CopyN(tw, r, size); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// ImportProject restores a project from an archive written by ExportProject.
+// If projectID is nonempty, records are imported into a project with that ID instead of the archived one.
+// The target project must not already exist. It returns the name of the imported project and its record counts.
+func (d *Client) ImportProject(ctx context.Context, r io.Reader, projectID string) (names.Project, map[string]int64, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return names.Project{}, nil, invalidArchive(err)
+	}
+	tr := tar.NewReader(zr)
+
+	manifest, err := readArchiveManifest(tr)
+	if err != nil {
+		return names.Project{}, nil, err
+	}
+	source, err := names.ParseProject(manifest.Project)
+	if err != nil {
+		return names.Project{}, nil, invalidArchive(err)
+	}
+	target := source
+	if projectID != "" {
+		target = names.Project{ProjectID: projectID}
+	}
+	if err := target.Validate(); err != nil {
+		return names.Project{}, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err := d.GetProject(ctx, target); err == nil {
+		return names.Project{}, nil, status.Errorf(codes.AlreadyExists, "project %q already exists", target)
+	} else if status.Code(err) != codes.NotFound {
+		return names.Project{}, nil, err
+	}
+
+	counts, err := d.importRecords(ctx, tr, source, target)
+	if err == nil {
+		err = checkArchiveCounts(manifest, counts)
+	}
+	if err != nil {
+		// Leave no partially imported project behind.
+		if derr := d.deleteProjectRecords(ctx, target); derr != nil {
+			return names.Project{}, nil, derr
+		}
+		return names.Project{}, nil, err
+	}
+
+	return target, counts, nil
+}
+
+func readArchiveManifest(tr *tar.Reader) (ArchiveManifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return ArchiveManifest{}, invalidArchive(err)
+	}
+	if header.Name != archiveManifestName {
+		return ArchiveManifest{}, invalidArchive(fmt.Errorf("first file is %q, want %q", header.Name, archiveManifestName))
+	}
+
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return ArchiveManifest{}, invalidArchive(err)
+	}
+	if manifest.Format != ArchiveFormat {
+		return ArchiveManifest{}, invalidArchive(fmt.Errorf("unknown format %q", manifest.Format))
+	}
+	if manifest.Version < 1 || manifest.Version > archiveVersion {
+		return ArchiveManifest{}, invalidArchive(fmt.Errorf("unsupported version %d", manifest.Version))
+	}
+	return manifest, nil
+}
+
+func (d *Client) importRecords(ctx context.Context, tr *tar.Reader, source, target names.Project) (map[string]int64, error) {
+	newValues := make(map[string]func() interface{}, len(archivedEntities))
+	for _, e := range archivedEntities {
+		newValues[e.kind] = e.new
+	}
+
+	counts := make(map[string]int64, len(archivedEntities))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return counts, nil
+		} else if err != nil {
+			return counts, invalidArchive(err)
+		}
+
+		kind := strings.TrimSuffix(header.Name, ".jsonl")
+		newValue, ok := newValues[kind]
+		if !ok || kind == header.Name {
+			return counts, invalidArchive(fmt.Errorf("unexpected file %q", header.Name))
+		}
+
+		s := bufio.NewScanner(tr)
+		s.Buffer(nil, int(header.Size)+1)
+		for s.Scan() {
+			v := newValue()
+			if err := json.Unmarshal(s.Bytes(), v); err != nil {
+				return counts, invalidArchive(fmt.Errorf("%s: %s", header.Name, err))
+			}
+			key, err := moveRecord(v, source, target)
+			if err != nil {
+				return counts, invalidArchive(fmt.Errorf("%s: %s", header.Name, err))
+			}
+			if _, err := d.Put(ctx, d.NewKey(kind, key), v); err != nil {
+				return counts, status.Error(codes.Internal, err.Error())
+			}
+			counts[kind]++
+		}
+		if err := s.Err(); err != nil {
+			return counts, invalidArchive(fmt.Errorf("%s: %s", header.Name, err))
+		}
+	}
+}
+
+// moveRecord rewrites the project ID and every resource name in a record from the source project to the target.
+// It returns the record's new storage key.
+func moveRecord(v interface{}, source, target names.Project) (string, error) {
+	from, to := source.String(), target.String()
+	r := reflect.ValueOf(v).Elem()
+	if id := r.FieldByName("ProjectID"); id.String() != source.ProjectID {
+		return "", fmt.Errorf("record %q does not belong to project %q", r.FieldByName("Key").String(), from)
+	}
+	for i := 0; i < r.NumField(); i++ {
+		f := r.Field(i)
+		if f.Kind() != reflect.String {
+			continue
+		}
+		switch s := f.String(); {
+		case r.Type().Field(i).Name == "ProjectID":
+			f.SetString(target.ProjectID)
+		case s == from || strings.HasPrefix(s, from+"/"):
+			f.SetString(to + strings.TrimPrefix(s, from))
+		}
+	}
+
+	key := r.FieldByName("Key").String()
+	if key != to && !strings.HasPrefix(key, to+"/") {
+		return "", fmt.Errorf("record key %q is outside project %q", key, from)
+	}
+	return key, nil
+}
+
+func checkArchiveCounts(manifest ArchiveManifest, counts map[string]int64) error {
+	for _, e := range archivedEntities {
+		if counts[e.kind] != manifest.Records[e.kind] {
+			return invalidArchive(fmt.Errorf("found %d %s records, manifest lists %d", counts[e.kind], e.kind, manifest.Records[e.kind]))
+		}
+	}
+	if counts[gorm.ProjectEntityName] != 1 {
+		return invalidArchive(fmt.Errorf("found %d project records, want 1", counts[gorm.ProjectEntityName]))
+	}
+	return nil
+}
+
+// deleteProjectRecords deletes every archived entity of a project.
+func (d *Client) deleteProjectRecords(ctx context.Context, name names.Project) error {
+	for _, e := range archivedEntities {
+		q := d.NewQuery(e.kind)
+		q = q.Require("ProjectID", name.ProjectID)
+		if err := d.Delete(ctx, q); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+	return nil
+}
+
+func invalidArchive(err error) error {
+	return status.Errorf(codes.InvalidArgument, "invalid archive: %s", err)
+}
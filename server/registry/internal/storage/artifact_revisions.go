@@ -0,0 +1,182 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the revisionific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (d *Client) ListArtifactRevisions(ctx context.Context, parent names.Artifact, opts PageOptions) (ArtifactList, error) {
+	normal := parent.Normal()
+	q := d.NewQuery(gorm.ArtifactEntityName)
+	q = q.Require("ProjectID", normal.ProjectID())
+	q = q.Require("ApiID", normal.ApiID())
+	q = q.Require("VersionID", normal.VersionID())
+	q = q.Require("SpecID", normal.SpecID())
+	q = q.Require("DeploymentID", normal.DeploymentID())
+	q = q.Require("ArtifactID", normal.ArtifactID())
+	q = q.Descending("RevisionCreateTime")
+
+	token, err := decodeToken(opts.Token)
+	if err != nil {
+		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
+	}
+
+	if err := token.ValidateFilter(opts.Filter); err != nil {
+		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid filter %q: %s", opts.Filter, err)
+	}
+
+	q = q.ApplyOffset(token.Offset)
+
+	q = q.Omit(omittedColumns(opts)...)
+	it := d.Run(ctx, q)
+	response := ArtifactList{
+		Artifacts: make([]models.Artifact, 0, opts.Size),
+	}
+
+	revision := new(models.Artifact)
+	for _, err = it.Next(revision); err == nil; _, err = it.Next(revision) {
+		token.Offset++
+
+		response.Artifacts = append(response.Artifacts, *revision)
+		if len(response.Artifacts) == int(opts.Size) {
+			break
+		}
+	}
+	if err != nil && err != iterator.Done {
+		return response, status.Error(codes.Internal, err.Error())
+	}
+
+	if err == nil {
+		response.Token, err = encodeToken(token)
+		if err != nil {
+			return response, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return response, nil
+}
+
+func (d *Client) SaveArtifactRevision(ctx context.Context, revision *models.Artifact) error {
+	k := d.NewKey(gorm.ArtifactEntityName, revision.RevisionName())
+	if _, err := d.Put(ctx, k, revision); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (d *Client) SaveArtifactRevisionContents(ctx context.Context, artifact *models.Artifact, contents []byte) error {
+	blob := models.NewBlobForArtifact(artifact, contents)
+	k := d.NewKey(gorm.BlobEntityName, artifact.RevisionName())
+	if _, err := d.Put(ctx, k, blob); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (d *Client) GetArtifactRevision(ctx context.Context, name names.ArtifactRevision) (*models.Artifact, error) {
+	name, err := d.unwrapArtifactRevisionTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := new(models.Artifact)
+	k := d.NewKey(gorm.ArtifactEntityName, name.String())
+	if err := d.Get(ctx, k, artifact); d.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "artifact revision %q not found", name)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return artifact, nil
+}
+
+func (d *Client) GetArtifactRevisionContents(ctx context.Context, name names.ArtifactRevision) (*models.Blob, error) {
+	name, err := d.unwrapArtifactRevisionTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	blob := new(models.Blob)
+	k := d.NewKey(gorm.BlobEntityName, name.String())
+	if err := d.Get(ctx, k, blob); d.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "artifact revision contents %q not found", name)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return blob, nil
+}
+
+func (d *Client) DeleteArtifactRevision(ctx context.Context, name names.ArtifactRevision) error {
+	name, err := d.unwrapArtifactRevisionTag(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	artifact := name.Artifact().Normal()
+	for _, entityName := range []string{
+		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
+	} {
+		q := d.NewQuery(entityName)
+		q = q.Require("ProjectID", artifact.ProjectID())
+		q = q.Require("ApiID", artifact.ApiID())
+		q = q.Require("VersionID", artifact.VersionID())
+		q = q.Require("SpecID", artifact.SpecID())
+		q = q.Require("DeploymentID", artifact.DeploymentID())
+		q = q.Require("ArtifactID", artifact.ArtifactID())
+		q = q.Require("RevisionID", name.RevisionID)
+		if err := d.Delete(ctx, q); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Blobs don't record deployments, so the contents are identified by their revision name.
+	if err := d.Delete(ctx, d.NewQuery(gorm.BlobEntityName).Require("key", name.String())); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (d *Client) SaveArtifactRevisionTag(ctx context.Context, tag *models.ArtifactRevisionTag) error {
+	k := d.NewKey(gorm.ArtifactRevisionTagEntityName, tag.String())
+	if _, err := d.Put(ctx, k, tag); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (d *Client) unwrapArtifactRevisionTag(ctx context.Context, name names.ArtifactRevision) (names.ArtifactRevision, error) {
+	tag := new(models.ArtifactRevisionTag)
+	if err := d.Get(ctx, d.NewKey(gorm.ArtifactRevisionTagEntityName, name.String()), tag); d.IsNotFound(err) {
+		return name, nil
+	} else if err != nil {
+		return names.ArtifactRevision{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return name.Artifact().Revision(tag.RevisionID), nil
+}
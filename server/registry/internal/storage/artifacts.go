@@ -38,16 +38,21 @@ var artifactFields = []filtering.Field{
 	{Name: "api_id", Type: filtering.String},
 	{Name: "version_id", Type: filtering.String},
 	{Name: "spec_id", Type: filtering.String},
+	{Name: "deployment_id", Type: filtering.String},
 	{Name: "artifact_id", Type: filtering.String},
+	{Name: "revision_id", Type: filtering.String},
 	{Name: "create_time", Type: filtering.Timestamp},
+	{Name: "revision_create_time", Type: filtering.Timestamp},
 	{Name: "update_time", Type: filtering.Timestamp},
 	{Name: "mime_type", Type: filtering.String},
 	{Name: "size_bytes", Type: filtering.Int},
+	{Name: "hash", Type: filtering.String},
+	projectParentField,
+	apiParentField,
+	versionParentField,
 }
 
 func (d *Client) ListSpecArtifacts(ctx context.Context, parent names.Spec, opts PageOptions) (ArtifactList, error) {
-	q := d.NewQuery(gorm.ArtifactEntityName)
-
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -59,21 +64,6 @@ func (d *Client) ListSpecArtifacts(ctx context.Context, parent names.Spec, opts
 		token.Filter = opts.Filter
 	}
 
-	q = q.ApplyOffset(token.Offset)
-
-	if id := parent.ProjectID; id != "-" {
-		q = q.Require("ProjectID", id)
-	}
-	if id := parent.ApiID; id != "-" {
-		q = q.Require("ApiID", id)
-	}
-	if id := parent.VersionID; id != "-" {
-		q = q.Require("VersionID", id)
-	}
-	if id := parent.SpecID; id != "-" {
-		q = q.Require("SpecID", id)
-	}
-
 	if parent.ProjectID != "-" && parent.ApiID != "-" && parent.VersionID != "-" && parent.SpecID != "-" {
 		if _, err := d.GetSpec(ctx, parent); err != nil {
 			return ArtifactList{}, err
@@ -92,15 +82,13 @@ func (d *Client) ListSpecArtifacts(ctx context.Context, parent names.Spec, opts
 		}
 	}
 
-	return d.listArtifacts(ctx, d.Run(ctx, q), opts, func(a *models.Artifact) bool {
+	it := d.GetRecentArtifactRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, parent.VersionID, parent.SpecID, omittedColumns(opts)...)
+	return d.listArtifacts(ctx, it, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != "" && a.VersionID != "" && a.SpecID != ""
 	})
 }
 
 func (d *Client) ListVersionArtifacts(ctx context.Context, parent names.Version, opts PageOptions) (ArtifactList, error) {
-	q := d.NewQuery(gorm.ArtifactEntityName)
-	q = q.Require("SpecID", "")
-
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -112,18 +100,6 @@ func (d *Client) ListVersionArtifacts(ctx context.Context, parent names.Version,
 		token.Filter = opts.Filter
 	}
 
-	q = q.ApplyOffset(token.Offset)
-
-	if id := parent.ProjectID; id != "-" {
-		q = q.Require("ProjectID", id)
-	}
-	if id := parent.ApiID; id != "-" {
-		q = q.Require("ApiID", id)
-	}
-	if id := parent.VersionID; id != "-" {
-		q = q.Require("VersionID", id)
-	}
-
 	if parent.ProjectID != "-" && parent.ApiID != "-" && parent.VersionID != "-" {
 		if _, err := d.GetVersion(ctx, parent); err != nil {
 			return ArtifactList{}, err
@@ -138,16 +114,13 @@ func (d *Client) ListVersionArtifacts(ctx context.Context, parent names.Version,
 		}
 	}
 
-	return d.listArtifacts(ctx, d.Run(ctx, q), opts, func(a *models.Artifact) bool {
+	it := d.GetRecentArtifactRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, parent.VersionID, "", omittedColumns(opts)...)
+	return d.listArtifacts(ctx, it, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != "" && a.VersionID != ""
 	})
 }
 
 func (d *Client) ListApiArtifacts(ctx context.Context, parent names.Api, opts PageOptions) (ArtifactList, error) {
-	q := d.NewQuery(gorm.ArtifactEntityName)
-	q = q.Require("VersionID", "")
-	q = q.Require("SpecID", "")
-
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -159,15 +132,6 @@ func (d *Client) ListApiArtifacts(ctx context.Context, parent names.Api, opts Pa
 		token.Filter = opts.Filter
 	}
 
-	q = q.ApplyOffset(token.Offset)
-
-	if id := parent.ProjectID; id != "-" {
-		q = q.Require("ProjectID", id)
-	}
-	if id := parent.ApiID; id != "-" {
-		q = q.Require("ApiID", id)
-	}
-
 	if parent.ProjectID != "-" && parent.ApiID != "-" {
 		if _, err := d.GetApi(ctx, parent); err != nil {
 			return ArtifactList{}, err
@@ -178,17 +142,13 @@ func (d *Client) ListApiArtifacts(ctx context.Context, parent names.Api, opts Pa
 		}
 	}
 
-	return d.listArtifacts(ctx, d.Run(ctx, q), opts, func(a *models.Artifact) bool {
+	it := d.GetRecentArtifactRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, "", "", omittedColumns(opts)...)
+	return d.listArtifacts(ctx, it, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != "" && a.ApiID != ""
 	})
 }
 
 func (d *Client) ListProjectArtifacts(ctx context.Context, parent names.Project, opts PageOptions) (ArtifactList, error) {
-	q := d.NewQuery(gorm.ArtifactEntityName)
-	q = q.Require("ApiID", "")
-	q = q.Require("VersionID", "")
-	q = q.Require("SpecID", "")
-
 	token, err := decodeToken(opts.Token)
 	if err != nil {
 		return ArtifactList{}, status.Errorf(codes.InvalidArgument, "invalid page token %q: %s", opts.Token, err.Error())
@@ -200,16 +160,14 @@ func (d *Client) ListProjectArtifacts(ctx context.Context, parent names.Project,
 		token.Filter = opts.Filter
 	}
 
-	q = q.ApplyOffset(token.Offset)
-
-	if id := parent.ProjectID; id != "-" {
-		q = q.Require("ProjectID", id)
+	if parent.ProjectID != "-" {
 		if _, err := d.GetProject(ctx, parent); err != nil {
 			return ArtifactList{}, err
 		}
 	}
 
-	return d.listArtifacts(ctx, d.Run(ctx, q), opts, func(a *models.Artifact) bool {
+	it := d.GetRecentArtifactRevisions(ctx, token.Offset, parent.ProjectID, "", "", "", omittedColumns(opts)...)
+	return d.listArtifacts(ctx, it, opts, func(a *models.Artifact) bool {
 		return a.ProjectID != ""
 	})
 }
@@ -226,6 +184,7 @@ func (d *Client) listArtifacts(ctx context.Context, it *gorm.Iterator, opts Page
 	if err != nil {
 		return ArtifactList{}, err
 	}
+	parents := d.newParentBindings(filter)
 
 	response := ArtifactList{
 		Artifacts: make([]models.Artifact, 0, opts.Size),
@@ -237,6 +196,9 @@ func (d *Client) listArtifacts(ctx context.Context, it *gorm.Iterator, opts Page
 		if err != nil {
 			return response, status.Error(codes.Internal, err.Error())
 		}
+		if err := parents.bind(ctx, artifactMap, artifact.ProjectID, artifact.ApiID, artifact.VersionID); err != nil {
+			return response, err
+		}
 
 		match, err := filter.Matches(artifactMap)
 		if err != nil {
@@ -267,42 +229,37 @@ func (d *Client) listArtifacts(ctx context.Context, it *gorm.Iterator, opts Page
 
 func artifactMap(artifact models.Artifact) (map[string]interface{}, error) {
 	return map[string]interface{}{
-		"name":        artifact.Name(),
-		"project_id":  artifact.ProjectID,
-		"api_id":      artifact.ApiID,
-		"version_id":  artifact.VersionID,
-		"spec_id":     artifact.SpecID,
-		"artifact_id": artifact.ArtifactID,
-		"create_time": artifact.CreateTime,
-		"update_time": artifact.UpdateTime,
-		"mime_type":   artifact.MimeType,
-		"size_bytes":  artifact.SizeInBytes,
+		"name":                 artifact.Name(),
+		"project_id":           artifact.ProjectID,
+		"api_id":               artifact.ApiID,
+		"version_id":           artifact.VersionID,
+		"spec_id":              artifact.SpecID,
+		"deployment_id":        artifact.DeploymentID,
+		"artifact_id":          artifact.ArtifactID,
+		"revision_id":          artifact.RevisionID,
+		"create_time":          artifact.CreateTime,
+		"revision_create_time": artifact.RevisionCreateTime,
+		"update_time":          artifact.UpdateTime,
+		"mime_type":            artifact.MimeType,
+		"size_bytes":           artifact.SizeInBytes,
+		"hash":                 artifact.Hash,
 	}, nil
 }
 
-func (d *Client) SaveArtifact(ctx context.Context, artifact *models.Artifact) error {
-	k := d.NewKey(gorm.ArtifactEntityName, artifact.Name())
-	if _, err := d.Put(ctx, k, artifact); err != nil {
-		return status.Error(codes.Internal, err.Error())
-	}
-
-	return nil
-}
-
-func (d *Client) SaveArtifactContents(ctx context.Context, artifact *models.Artifact, contents []byte) error {
-	blob := models.NewBlobForArtifact(artifact, contents)
-	k := d.NewKey(gorm.BlobEntityName, artifact.Name())
-	if _, err := d.Put(ctx, k, blob); err != nil {
-		return status.Error(codes.Internal, err.Error())
-	}
-
-	return nil
-}
-
 func (d *Client) GetArtifact(ctx context.Context, name names.Artifact) (*models.Artifact, error) {
-	artifact := new(models.Artifact)
-	k := d.NewKey(gorm.ArtifactEntityName, name.String())
-	if err := d.Get(ctx, k, artifact); d.IsNotFound(err) {
+	normal := name.Normal()
+	q := d.NewQuery(gorm.ArtifactEntityName)
+	q = q.Require("ProjectID", normal.ProjectID())
+	q = q.Require("ApiID", normal.ApiID())
+	q = q.Require("VersionID", normal.VersionID())
+	q = q.Require("SpecID", normal.SpecID())
+	q = q.Require("DeploymentID", normal.DeploymentID())
+	q = q.Require("ArtifactID", normal.ArtifactID())
+	q = q.Descending("RevisionCreateTime")
+
+	it := d.Run(ctx, q)
+	artifact := &models.Artifact{}
+	if _, err := it.Next(artifact); err == iterator.Done {
 		return nil, status.Errorf(codes.NotFound, "artifact %q not found in database", name)
 	} else if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -311,21 +268,10 @@ func (d *Client) GetArtifact(ctx context.Context, name names.Artifact) (*models.
 	return artifact, nil
 }
 
-func (d *Client) GetArtifactContents(ctx context.Context, name names.Artifact) (*models.Blob, error) {
-	blob := new(models.Blob)
-	k := d.NewKey(gorm.BlobEntityName, name.String())
-	if err := d.Get(ctx, k, blob); d.IsNotFound(err) {
-		return nil, status.Errorf(codes.NotFound, "artifact contents %q not found", name)
-	} else if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	return blob, nil
-}
-
 func (d *Client) DeleteArtifact(ctx context.Context, name names.Artifact) error {
 	for _, entityName := range []string{
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 		gorm.BlobEntityName,
 	} {
 		q := d.NewQuery(entityName)
@@ -341,3 +287,28 @@ func (d *Client) DeleteArtifact(ctx context.Context, name names.Artifact) error
 
 	return nil
 }
+
+func (d *Client) GetArtifactTags(ctx context.Context, name names.Artifact) ([]*models.ArtifactRevisionTag, error) {
+	normal := name.Normal()
+	q := d.NewQuery(gorm.ArtifactRevisionTagEntityName)
+	q = q.Require("ProjectID", normal.ProjectID())
+	q = q.Require("ApiID", normal.ApiID())
+	q = q.Require("VersionID", normal.VersionID())
+	q = q.Require("SpecID", normal.SpecID())
+	q = q.Require("DeploymentID", normal.DeploymentID())
+	q = q.Require("ArtifactID", normal.ArtifactID())
+
+	it := d.Run(ctx, q)
+	tags := make([]*models.ArtifactRevisionTag, 0)
+	for {
+		tag := new(models.ArtifactRevisionTag)
+		if _, err := it.Next(tag); err == iterator.Done {
+			break
+		} else if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,502 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kinds of consistency problems.
+const (
+	// ProblemOrphan is a record whose parent resource doesn't exist.
+	ProblemOrphan = "ORPHAN"
+	// ProblemDanglingTag is a revision tag for a revision that doesn't exist.
+	ProblemDanglingTag = "DANGLING_TAG"
+	// ProblemHashMismatch is a hash that doesn't match the stored contents.
+	ProblemHashMismatch = "HASH_MISMATCH"
+	// ProblemMissingContents is a revision with a hash but no stored contents.
+	ProblemMissingContents = "MISSING_CONTENTS"
+	// ProblemBadReference is a field that refers to a resource that doesn't exist.
+	ProblemBadReference = "BAD_REFERENCE"
+)
+
+// ConsistencyProblem describes a stored record that is inconsistent with other records.
+type ConsistencyProblem struct {
+	Kind        string
+	Entity      string
+	Key         string
+	Description string
+	Repaired    bool
+}
+
+// consistencyChecker holds the stored records that other records refer to.
+type consistencyChecker struct {
+	d         *Client
+	projectID string
+	repair    bool
+	problems  []ConsistencyProblem
+	fixes     []func() error
+
+	// resources holds the names of stored projects, apis, versions, specs and deployments
+	// that have all of their parents.
+	resources      map[string]bool
+	apis           []*models.Api
+	specs          map[string]*models.Spec
+	specTags       map[string]string
+	deployments    map[string]*models.Deployment
+	deploymentTags map[string]string
+	artifacts      map[string]*models.Artifact
+	contents       map[string]bool
+
+	// Revision keys in storage order, for reporting problems in a stable order.
+	specKeys       []string
+	deploymentKeys []string
+	artifactKeys   []string
+}
+
+// CheckConsistency looks for stored records that are inconsistent with each other in a project,
+// or in all projects if projectID is empty. If repair is set, problems that can be repaired are:
+// orphans and dangling tags are deleted, bad references are cleared and hashes are recomputed from
+// the stored contents.
+func (d *Client) CheckConsistency(ctx context.Context, projectID string, repair bool) ([]ConsistencyProblem, error) {
+	c := &consistencyChecker{
+		d:              d,
+		projectID:      projectID,
+		repair:         repair,
+		resources:      make(map[string]bool),
+		specs:          make(map[string]*models.Spec),
+		specTags:       make(map[string]string),
+		deployments:    make(map[string]*models.Deployment),
+		deploymentTags: make(map[string]string),
+		artifacts:      make(map[string]*models.Artifact),
+		contents:       make(map[string]bool),
+	}
+
+	// Records are checked parents first, so that the children of orphans are also reported as orphans.
+	for _, check := range []func(context.Context) error{
+		c.checkProjects,
+		c.checkApis,
+		c.checkVersions,
+		c.checkSpecs,
+		c.checkDeployments,
+		c.checkArtifacts,
+		c.checkTags,
+		c.checkBlobs,
+		c.checkMissingContents,
+		c.checkReferences,
+	} {
+		if err := check(ctx); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Repairs are made after all records are read, so that records aren't changed while they are being listed.
+	if repair {
+		for i, fix := range c.fixes {
+			if fix == nil {
+				continue
+			}
+			if err := fix(); err != nil {
+				return c.problems, status.Error(codes.Internal, err.Error())
+			}
+			c.problems[i].Repaired = true
+		}
+	}
+	return c.problems, nil
+}
+
+// report records a problem that can be repaired with fix, or that can't be repaired if fix is nil.
+func (c *consistencyChecker) report(kind, entity, key, description string, fix func() error) error {
+	c.problems = append(c.problems, ConsistencyProblem{Kind: kind, Entity: entity, Key: key, Description: description})
+	c.fixes = append(c.fixes, fix)
+	return nil
+}
+
+// orphan reports a record whose parent doesn't exist.
+func (c *consistencyChecker) orphan(ctx context.Context, entity, key, parent string) error {
+	return c.report(ProblemOrphan, entity, key, fmt.Sprintf("parent %q does not exist", parent), c.deleter(ctx, entity, key))
+}
+
+func (c *consistencyChecker) deleter(ctx context.Context, entity, key string) func() error {
+	return func() error {
+		return c.d.Delete(ctx, c.d.NewQuery(entity).Require("key", key))
+	}
+}
+
+func (c *consistencyChecker) putter(ctx context.Context, entity, key string, v interface{}) func() error {
+	return func() error {
+		_, err := c.d.Put(ctx, c.d.NewKey(entity, key), v)
+		return err
+	}
+}
+
+// each calls fn with the stored records of entity in the checked projects. If columns are
+// specified, only those columns are read.
+func (c *consistencyChecker) each(ctx context.Context, entity string, newValue func() interface{}, fn func(interface{}) error, columns ...string) error {
+	return c.d.eachRecord(ctx, c.d.projectQuery(entity, c.projectID).Select(columns...), newValue, fn)
+}
+
+func (c *consistencyChecker) checkProjects(ctx context.Context) error {
+	return c.each(ctx, gorm.ProjectEntityName, func() interface{} { return new(models.Project) }, func(v interface{}) error {
+		c.resources[v.(*models.Project).Name()] = true
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkApis(ctx context.Context) error {
+	err := c.each(ctx, gorm.ApiEntityName, func() interface{} { return new(models.Api) }, func(v interface{}) error {
+		api := *v.(*models.Api)
+		if parent := (names.Project{ProjectID: api.ProjectID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.ApiEntityName, api.Key, parent)
+		}
+		c.resources[api.Name()] = true
+		c.apis = append(c.apis, &api)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.each(ctx, gorm.ApiRevisionEntityName, func() interface{} { return new(models.ApiRevision) }, func(v interface{}) error {
+		r := v.(*models.ApiRevision)
+		if parent := (names.Api{ProjectID: r.ProjectID, ApiID: r.ApiID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.ApiRevisionEntityName, r.Key, parent)
+		}
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkVersions(ctx context.Context) error {
+	err := c.each(ctx, gorm.VersionEntityName, func() interface{} { return new(models.Version) }, func(v interface{}) error {
+		version := v.(*models.Version)
+		if parent := (names.Api{ProjectID: version.ProjectID, ApiID: version.ApiID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.VersionEntityName, version.Key, parent)
+		}
+		c.resources[version.Name()] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.each(ctx, gorm.VersionRevisionEntityName, func() interface{} { return new(models.VersionRevision) }, func(v interface{}) error {
+		r := v.(*models.VersionRevision)
+		if parent := (names.Version{ProjectID: r.ProjectID, ApiID: r.ApiID, VersionID: r.VersionID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.VersionRevisionEntityName, r.Key, parent)
+		}
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkSpecs(ctx context.Context) error {
+	return c.each(ctx, gorm.SpecEntityName, func() interface{} { return new(models.Spec) }, func(v interface{}) error {
+		spec := *v.(*models.Spec)
+		if parent := (names.Version{ProjectID: spec.ProjectID, ApiID: spec.ApiID, VersionID: spec.VersionID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.SpecEntityName, spec.Key, parent)
+		}
+		c.resources[spec.Name()] = true
+		c.specs[spec.Key] = &spec
+		c.specKeys = append(c.specKeys, spec.Key)
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkDeployments(ctx context.Context) error {
+	return c.each(ctx, gorm.DeploymentEntityName, func() interface{} { return new(models.Deployment) }, func(v interface{}) error {
+		deployment := *v.(*models.Deployment)
+		if parent := (names.Api{ProjectID: deployment.ProjectID, ApiID: deployment.ApiID}).String(); !c.resources[parent] {
+			return c.orphan(ctx, gorm.DeploymentEntityName, deployment.Key, parent)
+		}
+		c.resources[deployment.Name()] = true
+		c.deployments[deployment.Key] = &deployment
+		c.deploymentKeys = append(c.deploymentKeys, deployment.Key)
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkArtifacts(ctx context.Context) error {
+	return c.each(ctx, gorm.ArtifactEntityName, func() interface{} { return new(models.Artifact) }, func(v interface{}) error {
+		artifact := *v.(*models.Artifact)
+		if parent := strings.TrimSuffix(artifact.Name(), "/artifacts/"+artifact.ArtifactID); !c.resources[parent] {
+			return c.orphan(ctx, gorm.ArtifactEntityName, artifact.Key, parent)
+		}
+		c.artifacts[artifact.Key] = &artifact
+		c.artifactKeys = append(c.artifactKeys, artifact.Key)
+		return nil
+	})
+}
+
+func (c *consistencyChecker) checkTags(ctx context.Context) error {
+	dangling := func(entity, key, revision string) error {
+		return c.report(ProblemDanglingTag, entity, key, fmt.Sprintf("revision %q does not exist", revision), c.deleter(ctx, entity, key))
+	}
+
+	err := c.each(ctx, gorm.SpecRevisionTagEntityName, func() interface{} { return new(models.SpecRevisionTag) }, func(v interface{}) error {
+		t := v.(*models.SpecRevisionTag)
+		revision := names.SpecRevision{ProjectID: t.ProjectID, ApiID: t.ApiID, VersionID: t.VersionID, SpecID: t.SpecID, RevisionID: t.RevisionID}.String()
+		if c.specs[revision] == nil {
+			return dangling(gorm.SpecRevisionTagEntityName, t.Key, revision)
+		}
+		c.specTags[t.String()] = revision
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.each(ctx, gorm.DeploymentRevisionTagEntityName, func() interface{} { return new(models.DeploymentRevisionTag) }, func(v interface{}) error {
+		t := v.(*models.DeploymentRevisionTag)
+		revision := names.DeploymentRevision{ProjectID: t.ProjectID, ApiID: t.ApiID, DeploymentID: t.DeploymentID, RevisionID: t.RevisionID}.String()
+		if c.deployments[revision] == nil {
+			return dangling(gorm.DeploymentRevisionTagEntityName, t.Key, revision)
+		}
+		c.deploymentTags[t.String()] = revision
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.each(ctx, gorm.ArtifactRevisionTagEntityName, func() interface{} { return new(models.ArtifactRevisionTag) }, func(v interface{}) error {
+		t := v.(*models.ArtifactRevisionTag)
+		revision := (&models.Artifact{
+			ProjectID:    t.ProjectID,
+			ApiID:        t.ApiID,
+			VersionID:    t.VersionID,
+			SpecID:       t.SpecID,
+			DeploymentID: t.DeploymentID,
+			ArtifactID:   t.ArtifactID,
+			RevisionID:   t.RevisionID,
+		}).RevisionName()
+		if c.artifacts[revision] == nil {
+			return dangling(gorm.ArtifactRevisionTagEntityName, t.Key, revision)
+		}
+		return nil
+	})
+}
+
+// checkBlobs checks that every blob belongs to a spec or artifact revision with a matching hash.
+// Only the columns needed for the check are read; blobs are read again in full to repair them.
+func (c *consistencyChecker) checkBlobs(ctx context.Context) error {
+	return c.each(ctx, gorm.BlobEntityName, func() interface{} { return new(models.Blob) }, func(v interface{}) error {
+		blob := v.(*models.Blob)
+
+		var (
+			entity, mimeType string
+			hash             *string
+			size             *int32
+		)
+		if spec := c.specs[blob.Key]; blob.ArtifactID == "" && spec != nil {
+			entity, mimeType, hash, size = gorm.SpecEntityName, spec.MimeType, &spec.Hash, &spec.SizeInBytes
+		} else if artifact := c.artifacts[blob.Key]; blob.ArtifactID != "" && artifact != nil {
+			entity, mimeType, hash, size = gorm.ArtifactEntityName, artifact.MimeType, &artifact.Hash, &artifact.SizeInBytes
+		} else {
+			return c.orphan(ctx, gorm.BlobEntityName, blob.Key, blob.Key)
+		}
+		c.contents[blob.Key] = true
+
+		gotSize, got, err := blob.ContentsHash(mimeType)
+		if err != nil {
+			return c.report(ProblemHashMismatch, gorm.BlobEntityName, blob.Key, fmt.Sprintf("contents can't be read as %q: %s", mimeType, err), nil)
+		}
+		if got == *hash && got == blob.Hash {
+			return nil
+		}
+
+		description := fmt.Sprintf("contents have hash %q, but the %s record has hash %q and the blob record has hash %q", got, strings.ToLower(entity), *hash, blob.Hash)
+		key := blob.Key
+		return c.report(ProblemHashMismatch, entity, key, description, func() error {
+			*hash, *size = got, gotSize
+			var owner interface{} = c.specs[key]
+			if entity == gorm.ArtifactEntityName {
+				owner = c.artifacts[key]
+			}
+			if err := c.putter(ctx, entity, key, owner)(); err != nil {
+				return err
+			}
+			stored := new(models.Blob)
+			if err := c.d.Get(ctx, c.d.NewKey(gorm.BlobEntityName, key), stored); err != nil {
+				return err
+			}
+			stored.Hash, stored.SizeInBytes = got, gotSize
+			return c.putter(ctx, gorm.BlobEntityName, key, stored)()
+		})
+	}, "key", "artifact_id", "hash", "contents")
+}
+
+// checkMissingContents reports revisions with hashes that have no stored contents.
+// Contents can't be recreated, so these problems aren't repaired.
+func (c *consistencyChecker) checkMissingContents(ctx context.Context) error {
+	for _, key := range c.specKeys {
+		if c.specs[key].Hash != "" && !c.contents[key] {
+			if err := c.report(ProblemMissingContents, gorm.SpecEntityName, key, "revision has a hash but no contents", nil); err != nil {
+				return err
+			}
+		}
+	}
+	for _, key := range c.artifactKeys {
+		if c.artifacts[key].Hash != "" && !c.contents[key] {
+			if err := c.report(ProblemMissingContents, gorm.ArtifactEntityName, key, "revision has a hash but no contents", nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkReferences checks the recommended versions and deployments of apis and the specs of deployments.
+func (c *consistencyChecker) checkReferences(ctx context.Context) error {
+	for _, api := range c.apis {
+		api := api
+		if api.RecommendedVersion != "" {
+			if reason, err := c.versionReference(ctx, api.ProjectID, api.RecommendedVersion); err != nil {
+				return err
+			} else if reason != "" {
+				description := fmt.Sprintf("recommended_version %q %s", api.RecommendedVersion, reason)
+				if err := c.report(ProblemBadReference, gorm.ApiEntityName, api.Key, description, func() error {
+					api.RecommendedVersion = ""
+					return c.putter(ctx, gorm.ApiEntityName, api.Key, api)()
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		if api.RecommendedDeployment != "" {
+			if reason, err := c.deploymentReference(ctx, api.ProjectID, api.RecommendedDeployment); err != nil {
+				return err
+			} else if reason != "" {
+				description := fmt.Sprintf("recommended_deployment %q %s", api.RecommendedDeployment, reason)
+				if err := c.report(ProblemBadReference, gorm.ApiEntityName, api.Key, description, func() error {
+					api.RecommendedDeployment = ""
+					return c.putter(ctx, gorm.ApiEntityName, api.Key, api)()
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, key := range c.deploymentKeys {
+		deployment := c.deployments[key]
+		if deployment.ApiSpecRevision == "" {
+			continue
+		}
+		if reason, err := c.specReference(ctx, deployment.ProjectID, deployment.ApiSpecRevision); err != nil {
+			return err
+		} else if reason != "" {
+			description := fmt.Sprintf("api_spec_revision %q %s", deployment.ApiSpecRevision, reason)
+			if err := c.report(ProblemBadReference, gorm.DeploymentEntityName, key, description, func() error {
+				deployment.ApiSpecRevision = ""
+				return c.putter(ctx, gorm.DeploymentEntityName, key, deployment)()
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// qualified returns a resource name that is relative to a project as a full resource name.
+func qualified(projectID, name string) string {
+	if strings.HasPrefix(name, "apis/") {
+		return fmt.Sprintf("projects/%s/locations/%s/%s", projectID, names.Location, name)
+	}
+	return name
+}
+
+// checked returns true if the records of a project were loaded by the checker.
+func (c *consistencyChecker) checked(projectID string) bool {
+	return c.projectID == "" || c.projectID == projectID
+}
+
+// versionReference returns the reason that a reference to a version is bad, or an empty string if it is good.
+func (c *consistencyChecker) versionReference(ctx context.Context, projectID, ref string) (string, error) {
+	name, err := names.ParseVersion(qualified(projectID, ref))
+	if err != nil {
+		return "is not a version name", nil
+	}
+	if c.checked(name.ProjectID) {
+		if !c.resources[name.String()] {
+			return "does not exist", nil
+		}
+		return "", nil
+	}
+	return c.lookup(c.d.GetVersion(ctx, name))
+}
+
+// specReference returns the reason that a reference to a spec or spec revision is bad, or an empty string if it is good.
+func (c *consistencyChecker) specReference(ctx context.Context, projectID, ref string) (string, error) {
+	ref = qualified(projectID, ref)
+	if name, err := names.ParseSpecRevision(ref); err == nil {
+		if c.checked(name.ProjectID) {
+			if c.specs[name.String()] == nil && c.specTags[name.String()] == "" {
+				return "does not exist", nil
+			}
+			return "", nil
+		}
+		return c.lookup(c.d.GetSpecRevision(ctx, name))
+	}
+	if name, err := names.ParseSpec(ref); err == nil {
+		if c.checked(name.ProjectID) {
+			if !c.resources[name.String()] {
+				return "does not exist", nil
+			}
+			return "", nil
+		}
+		return c.lookup(c.d.GetSpec(ctx, name))
+	}
+	return "is not a spec or spec revision name", nil
+}
+
+// deploymentReference returns the reason that a reference to a deployment or deployment revision is bad,
+// or an empty string if it is good.
+func (c *consistencyChecker) deploymentReference(ctx context.Context, projectID, ref string) (string, error) {
+	ref = qualified(projectID, ref)
+	if name, err := names.ParseDeploymentRevision(ref); err == nil {
+		if c.checked(name.ProjectID) {
+			if c.deployments[name.String()] == nil && c.deploymentTags[name.String()] == "" {
+				return "does not exist", nil
+			}
+			return "", nil
+		}
+		return c.lookup(c.d.GetDeploymentRevision(ctx, name))
+	}
+	if name, err := names.ParseDeployment(ref); err == nil {
+		if c.checked(name.ProjectID) {
+			if !c.resources[name.String()] {
+				return "does not exist", nil
+			}
+			return "", nil
+		}
+		return c.lookup(c.d.GetDeployment(ctx, name))
+	}
+	return "is not a deployment or deployment revision name", nil
+}
+
+// lookup converts the result of getting a referenced resource to the reason that the reference is bad.
+func (c *consistencyChecker) lookup(_ interface{}, err error) (string, error) {
+	if status.Code(err) == codes.NotFound {
+		return "does not exist", nil
+	}
+	return "", err
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"encoding/gob"
 	"fmt"
+	"sort"
 
 	"github.com/apigee/registry/server/registry/internal/storage/gorm"
 )
@@ -35,6 +36,37 @@ type PageOptions struct {
 	// If specified, listing will continue from the end of the previous page. Otherwise,
 	// the first page in a listing series will be returned.
 	Token string
+	// Fields lists the top-level resource fields to return, as given by a read mask.
+	// If unspecified, all fields are returned.
+	Fields []string
+}
+
+// optionalColumns maps resource fields to storage columns that can be skipped
+// when listing resources that don't need them.
+var optionalColumns = map[string]string{
+	"description": "description",
+	"labels":      "labels",
+	"annotations": "annotations",
+}
+
+// omittedColumns returns the storage columns that aren't needed to return the fields listed in opts.
+// Columns are never omitted from filtered listings, since filters can refer to any field.
+func omittedColumns(opts PageOptions) []string {
+	if len(opts.Fields) == 0 || opts.Filter != "" {
+		return nil
+	}
+	requested := make(map[string]bool, len(opts.Fields))
+	for _, f := range opts.Fields {
+		requested[f] = true
+	}
+	var omit []string
+	for field, column := range optionalColumns {
+		if !requested[field] {
+			omit = append(omit, column)
+		}
+	}
+	sort.Strings(omit)
+	return omit
 }
 
 type Client struct {
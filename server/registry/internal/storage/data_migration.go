@@ -0,0 +1,94 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+)
+
+// DataCheckpoint records the key of the last entity of each kind copied by CopyData.
+type DataCheckpoint map[string]string
+
+// DataCopyOptions contains custom arguments for copying data between databases.
+type DataCopyOptions struct {
+	// BatchSize is the maximum number of entities copied at a time. If unspecified, it defaults to 100.
+	BatchSize int
+	// Checkpoint holds the progress of an earlier copy that was interrupted.
+	// Copying resumes after the keys that it lists and it is updated after each batch.
+	Checkpoint DataCheckpoint
+	// OnBatch is called after each batch with the kind and number of entities copied.
+	// Copying stops if it returns an error.
+	OnBatch func(kind string, n int, checkpoint DataCheckpoint) error
+}
+
+func (o DataCopyOptions) batchSize() int {
+	if o.BatchSize <= 0 {
+		return 100
+	}
+	return o.BatchSize
+}
+
+// CopyData copies every stored entity from one database to another without changes.
+func CopyData(ctx context.Context, from, to *Client, opts DataCopyOptions) error {
+	if opts.Checkpoint == nil {
+		opts.Checkpoint = make(DataCheckpoint)
+	}
+	for _, kind := range gorm.EntityNames() {
+		for {
+			n, last, err := gorm.CopyBatch(ctx, from.Client, to.Client, kind, opts.Checkpoint[kind], opts.batchSize())
+			if err != nil {
+				return fmt.Errorf("failed to copy %s entities after %q: %s", kind, opts.Checkpoint[kind], err)
+			}
+			if n == 0 {
+				break
+			}
+			opts.Checkpoint[kind] = last
+			if opts.OnBatch != nil {
+				if err := opts.OnBatch(kind, n, opts.Checkpoint); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// DataDigest summarizes the stored entities of one kind.
+type DataDigest struct {
+	Kind   string
+	Count  int64
+	Digest string
+}
+
+// DataDigests summarizes every kind of stored entity.
+// Databases holding the same entities have the same digests.
+func (d *Client) DataDigests(ctx context.Context, batchSize int) ([]DataDigest, error) {
+	if batchSize <= 0 {
+		batchSize = DataCopyOptions{}.batchSize()
+	}
+	kinds := gorm.EntityNames()
+	digests := make([]DataDigest, len(kinds))
+	for i, kind := range kinds {
+		count, digest, err := d.Digest(ctx, kind, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s entities: %s", kind, err)
+		}
+		digests[i] = DataDigest{Kind: kind, Count: count, Digest: digest}
+	}
+	return digests, nil
+}
@@ -43,6 +43,7 @@ func (d *Client) ListDeploymentRevisions(ctx context.Context, parent names.Deplo
 
 	q = q.ApplyOffset(token.Offset)
 
+	q = q.Omit(omittedColumns(opts)...)
 	it := d.Run(ctx, q)
 	response := DeploymentList{
 		Deployments: make([]models.Deployment, 0, opts.Size),
@@ -129,6 +130,27 @@ func (d *Client) SaveDeploymentRevisionTag(ctx context.Context, tag *models.Depl
 	return nil
 }
 
+func (d *Client) GetDeploymentRevisionTag(ctx context.Context, name names.DeploymentRevision) (*models.DeploymentRevisionTag, error) {
+	tag := new(models.DeploymentRevisionTag)
+	k := d.NewKey(gorm.DeploymentRevisionTagEntityName, name.String())
+	if err := d.Get(ctx, k, tag); d.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "deployment revision tag %q not found", name)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return tag, nil
+}
+
+func (d *Client) DeleteDeploymentRevisionTag(ctx context.Context, name names.DeploymentRevision) error {
+	q := d.NewQuery(gorm.DeploymentRevisionTagEntityName).Require("key", name.String())
+	if err := d.Delete(ctx, q); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
 func (d *Client) unwrapDeploymentRevisionTag(ctx context.Context, name names.DeploymentRevision) (names.DeploymentRevision, error) {
 	tag := new(models.DeploymentRevisionTag)
 	if err := d.Get(ctx, d.NewKey(gorm.DeploymentRevisionTagEntityName, name.String()), tag); d.IsNotFound(err) {
@@ -37,6 +37,7 @@ var deploymentFields = []filtering.Field{
 	{Name: "project_id", Type: filtering.String},
 	{Name: "api_id", Type: filtering.String},
 	{Name: "deployment_id", Type: filtering.String},
+	{Name: "revision_id", Type: filtering.String},
 	{Name: "display_name", Type: filtering.String},
 	{Name: "description", Type: filtering.String},
 	{Name: "create_time", Type: filtering.Timestamp},
@@ -48,6 +49,10 @@ var deploymentFields = []filtering.Field{
 	{Name: "intended_audience", Type: filtering.String},
 	{Name: "access_guidance", Type: filtering.String},
 	{Name: "labels", Type: filtering.StringMap},
+	{Name: "annotations", Type: filtering.StringMap},
+	{Name: "revision_tags", Type: filtering.StringList},
+	projectParentField,
+	apiParentField,
 }
 
 func (d *Client) ListDeployments(ctx context.Context, parent names.Api, opts PageOptions) (DeploymentList, error) {
@@ -77,17 +82,29 @@ func (d *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 		return DeploymentList{}, err
 	}
 
-	it := d.GetRecentDeploymentRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID)
+	var tags map[string][]string
+	if filter.References("revision_tags") {
+		tags, err = d.deploymentRevisionTags(ctx, parent.Deployment("-"))
+		if err != nil {
+			return DeploymentList{}, err
+		}
+	}
+	parents := d.newParentBindings(filter)
+
+	it := d.GetRecentDeploymentRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, omittedColumns(opts)...)
 	response := DeploymentList{
 		Deployments: make([]models.Deployment, 0, opts.Size),
 	}
 
 	deployment := new(models.Deployment)
 	for _, err = it.Next(deployment); err == nil; _, err = it.Next(deployment) {
-		deploymentMap, err := deploymentMap(*deployment)
+		deploymentMap, err := deploymentMap(*deployment, tags[deployment.RevisionName()])
 		if err != nil {
 			return response, status.Error(codes.Internal, err.Error())
 		}
+		if err := parents.bind(ctx, deploymentMap, deployment.ProjectID, deployment.ApiID, ""); err != nil {
+			return response, err
+		}
 
 		match, err := filter.Matches(deploymentMap)
 		if err != nil {
@@ -116,12 +133,17 @@ func (d *Client) ListDeployments(ctx context.Context, parent names.Api, opts Pag
 	return response, nil
 }
 
-func deploymentMap(deployment models.Deployment) (map[string]interface{}, error) {
+func deploymentMap(deployment models.Deployment, tags []string) (map[string]interface{}, error) {
 	labels, err := deployment.LabelsMap()
 	if err != nil {
 		return nil, err
 	}
 
+	annotations, err := deployment.AnnotationsMap()
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		"name":                 deployment.Name(),
 		"project_id":           deployment.ProjectID,
@@ -139,6 +161,8 @@ func deploymentMap(deployment models.Deployment) (map[string]interface{}, error)
 		"intended_audience":    deployment.IntendedAudience,
 		"access_guidance":      deployment.AccessGuidance,
 		"labels":               labels,
+		"annotations":          annotations,
+		"revision_tags":        tags,
 	}, nil
 }
 
@@ -164,6 +188,7 @@ func (d *Client) DeleteDeployment(ctx context.Context, name names.Deployment) er
 		gorm.DeploymentEntityName,
 		gorm.DeploymentRevisionTagEntityName,
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 	} {
 		q := d.NewQuery(entityName)
 		q = q.Require("ProjectID", name.ProjectID)
@@ -179,8 +204,12 @@ func (d *Client) DeleteDeployment(ctx context.Context, name names.Deployment) er
 
 func (d *Client) GetDeploymentTags(ctx context.Context, name names.Deployment) ([]*models.DeploymentRevisionTag, error) {
 	q := d.NewQuery(gorm.DeploymentRevisionTagEntityName)
-	q = q.Require("ProjectID", name.ProjectID)
-	q = q.Require("ApiID", name.ApiID)
+	if name.ProjectID != "-" {
+		q = q.Require("ProjectID", name.ProjectID)
+	}
+	if name.ApiID != "-" {
+		q = q.Require("ApiID", name.ApiID)
+	}
 	if name.DeploymentID != "-" {
 		q = q.Require("DeploymentID", name.DeploymentID)
 	}
@@ -194,6 +223,7 @@ func (d *Client) GetDeploymentTags(ctx context.Context, name names.Deployment) (
 
 	for _, err = it.Next(tag); err == nil; _, err = it.Next(tag) {
 		tags = append(tags, tag)
+		tag = new(models.DeploymentRevisionTag)
 	}
 	if err != nil && err != iterator.Done {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -201,3 +231,23 @@ func (d *Client) GetDeploymentTags(ctx context.Context, name names.Deployment) (
 
 	return tags, nil
 }
+
+// deploymentRevisionTags returns the tags of matching deployment revisions, keyed by revision name.
+func (d *Client) deploymentRevisionTags(ctx context.Context, name names.Deployment) (map[string][]string, error) {
+	tags, err := d.GetDeploymentTags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revTags := make(map[string][]string, len(tags))
+	for _, tag := range tags {
+		rev := names.DeploymentRevision{
+			ProjectID:    tag.ProjectID,
+			ApiID:        tag.ApiID,
+			DeploymentID: tag.DeploymentID,
+			RevisionID:   tag.RevisionID,
+		}.String()
+		revTags[rev] = append(revTags[rev], tag.Tag)
+	}
+	return revTags, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/filtering"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Filters can refer to the fields of a resource's parents, as in `api.labels.team == "payments"`.
+var (
+	projectParentField = filtering.Field{Name: "project", Type: filtering.Resource}
+	apiParentField     = filtering.Field{Name: "api", Type: filtering.Resource}
+	versionParentField = filtering.Field{Name: "version", Type: filtering.Resource}
+)
+
+// parentBindings adds the parent resources that a filter refers to into the maps it is evaluated against.
+// Parents are loaded once per listing.
+type parentBindings struct {
+	client *Client
+	filter filtering.Filter
+	cache  map[string]map[string]interface{}
+}
+
+func (d *Client) newParentBindings(filter filtering.Filter) *parentBindings {
+	return &parentBindings{
+		client: d,
+		filter: filter,
+		cache:  make(map[string]map[string]interface{}),
+	}
+}
+
+// bind adds the parents with the given IDs to a resource map.
+// Parents with empty IDs are bound to empty maps, since the resource doesn't have them.
+func (b *parentBindings) bind(ctx context.Context, m map[string]interface{}, projectID, apiID, versionID string) error {
+	if b.filter.References(projectParentField.Name) {
+		v, err := b.project(ctx, projectID)
+		if err != nil {
+			return err
+		}
+		m[projectParentField.Name] = v
+	}
+	if b.filter.References(apiParentField.Name) {
+		v, err := b.api(ctx, projectID, apiID)
+		if err != nil {
+			return err
+		}
+		m[apiParentField.Name] = v
+	}
+	if b.filter.References(versionParentField.Name) {
+		v, err := b.version(ctx, projectID, apiID, versionID)
+		if err != nil {
+			return err
+		}
+		m[versionParentField.Name] = v
+	}
+	return nil
+}
+
+func (b *parentBindings) project(ctx context.Context, projectID string) (map[string]interface{}, error) {
+	name := names.Project{ProjectID: projectID}
+	if projectID == "" {
+		return map[string]interface{}{}, nil
+	} else if v, ok := b.cache[name.String()]; ok {
+		return v, nil
+	}
+
+	project, err := b.client.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	v := projectMap(*project)
+	b.cache[name.String()] = v
+	return v, nil
+}
+
+func (b *parentBindings) api(ctx context.Context, projectID, apiID string) (map[string]interface{}, error) {
+	name := names.Api{ProjectID: projectID, ApiID: apiID}
+	if apiID == "" {
+		return map[string]interface{}{}, nil
+	} else if v, ok := b.cache[name.String()]; ok {
+		return v, nil
+	}
+
+	api, err := b.client.GetApi(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := apiMap(*api)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	b.cache[name.String()] = v
+	return v, nil
+}
+
+func (b *parentBindings) version(ctx context.Context, projectID, apiID, versionID string) (map[string]interface{}, error) {
+	name := names.Version{ProjectID: projectID, ApiID: apiID, VersionID: versionID}
+	if versionID == "" {
+		return map[string]interface{}{}, nil
+	} else if v, ok := b.cache[name.String()]; ok {
+		return v, nil
+	}
+
+	version, err := b.client.GetVersion(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := versionMap(*version)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	b.cache[name.String()] = v
+	return v, nil
+}
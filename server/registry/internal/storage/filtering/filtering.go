@@ -31,6 +31,10 @@ const (
 	Int       FieldType = iota
 	Timestamp FieldType = iota
 	StringMap FieldType = iota
+	// StringList is a list of strings, such as the tags of a revision.
+	StringList FieldType = iota
+	// Resource is a map of field names to values, such as the fields of a parent resource.
+	Resource FieldType = iota
 )
 
 type Field struct {
@@ -39,7 +43,14 @@ type Field struct {
 }
 
 type Filter struct {
-	program cel.Program
+	program    cel.Program
+	references map[string]bool
+}
+
+// References returns true if the filter expression refers to the named field.
+// It can be used to avoid loading values that a filter doesn't use.
+func (f *Filter) References(name string) bool {
+	return f.references[name]
 }
 
 func (f *Filter) Matches(model map[string]interface{}) (bool, error) {
@@ -76,12 +87,16 @@ func NewFilter(filter string, fields []Field) (Filter, error) {
 			declarations = append(declarations, decls.NewConst(field.Name, decls.Timestamp, nil))
 		case StringMap:
 			declarations = append(declarations, decls.NewConst(field.Name, decls.NewMapType(decls.String, decls.String), nil))
+		case StringList:
+			declarations = append(declarations, decls.NewConst(field.Name, decls.NewListType(decls.String), nil))
+		case Resource:
+			declarations = append(declarations, decls.NewConst(field.Name, decls.NewMapType(decls.String, decls.Dyn), nil))
 		default:
 			return Filter{}, status.Errorf(codes.InvalidArgument, "unknown filter argument type")
 		}
 	}
 
-	env, err := cel.NewEnv(cel.Container("filter"), cel.Declarations(declarations...), ext.Strings())
+	env, err := cel.NewEnv(cel.Container("filter"), cel.Declarations(declarations...), cel.Declarations(semverDeclarations...), ext.Strings())
 	if err != nil {
 		return Filter{}, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -91,10 +106,22 @@ func NewFilter(filter string, fields []Field) (Filter, error) {
 		return Filter{}, status.Error(codes.InvalidArgument, iss.Err().Error())
 	}
 
-	prg, err := env.Program(ast)
+	prg, err := env.Program(ast, cel.Functions(semverOverloads...))
 	if err != nil {
 		return Filter{}, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	return Filter{program: prg}, nil
+	checked, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return Filter{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	references := make(map[string]bool)
+	for _, ref := range checked.GetReferenceMap() {
+		if name := ref.GetName(); name != "" {
+			references[name] = true
+		}
+	}
+
+	return Filter{program: prg, references: references}, nil
 }
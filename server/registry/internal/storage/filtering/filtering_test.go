@@ -191,6 +191,90 @@ func TestFilter_Matches(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "in StringList",
+			filter: `"match" in tags`,
+			fields: []Field{
+				{
+					Name: "tags",
+					Type: StringList,
+				},
+			},
+			positive: map[string]interface{}{
+				"tags": []string{"match"},
+			},
+			negative: map[string]interface{}{
+				"tags": []string(nil),
+			},
+		},
+		{
+			desc:   "equal to Resource field",
+			filter: `has(api.labels.k) && api.labels.k == "match"`,
+			fields: []Field{
+				{
+					Name: "api",
+					Type: Resource,
+				},
+			},
+			positive: map[string]interface{}{
+				"api": map[string]interface{}{
+					"labels": map[string]string{"k": "match"},
+				},
+			},
+			negative: map[string]interface{}{
+				"api": map[string]interface{}{
+					"labels": map[string]string{},
+				},
+			},
+		},
+		{
+			desc:   "matches String",
+			filter: `k.matches("^ma.ch$")`,
+			fields: []Field{
+				{
+					Name: "k",
+					Type: String,
+				},
+			},
+			positive: map[string]interface{}{
+				"k": "match",
+			},
+			negative: map[string]interface{}{
+				"k": "mismatch",
+			},
+		},
+		{
+			desc:   "semver_compare String",
+			filter: `semver_compare(k, "v1.10.0") >= 0`,
+			fields: []Field{
+				{
+					Name: "k",
+					Type: String,
+				},
+			},
+			positive: map[string]interface{}{
+				"k": "v1.10",
+			},
+			negative: map[string]interface{}{
+				"k": "1.9.3",
+			},
+		},
+		{
+			desc:   "is_semver String",
+			filter: `is_semver(k)`,
+			fields: []Field{
+				{
+					Name: "k",
+					Type: String,
+				},
+			},
+			positive: map[string]interface{}{
+				"k": "v2.0.0-alpha.1+build",
+			},
+			negative: map[string]interface{}{
+				"k": "latest",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -214,3 +298,60 @@ func TestFilter_Matches(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_References(t *testing.T) {
+	f, err := NewFilter(`api.labels.k == "v" && size(tags) > 0`, []Field{
+		{Name: "api", Type: Resource},
+		{Name: "tags", Type: StringList},
+		{Name: "version", Type: Resource},
+	})
+	if err != nil {
+		t.Fatalf("NewFilter() returned error: %s", err)
+	}
+
+	for name, want := range map[string]bool{"api": true, "tags": true, "version": false} {
+		if got := f.References(name); got != want {
+			t.Errorf("References(%q) returned %t, want %t", name, got, want)
+		}
+	}
+}
+
+func TestSemverCompare(t *testing.T) {
+	// Versions in increasing order of precedence, as in https://semver.org/#spec-item-11.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"v1",
+		"1.0.1",
+		"1.2",
+		"1.10.0",
+		"2.0.0",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := parseSemver(ordered[i])
+			if err != nil {
+				t.Fatalf("parseSemver(%q) returned error: %s", ordered[i], err)
+			}
+			b, err := parseSemver(ordered[j])
+			if err != nil {
+				t.Fatalf("parseSemver(%q) returned error: %s", ordered[j], err)
+			}
+			if got, want := a.compare(b), compareInts(i, j); got != want {
+				t.Errorf("compare(%q, %q) returned %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	for _, s := range []string{"", "v", "1.2.3.4", "01.2.3", "1.x", "1.0.0-", "1.0.0-a..b"} {
+		if _, err := parseSemver(s); err == nil {
+			t.Errorf("parseSemver(%q) returned no error, want error", s)
+		}
+	}
+}
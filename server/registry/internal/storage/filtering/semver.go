@@ -0,0 +1,169 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// semverDeclarations declares the semantic versioning functions available to filters.
+var semverDeclarations = []*exprpb.Decl{
+	decls.NewFunction("semver_compare",
+		decls.NewOverload("semver_compare_string_string", []*exprpb.Type{decls.String, decls.String}, decls.Int)),
+	decls.NewFunction("is_semver",
+		decls.NewOverload("is_semver_string", []*exprpb.Type{decls.String}, decls.Bool)),
+}
+
+// semverOverloads implements the semantic versioning functions available to filters.
+var semverOverloads = []*functions.Overload{
+	{
+		Operator: "semver_compare",
+		Binary: func(lhs, rhs ref.Val) ref.Val {
+			a, ok := lhs.(types.String)
+			if !ok {
+				return types.MaybeNoSuchOverloadErr(lhs)
+			}
+			b, ok := rhs.(types.String)
+			if !ok {
+				return types.MaybeNoSuchOverloadErr(rhs)
+			}
+			va, err := parseSemver(string(a))
+			if err != nil {
+				return types.NewErr("semver_compare: %s", err)
+			}
+			vb, err := parseSemver(string(b))
+			if err != nil {
+				return types.NewErr("semver_compare: %s", err)
+			}
+			return types.Int(va.compare(vb))
+		},
+	},
+	{
+		Operator: "is_semver",
+		Unary: func(v ref.Val) ref.Val {
+			s, ok := v.(types.String)
+			if !ok {
+				return types.MaybeNoSuchOverloadErr(v)
+			}
+			_, err := parseSemver(string(s))
+			return types.Bool(err == nil)
+		},
+	},
+}
+
+// semver is a parsed semantic version, as described at https://semver.org.
+// Build metadata is ignored because it doesn't affect precedence.
+type semver struct {
+	major, minor, patch int
+	prerelease          []string
+}
+
+// parseSemver parses a semantic version. A leading "v" is allowed and
+// missing minor and patch numbers are treated as zero, so that common
+// version IDs like "v1" and "v1.2" can be compared.
+func parseSemver(s string) (semver, error) {
+	var v semver
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.Index(rest, "+"); i >= 0 {
+		rest = rest[:i]
+	}
+	if i := strings.Index(rest, "-"); i >= 0 {
+		v.prerelease = strings.Split(rest[i+1:], ".")
+		rest = rest[:i]
+		for _, id := range v.prerelease {
+			if id == "" {
+				return semver{}, fmt.Errorf("invalid semantic version %q", s)
+			}
+		}
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 {
+		return semver{}, fmt.Errorf("invalid semantic version %q", s)
+	}
+	numbers := []*int{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (len(part) > 1 && part[0] == '0') {
+			return semver{}, fmt.Errorf("invalid semantic version %q", s)
+		}
+		*numbers[i] = n
+	}
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 if v has lower, equal or higher precedence than other.
+func (v semver) compare(other semver) int {
+	if c := compareInts(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := compareInts(v.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := compareInts(v.patch, other.patch); c != 0 {
+		return c
+	}
+
+	// A version without a prerelease has higher precedence than one with a prerelease.
+	switch {
+	case len(v.prerelease) == 0 && len(other.prerelease) == 0:
+		return 0
+	case len(v.prerelease) == 0:
+		return 1
+	case len(other.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.prerelease) && i < len(other.prerelease); i++ {
+		a, b := v.prerelease[i], other.prerelease[i]
+		na, aErr := strconv.Atoi(a)
+		nb, bErr := strconv.Atoi(b)
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(na, nb)
+		case aErr == nil:
+			c = -1 // Numeric identifiers have lower precedence.
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(a, b)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v.prerelease), len(other.prerelease))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
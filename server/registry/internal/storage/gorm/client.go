@@ -16,14 +16,18 @@ package gorm
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 
 	_ "github.com/GoogleCloudPlatform/cloudsql-proxy/proxy/dialers/postgres"
+	"github.com/apigee/registry/server/metrics"
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // A complete list of entities used by the storage system
@@ -31,13 +35,17 @@ import (
 var entities = []interface{}{
 	&models.Project{},
 	&models.Api{},
+	&models.ApiRevision{},
 	&models.Version{},
+	&models.VersionRevision{},
 	&models.Spec{},
 	&models.SpecRevisionTag{},
 	&models.Deployment{},
 	&models.DeploymentRevisionTag{},
 	&models.Artifact{},
+	&models.ArtifactRevisionTag{},
 	&models.Blob{},
+	&models.RequestRecord{},
 }
 
 // Client represents a connection to a storage provider.
@@ -78,6 +86,12 @@ func NewClient(ctx context.Context, driver, dsn string) (*Client, error) {
 			unlock()
 			return nil, err
 		}
+		if err := registerCallbacks(db); err != nil {
+			c := &Client{db: db}
+			c.close()
+			unlock()
+			return nil, err
+		}
 		unlock()
 		// empirically, it does not seem safe to disable the mutex for sqlite3,
 		// which might make sense since sqlite database access is in-process.
@@ -96,6 +110,12 @@ func NewClient(ctx context.Context, driver, dsn string) (*Client, error) {
 			unlock()
 			return nil, err
 		}
+		if err := registerCallbacks(db); err != nil {
+			c := &Client{db: db}
+			c.close()
+			unlock()
+			return nil, err
+		}
 		unlock()
 		// postgres runs in a separate process and seems to have no problems
 		// with concurrent access and modifications.
@@ -140,6 +160,17 @@ func (c *Client) EnsureTables() error {
 	return nil
 }
 
+// Ping verifies that the database is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	lock()
+	defer unlock()
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
 // IsNotFound returns true if an error is due to an entity not being found.
 func (c *Client) IsNotFound(err error) bool {
 	return err == gorm.ErrRecordNotFound
@@ -149,13 +180,47 @@ func (c *Client) IsNotFound(err error) bool {
 func (c *Client) Get(ctx context.Context, k *Key, v interface{}) error {
 	lock()
 	defer unlock()
-	return c.db.Where("key = ?", k.Name).First(v).Error
+	return c.db.WithContext(ctx).Where("key = ?", k.Name).First(v).Error
 }
 
 // Put puts an entity using the storage client.
 func (c *Client) Put(ctx context.Context, k *Key, v interface{}) (*Key, error) {
 	lock()
 	defer unlock()
+	setKey(k, v)
+	if r, ok := v.(*models.Blob); ok {
+		if r.ArtifactID != "" {
+			metrics.ObserveBlobSize("artifact", len(r.Contents))
+		} else {
+			metrics.ObserveBlobSize("spec", len(r.Contents))
+		}
+	}
+	_ = c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update all fields from model: https://gorm.io/docs/update.html#Update-Selected-Fields
+		rowsAffected := tx.Model(v).Select("*").Where("key = ?", k.Name).Updates(v).RowsAffected
+		if rowsAffected == 0 {
+			tx.Create(v)
+		}
+		return nil
+	})
+	return k, nil
+}
+
+// Insert creates an entity unless one already exists with the same key.
+// It returns true if the entity was created.
+func (c *Client) Insert(ctx context.Context, k *Key, v interface{}) (bool, error) {
+	lock()
+	defer unlock()
+	setKey(k, v)
+	result := c.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(v)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// setKey sets the storage key of an entity.
+func setKey(k *Key, v interface{}) {
 	switch r := v.(type) {
 	case *models.Project:
 		r.Key = k.Name
@@ -171,27 +236,26 @@ func (c *Client) Put(ctx context.Context, k *Key, v interface{}) (*Key, error) {
 		r.Key = k.Name
 	case *models.DeploymentRevisionTag:
 		r.Key = k.Name
+	case *models.ApiRevision:
+		r.Key = k.Name
+	case *models.VersionRevision:
+		r.Key = k.Name
 	case *models.Artifact:
 		r.Key = k.Name
+	case *models.ArtifactRevisionTag:
+		r.Key = k.Name
+	case *models.RequestRecord:
+		r.Key = k.Name
 	case *models.Blob:
 		r.Key = k.Name
 	}
-	_ = c.db.Transaction(func(tx *gorm.DB) error {
-		// Update all fields from model: https://gorm.io/docs/update.html#Update-Selected-Fields
-		rowsAffected := tx.Model(v).Select("*").Where("key = ?", k.Name).Updates(v).RowsAffected
-		if rowsAffected == 0 {
-			tx.Create(v)
-		}
-		return nil
-	})
-	return k, nil
 }
 
 // Delete deletes all entities matching a query.
 func (c *Client) Delete(ctx context.Context, q *Query) error {
-	op := c.db
+	op := c.db.WithContext(ctx)
 	for _, r := range q.Requirements {
-		op = op.Where(r.Name+" = ?", r.Value)
+		op = op.Where(r.clause(), r.Value)
 	}
 	switch q.Kind {
 	case "Project":
@@ -208,14 +272,173 @@ func (c *Client) Delete(ctx context.Context, q *Query) error {
 		return op.Delete(models.Deployment{}).Error
 	case "DeploymentRevisionTag":
 		return op.Delete(models.DeploymentRevisionTag{}).Error
+	case "ApiRevision":
+		return op.Delete(models.ApiRevision{}).Error
+	case "VersionRevision":
+		return op.Delete(models.VersionRevision{}).Error
 	case "Artifact":
 		return op.Delete(models.Artifact{}).Error
+	case "ArtifactRevisionTag":
+		return op.Delete(models.ArtifactRevisionTag{}).Error
 	case "Blob":
 		return op.Delete(models.Blob{}).Error
+	case "RequestRecord":
+		return op.Delete(models.RequestRecord{}).Error
 	}
 	return nil
 }
 
+// Count returns the number of entities matching a query.
+func (c *Client) Count(ctx context.Context, q *Query) (int64, error) {
+	lock()
+	defer unlock()
+	op, err := c.aggregate(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	err = op.Count(&n).Error
+	return n, err
+}
+
+// Sum returns the sum of a numeric column over all entities matching a query.
+func (c *Client) Sum(ctx context.Context, q *Query, column string) (int64, error) {
+	lock()
+	defer unlock()
+	op, err := c.aggregate(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	err = op.Select("COALESCE(SUM(" + column + "), 0)").Row().Scan(&total)
+	return total, err
+}
+
+// Group describes the entities that have the same values for a set of grouped columns.
+type Group struct {
+	Values []string
+	Count  int64
+	Sum    int64
+}
+
+// revisionKeys lists the columns that identify the resources of revisioned entities.
+var revisionKeys = map[string][]string{
+	"Spec":       {"project_id", "api_id", "version_id", "spec_id"},
+	"Deployment": {"project_id", "api_id", "deployment_id"},
+	"Artifact":   {"project_id", "api_id", "version_id", "spec_id", "deployment_id", "artifact_id"},
+}
+
+// GroupCount counts the entities matching a query, grouped by the values of the specified columns.
+// If sum is not empty, the values of that column are also totaled in each group.
+// Only the most recent revisions of revisioned entities are counted.
+func (c *Client) GroupCount(ctx context.Context, q *Query, columns []string, sum string) ([]Group, error) {
+	lock()
+	defer unlock()
+
+	var table string
+	switch q.Kind {
+	case "Project":
+		table = "projects"
+	case "Api":
+		table = "apis"
+	case "Version":
+		table = "versions"
+	case "Spec":
+		table = "specs"
+	case "Deployment":
+		table = "deployments"
+	case "Artifact":
+		table = "artifacts"
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", q.Kind)
+	}
+
+	op := c.db.WithContext(ctx).Table(table)
+	if keys, ok := revisionKeys[q.Kind]; ok {
+		// Join the most recent revision_create_time of each resource, as in GetRecentSpecRevisions.
+		on := make([]string, 0, len(keys)+1)
+		for _, k := range keys {
+			on = append(on, fmt.Sprintf("%s.%s = grp.%s", table, k, k))
+		}
+		on = append(on, table+".revision_create_time = grp.recent_create_time")
+		group := strings.Join(keys, ", ")
+		op = op.Joins("JOIN (?) AS grp ON "+strings.Join(on, " AND "),
+			c.db.Select(group+", MAX(revision_create_time) AS recent_create_time").
+				Table(table).
+				Group(group))
+	}
+	for _, r := range q.Requirements {
+		op = op.Where(table+"."+r.clause(), r.Value)
+	}
+
+	selected := make([]string, 0, len(columns)+2)
+	for _, column := range columns {
+		selected = append(selected, table+"."+column)
+	}
+	if len(columns) > 0 {
+		op = op.Group(strings.Join(selected, ", "))
+	}
+	selected = append(selected, "COUNT(*)")
+	if sum != "" {
+		selected = append(selected, "COALESCE(SUM("+table+"."+sum+"), 0)")
+	} else {
+		selected = append(selected, "0")
+	}
+
+	rows, err := op.Select(strings.Join(selected, ", ")).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, 0, len(columns)+2)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		g := Group{Values: make([]string, len(columns))}
+		dest = append(dest, &g.Count, &g.Sum)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			g.Values[i] = v.String
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// aggregate returns a statement that selects all entities matching a query.
+func (c *Client) aggregate(ctx context.Context, q *Query) (*gorm.DB, error) {
+	var model interface{}
+	switch q.Kind {
+	case "Project":
+		model = &models.Project{}
+	case "Api":
+		model = &models.Api{}
+	case "Version":
+		model = &models.Version{}
+	case "Spec":
+		model = &models.Spec{}
+	case "Deployment":
+		model = &models.Deployment{}
+	case "Artifact":
+		model = &models.Artifact{}
+	case "Blob":
+		model = &models.Blob{}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", q.Kind)
+	}
+	op := c.db.WithContext(ctx).Model(model)
+	for _, r := range q.Requirements {
+		op = op.Where(r.clause(), r.Value)
+	}
+	return op, nil
+}
+
 // Run runs a query using the storage client, returning an iterator.
 func (c *Client) Run(ctx context.Context, q *Query) *Iterator {
 	lock()
@@ -226,9 +449,15 @@ func (c *Client) Run(ctx context.Context, q *Query) *Iterator {
 	// the iterator if there are no more resources to consider. Previously,
 	// the entire table would be read into memory. This limit should maintain
 	// that behavior until we improve our iterator implementation.
-	op := c.db.Offset(q.Offset).Limit(100000)
+	op := c.db.WithContext(ctx).Offset(q.Offset).Limit(100000)
 	for _, r := range q.Requirements {
-		op = op.Where(r.Name+" = ?", r.Value)
+		op = op.Where(r.clause(), r.Value)
+	}
+	if len(q.Omitted) > 0 {
+		op = op.Omit(q.Omitted...)
+	}
+	if len(q.Selected) > 0 {
+		op = op.Select(q.Selected)
 	}
 
 	if order := q.Order; order != "" {
@@ -274,18 +503,30 @@ func (c *Client) Run(ctx context.Context, q *Query) *Iterator {
 		var v []models.Artifact
 		_ = op.Find(&v).Error
 		return &Iterator{Client: c, Values: v, Index: 0}
+	case "ArtifactRevisionTag":
+		var v []models.ArtifactRevisionTag
+		_ = op.Find(&v).Error
+		return &Iterator{Client: c, Values: v, Index: 0}
+	case "ApiRevision":
+		var v []models.ApiRevision
+		_ = op.Find(&v).Error
+		return &Iterator{Client: c, Values: v, Index: 0}
+	case "VersionRevision":
+		var v []models.VersionRevision
+		_ = op.Find(&v).Error
+		return &Iterator{Client: c, Values: v, Index: 0}
 	default:
 		return nil
 	}
 }
 
-func (c *Client) GetRecentSpecRevisions(ctx context.Context, offset int32, projectID, apiID, versionID string) *Iterator {
+func (c *Client) GetRecentSpecRevisions(ctx context.Context, offset int32, projectID, apiID, versionID string, omit ...string) *Iterator {
 	lock()
 	defer unlock()
 
 	// Select all columns from `specs` table specifically.
 	// We do not want to select duplicates from the joined subquery result.
-	op := c.db.Select("specs.*").
+	op := c.db.WithContext(ctx).Select(c.columns(&models.Spec{}, "specs", omit)).
 		Table("specs").
 		// Join missing columns that couldn't be selected in the subquery.
 		Joins("JOIN (?) AS grp ON specs.project_id = grp.project_id AND specs.api_id = grp.api_id AND specs.version_id = grp.version_id AND specs.spec_id = grp.spec_id AND specs.revision_create_time = grp.recent_create_time",
@@ -314,13 +555,13 @@ func (c *Client) GetRecentSpecRevisions(ctx context.Context, offset int32, proje
 	return &Iterator{Client: c, Values: v, Index: 0}
 }
 
-func (c *Client) GetRecentDeploymentRevisions(ctx context.Context, offset int32, projectID, apiID string) *Iterator {
+func (c *Client) GetRecentDeploymentRevisions(ctx context.Context, offset int32, projectID, apiID string, omit ...string) *Iterator {
 	lock()
 	defer unlock()
 
 	// Select all columns from `deployments` table specifically.
 	// We do not want to select duplicates from the joined subquery result.
-	op := c.db.Select("deployments.*").
+	op := c.db.WithContext(ctx).Select(c.columns(&models.Deployment{}, "deployments", omit)).
 		Table("deployments").
 		// Join missing columns that couldn't be selected in the subquery.
 		Joins("JOIN (?) AS grp ON deployments.project_id = grp.project_id AND deployments.api_id = grp.api_id AND deployments.deployment_id = grp.deployment_id AND deployments.revision_create_time = grp.recent_create_time",
@@ -346,6 +587,68 @@ func (c *Client) GetRecentDeploymentRevisions(ctx context.Context, offset int32,
 	return &Iterator{Client: c, Values: v, Index: 0}
 }
 
+func (c *Client) GetRecentArtifactRevisions(ctx context.Context, offset int32, projectID, apiID, versionID, specID string, omit ...string) *Iterator {
+	lock()
+	defer unlock()
+
+	// Select all columns from `artifacts` table specifically.
+	// We do not want to select duplicates from the joined subquery result.
+	op := c.db.WithContext(ctx).Select(c.columns(&models.Artifact{}, "artifacts", omit)).
+		Table("artifacts").
+		// Join missing columns that couldn't be selected in the subquery.
+		Joins("JOIN (?) AS grp ON artifacts.project_id = grp.project_id AND artifacts.api_id = grp.api_id AND artifacts.version_id = grp.version_id AND artifacts.spec_id = grp.spec_id AND artifacts.deployment_id = grp.deployment_id AND artifacts.artifact_id = grp.artifact_id AND artifacts.revision_create_time = grp.recent_create_time",
+			// Select artifact names and only their most recent revision_create_time
+			// This query cannot select all the columns we want.
+			// See: https://stackoverflow.com/questions/7745609/sql-select-only-rows-with-max-value-on-a-column
+			c.db.Select("project_id, api_id, version_id, spec_id, deployment_id, artifact_id, MAX(revision_create_time) AS recent_create_time").
+				Table("artifacts").
+				Group("project_id, api_id, version_id, spec_id, deployment_id, artifact_id")).
+		Order("key").
+		Offset(int(offset)).
+		Limit(100000)
+
+	// Empty IDs are significant: they restrict results to artifacts
+	// that are not owned by a resource of the corresponding type.
+	if projectID != "-" {
+		op = op.Where("artifacts.project_id = ?", projectID)
+	}
+	if apiID != "-" {
+		op = op.Where("artifacts.api_id = ?", apiID)
+	}
+	if versionID != "-" {
+		op = op.Where("artifacts.version_id = ?", versionID)
+	}
+	if specID != "-" {
+		op = op.Where("artifacts.spec_id = ?", specID)
+	}
+
+	var v []models.Artifact
+	_ = op.Scan(&v).Error
+	return &Iterator{Client: c, Values: v, Index: 0}
+}
+
+// columns returns the qualified columns of a table to select, excluding any omitted columns.
+func (c *Client) columns(model interface{}, table string, omit []string) string {
+	if len(omit) == 0 {
+		return table + ".*"
+	}
+	stmt := &gorm.Statement{DB: c.db}
+	if err := stmt.Parse(model); err != nil {
+		return table + ".*"
+	}
+	omitted := make(map[string]bool, len(omit))
+	for _, name := range omit {
+		omitted[name] = true
+	}
+	columns := make([]string, 0, len(stmt.Schema.DBNames))
+	for _, name := range stmt.Schema.DBNames {
+		if !omitted[name] {
+			columns = append(columns, table+"."+name)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
 func (c *Client) Migrate(kind string) error {
 	return c.db.AutoMigrate(entities...)
 }
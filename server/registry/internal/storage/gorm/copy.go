@@ -0,0 +1,158 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// EntityNames returns the names of all stored entities, with parents before children.
+func EntityNames() []string {
+	kinds := make([]string, len(entities))
+	for i, entity := range entities {
+		kinds[i] = reflect.TypeOf(entity).Elem().Name()
+	}
+	return kinds
+}
+
+func entityType(kind string) (reflect.Type, error) {
+	for _, entity := range entities {
+		if t := reflect.TypeOf(entity).Elem(); t.Name() == kind {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown entity %q", kind)
+}
+
+// CopyBatch copies up to limit entities of a kind from src to dst, taking the entities
+// that follow after in the key order of src. Entities that already exist in dst are overwritten,
+// so a batch that was interrupted can safely be copied again.
+// It returns the number of entities copied and the key of the last one.
+func CopyBatch(ctx context.Context, src, dst *Client, kind, after string, limit int) (int, string, error) {
+	rows, err := src.readBatch(ctx, src.NewQuery(kind), after, limit)
+	if err != nil {
+		return 0, after, err
+	}
+	n := rows.Len()
+	if n == 0 {
+		return 0, after, nil
+	}
+
+	lock()
+	err = dst.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, UpdateAll: true}).
+		Create(rows.Addr().Interface()).Error
+	unlock()
+	if err != nil {
+		return 0, after, err
+	}
+	return n, rows.Index(n - 1).FieldByName("Key").String(), nil
+}
+
+// RunBatch runs a query for up to limit entities with keys after the given key, in key order.
+// Unlike Run, it reports errors, and pages through results without offsets, so entities
+// aren't skipped or repeated when others are added or removed between batches.
+func (c *Client) RunBatch(ctx context.Context, q *Query, after string, limit int) (*Iterator, error) {
+	rows, err := c.readBatch(ctx, q, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &Iterator{Client: c, Values: rows.Interface()}, nil
+}
+
+// readBatch returns a slice of up to limit entities matching a query with keys after the given key.
+func (c *Client) readBatch(ctx context.Context, q *Query, after string, limit int) (reflect.Value, error) {
+	t, err := entityType(q.Kind)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rows := reflect.New(reflect.SliceOf(t))
+
+	lock()
+	defer unlock()
+	op := c.db.WithContext(ctx)
+	for _, r := range q.Requirements {
+		op = op.Where(r.clause(), r.Value)
+	}
+	if len(q.Omitted) > 0 {
+		op = op.Omit(q.Omitted...)
+	}
+	if len(q.Selected) > 0 {
+		op = op.Select(q.Selected)
+	}
+	err = op.Where("key > ?", after).
+		Order("key").
+		Limit(limit).
+		Find(rows.Interface()).Error
+	return rows.Elem(), err
+}
+
+// Digest returns the number of stored entities of a kind and a digest of their contents.
+// Digests don't depend on the database that holds the entities or the order of their keys,
+// so they can be used to verify that entities were copied between databases without changes.
+func (c *Client) Digest(ctx context.Context, kind string, batchSize int) (int64, string, error) {
+	var hashes [][]byte
+	for after := ""; ; {
+		rows, err := c.readBatch(ctx, c.NewQuery(kind), after, batchSize)
+		if err != nil {
+			return 0, "", err
+		}
+		if rows.Len() == 0 {
+			break
+		}
+		for i := 0; i < rows.Len(); i++ {
+			hashes = append(hashes, entityHash(rows.Index(i)))
+		}
+		after = rows.Index(rows.Len() - 1).FieldByName("Key").String()
+	}
+
+	sort.Slice(hashes, func(i, j int) bool { return bytes.Compare(hashes[i], hashes[j]) < 0 })
+	h := sha256.New()
+	for _, b := range hashes {
+		h.Write(b)
+	}
+	return int64(len(hashes)), hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// entityHash hashes every field of an entity. Times are compared in UTC at the
+// microsecond precision that all supported databases can store.
+func entityHash(v reflect.Value) []byte {
+	h := sha256.New()
+	for i := 0; i < v.NumField(); i++ {
+		var b []byte
+		switch f := v.Field(i).Interface().(type) {
+		case time.Time:
+			b = []byte(f.Round(time.Microsecond).UTC().Format(time.RFC3339Nano))
+		case []byte:
+			b = f
+		default:
+			b = []byte(fmt.Sprint(f))
+		}
+		// Prefix each field with its length so that adjacent fields can't run together.
+		_ = binary.Write(h, binary.BigEndian, int64(len(b)))
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
@@ -19,8 +19,12 @@ const (
 	ProjectEntityName = "Project"
 	// ApiEntityName is the storage entity name for API resources.
 	ApiEntityName = "Api"
+	// ApiRevisionEntityName is the storage entity name for API metadata revisions.
+	ApiRevisionEntityName = "ApiRevision"
 	// VersionEntityName is the storage entity name for API version resources.
 	VersionEntityName = "Version"
+	// VersionRevisionEntityName is the storage entity name for API version metadata revisions.
+	VersionRevisionEntityName = "VersionRevision"
 	// SpecEntityName is the storage entity name for API spec resources.
 	SpecEntityName = "Spec"
 	// SpecRevisionTagEntityName is the storage entity name for API spec revision tag resources.
@@ -31,6 +35,10 @@ const (
 	DeploymentRevisionTagEntityName = "DeploymentRevisionTag"
 	// ArtifactEntityName is the storage entity name for artifact resources.
 	ArtifactEntityName = "Artifact"
+	// ArtifactRevisionTagEntityName is the storage entity name for artifact revision tag resources.
+	ArtifactRevisionTagEntityName = "ArtifactRevisionTag"
 	// BlobEntityName is the storage entity name for blob resources.
 	BlobEntityName = "Blob"
+	// RequestRecordEntityName is the storage entity name for records of requests with request IDs.
+	RequestRecordEntityName = "RequestRecord"
 )
@@ -17,11 +17,17 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/apigee/registry/server/registry/internal/storage/models"
 	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/testing/protocmp"
 )
 
@@ -167,3 +173,108 @@ func TestLoad(t *testing.T) {
 		c.Close()
 	}
 }
+
+func TestRunBatch(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := NewClient(ctx, "sqlite3", t.TempDir()+"/testing.db")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %s", err)
+	}
+	defer c.Close()
+	if err := c.EnsureTables(); err != nil {
+		t.Fatalf("EnsureTables returned error: %s", err)
+	}
+
+	var want []string
+	for _, id := range []string{"e", "c", "a", "d", "b"} {
+		for _, project := range []string{"demo", "other"} {
+			api := &models.Api{ProjectID: project, ApiID: id, Description: "Demonstration API"}
+			if _, err := c.Put(ctx, c.NewKey(ApiEntityName, api.Name()), api); err != nil {
+				t.Fatalf("Setup: Put(%q) returned error: %s", api.Name(), err)
+			}
+		}
+		want = append(want, "projects/demo/locations/global/apis/"+id)
+	}
+	sort.Strings(want)
+
+	q := c.NewQuery(ApiEntityName).Require("ProjectID", "demo").Select("key", "api_id")
+	var got []string
+	for after, batches := "", 0; ; batches++ {
+		if batches > len(want) {
+			t.Fatalf("RunBatch(%q) didn't reach the end of the results", after)
+		}
+		it, err := c.RunBatch(ctx, q, after, 2)
+		if err != nil {
+			t.Fatalf("RunBatch(%q) returned error: %s", after, err)
+		}
+		count := 0
+		api := new(models.Api)
+		for _, err = it.Next(api); err == nil; _, err = it.Next(api) {
+			if api.Description != "" {
+				t.Errorf("RunBatch(%q) returned unselected description %q", after, api.Description)
+			}
+			got = append(got, api.Key)
+			count++
+		}
+		if count == 0 {
+			break
+		}
+		after = it.Cursor
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("RunBatch returned unexpected keys (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestQueryTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(trace.NewNoopTracerProvider()) })
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	c, err := NewClient(ctx, "sqlite3", t.TempDir()+"/testing.db")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %s", err)
+	}
+	defer c.Close()
+	if err := c.EnsureTables(); err != nil {
+		t.Fatalf("EnsureTables returned error: %s", err)
+	}
+
+	k := c.NewKey(ProjectEntityName, "projects/my-project")
+	if _, err := c.Put(ctx, k, &models.Project{ProjectID: "my-project"}); err != nil {
+		t.Fatalf("Put(%q) returned error: %s", k, err)
+	}
+	if err := c.Get(ctx, k, new(models.Project)); err != nil {
+		t.Fatalf("Get(%q) returned error: %s", k, err)
+	}
+	parent.End()
+
+	var names []string
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			continue
+		}
+		names = append(names, s.Name())
+		if table := attributeValue(s.Attributes(), "db.sql.table"); table != "projects" {
+			t.Errorf("Span %q has table %q, want %q", s.Name(), table, "projects")
+		}
+	}
+
+	want := []string{"storage.update", "storage.create", "storage.query"}
+	if !cmp.Equal(names, want) {
+		t.Errorf("Storage spans returned unexpected diff (-want +got):\n%s", cmp.Diff(want, names))
+	}
+}
+
+func attributeValue(attrs []attribute.KeyValue, key string) string {
+	for _, a := range attrs {
+		if string(a.Key) == key {
+			return a.Value.Emit()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/apigee/registry/server/metrics"
+	"github.com/apigee/registry/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const queryKey = "instrumentation:query"
+
+// query holds the state of a query between its before and after callbacks.
+type query struct {
+	start time.Time
+	span  trace.Span
+}
+
+// registerCallbacks adds callbacks that trace each query run with db and record its latency.
+func registerCallbacks(db *gorm.DB) error {
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			_, span := tracing.Tracer().Start(tx.Statement.Context, "storage."+operation,
+				trace.WithSpanKind(trace.SpanKindClient))
+			tx.InstanceSet(queryKey, &query{start: time.Now(), span: span})
+		}
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(queryKey)
+			if !ok {
+				return
+			}
+			q, ok := v.(*query)
+			if !ok {
+				return
+			}
+			metrics.ObserveStorageQuery(operation, tx.Statement.Table, time.Since(q.start))
+
+			q.span.SetAttributes(
+				attribute.String("db.system", tx.Dialector.Name()),
+				attribute.String("db.sql.table", tx.Statement.Table),
+				attribute.String("db.statement", tx.Statement.SQL.String()),
+				attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+			)
+			if err := tx.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				q.span.RecordError(err)
+				q.span.SetStatus(codes.Error, err.Error())
+			}
+			q.span.End()
+		}
+	}
+
+	type registrar interface {
+		Register(string, func(*gorm.DB)) error
+	}
+	cb := db.Callback()
+	for _, c := range []struct {
+		operation     string
+		before, after registrar
+	}{
+		{"create", cb.Create().Before("gorm:create"), cb.Create().After("gorm:create")},
+		{"query", cb.Query().Before("gorm:query"), cb.Query().After("gorm:query")},
+		{"update", cb.Update().Before("gorm:update"), cb.Update().After("gorm:update")},
+		{"delete", cb.Delete().Before("gorm:delete"), cb.Delete().After("gorm:delete")},
+		{"row", cb.Row().Before("gorm:row"), cb.Row().After("gorm:row")},
+		{"raw", cb.Raw().Before("gorm:raw"), cb.Raw().After("gorm:raw")},
+	} {
+		if err := c.before.Register("instrumentation:before_"+c.operation, before(c.operation)); err != nil {
+			return err
+		}
+		if err := c.after.Register("instrumentation:after_"+c.operation, after(c.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
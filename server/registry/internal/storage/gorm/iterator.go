@@ -102,6 +102,33 @@ func (it *Iterator) Next(v interface{}) (*Key, error) {
 			return it.Client.NewKey("Artifact", x.Key), nil
 		}
 		return nil, iterator.Done
+	case *models.ArtifactRevisionTag:
+		values := it.Values.([]models.ArtifactRevisionTag)
+		if it.Index < len(values) {
+			*x = values[it.Index]
+			it.Cursor = x.Key
+			it.Index++
+			return it.Client.NewKey("ArtifactRevisionTag", x.Key), nil
+		}
+		return nil, iterator.Done
+	case *models.ApiRevision:
+		values := it.Values.([]models.ApiRevision)
+		if it.Index < len(values) {
+			*x = values[it.Index]
+			it.Cursor = x.Key
+			it.Index++
+			return it.Client.NewKey("ApiRevision", x.Key), nil
+		}
+		return nil, iterator.Done
+	case *models.VersionRevision:
+		values := it.Values.([]models.VersionRevision)
+		if it.Index < len(values) {
+			*x = values[it.Index]
+			it.Cursor = x.Key
+			it.Index++
+			return it.Client.NewKey("VersionRevision", x.Key), nil
+		}
+		return nil, iterator.Done
 	case *models.SpecRevisionTag:
 		values := it.Values.([]models.SpecRevisionTag)
 		if it.Index < len(values) {
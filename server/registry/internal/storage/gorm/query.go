@@ -14,18 +14,32 @@
 
 package gorm
 
+import "time"
+
 // Query represents a query in a storage provider.
 type Query struct {
 	Kind         string
 	Offset       int
 	Order        string
 	Requirements []*Requirement
+	Omitted      []string
+	Selected     []string
 }
 
-// Requirement adds an equality filter to a query.
+// Requirement adds a filter to a query.
+// Unless an operator is specified, the filter is an equality filter.
 type Requirement struct {
-	Name  string
-	Value interface{}
+	Name     string
+	Value    interface{}
+	Operator string
+}
+
+// clause returns the SQL condition for a requirement.
+func (r *Requirement) clause() string {
+	if r.Operator == "" {
+		return r.Name + " = ?"
+	}
+	return r.Name + " " + r.Operator + " ?"
 }
 
 // NewQuery creates a new query.
@@ -37,23 +51,56 @@ func (c *Client) NewQuery(kind string) *Query {
 
 // Require adds a filter to a query that requires a field to have a specified value.
 func (q *Query) Require(name string, value interface{}) *Query {
+	q.Requirements = append(q.Requirements, &Requirement{Name: columnName(name), Value: value})
+	return q
+}
+
+// Exclude adds a filter to a query that requires a field to not have a specified value.
+func (q *Query) Exclude(name string, value interface{}) *Query {
+	q.Requirements = append(q.Requirements, &Requirement{Name: columnName(name), Value: value, Operator: "<>"})
+	return q
+}
+
+// columnName returns the storage column for an ID field.
+func columnName(name string) string {
 	switch name {
 	case "ProjectID":
-		name = "project_id"
+		return "project_id"
 	case "ApiID":
-		name = "api_id"
+		return "api_id"
 	case "VersionID":
-		name = "version_id"
+		return "version_id"
 	case "SpecID":
-		name = "spec_id"
+		return "spec_id"
 	case "DeploymentID":
-		name = "deployment_id"
+		return "deployment_id"
 	case "RevisionID":
-		name = "revision_id"
+		return "revision_id"
 	case "ArtifactID":
-		name = "artifact_id"
+		return "artifact_id"
 	}
-	q.Requirements = append(q.Requirements, &Requirement{Name: name, Value: value})
+	return name
+}
+
+// Before adds a filter to a query that requires a time field to be earlier than a specified value.
+func (q *Query) Before(name string, value time.Time) *Query {
+	switch name {
+	case "CreateTime":
+		name = "create_time"
+	}
+	q.Requirements = append(q.Requirements, &Requirement{Name: name, Value: value, Operator: "<"})
+	return q
+}
+
+// Omit excludes columns from the values returned by a query.
+func (q *Query) Omit(columns ...string) *Query {
+	q.Omitted = append(q.Omitted, columns...)
+	return q
+}
+
+// Select limits the values returned by a query to the specified columns.
+func (q *Query) Select(columns ...string) *Query {
+	q.Selected = append(q.Selected, columns...)
 	return q
 }
 
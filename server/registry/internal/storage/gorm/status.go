@@ -0,0 +1,112 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gorm
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// DatabaseVersion returns the version reported by the database.
+func (c *Client) DatabaseVersion(ctx context.Context) (string, error) {
+	lock()
+	defer unlock()
+	var query string
+	switch c.db.Dialector.Name() {
+	case "sqlite":
+		query = "SELECT sqlite_version()"
+	case "postgres":
+		query = "SHOW server_version"
+	default:
+		return "", fmt.Errorf("unsupported dialect %s", c.db.Dialector.Name())
+	}
+	var version string
+	err := c.db.WithContext(ctx).Raw(query).Row().Scan(&version)
+	return version, err
+}
+
+// PoolStats returns statistics about the connections of the client.
+func (c *Client) PoolStats() (sql.DBStats, error) {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
+
+// SchemaVersion returns a digest of the tables and columns of all entities,
+// and whether the database has all of them.
+func (c *Client) SchemaVersion() (string, bool, error) {
+	lock()
+	defer unlock()
+	h := sha256.New()
+	current := true
+	migrator := c.db.Migrator()
+	for _, entity := range entities {
+		stmt := &gorm.Statement{DB: c.db}
+		if err := stmt.Parse(entity); err != nil {
+			return "", false, err
+		}
+		fmt.Fprintf(h, "%s\n", stmt.Schema.Table)
+		hasTable := migrator.HasTable(entity)
+		current = current && hasTable
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			fmt.Fprintf(h, "\t%s %s\n", field.DBName, field.DataType)
+			current = current && hasTable && migrator.HasColumn(entity, field.DBName)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12], current, nil
+}
+
+// CountBy counts every entity matching a query, including all revisions of revisioned entities,
+// grouped by the values of a column. If sum is not empty, the values of that column are also
+// totaled in each group.
+func (c *Client) CountBy(ctx context.Context, q *Query, column, sum string) ([]Group, error) {
+	lock()
+	defer unlock()
+	op, err := c.aggregate(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	total := "0"
+	if sum != "" {
+		total = "COALESCE(SUM(" + sum + "), 0)"
+	}
+	rows, err := op.Select(column + ", COUNT(*), " + total).Group(column).Order(column).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		var value sql.NullString
+		g := Group{}
+		if err := rows.Scan(&value, &g.Count, &g.Sum); err != nil {
+			return nil, err
+		}
+		g.Values = []string{value.String}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
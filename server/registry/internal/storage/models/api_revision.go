@@ -0,0 +1,106 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ApiRevision is the storage-side representation of a recorded revision of an API's metadata.
+type ApiRevision struct {
+	Key                   string    `gorm:"primaryKey"`
+	ProjectID             string    // Uniquely identifies a project.
+	ApiID                 string    // Uniquely identifies an api within a project.
+	RevisionID            string    // Uniquely identifies a revision of an api.
+	DisplayName           string    // A human-friendly name.
+	Description           string    // A detailed description.
+	CreateTime            time.Time // Creation time of the api.
+	RevisionCreateTime    time.Time // Creation time of the revision.
+	Availability          string    // Availability of the API.
+	RecommendedVersion    string    // Recommended API version.
+	RecommendedDeployment string    // Recommended API deployment.
+	Labels                []byte    // Serialized labels.
+	Annotations           []byte    // Serialized annotations.
+}
+
+// NewApiRevision records the current metadata of an api as a new revision.
+func NewApiRevision(api *Api) *ApiRevision {
+	name := names.Api{ProjectID: api.ProjectID, ApiID: api.ApiID}.Normal()
+	return &ApiRevision{
+		ProjectID:             name.ProjectID,
+		ApiID:                 name.ApiID,
+		RevisionID:            newRevisionID(),
+		DisplayName:           api.DisplayName,
+		Description:           api.Description,
+		CreateTime:            api.CreateTime,
+		RevisionCreateTime:    api.UpdateTime,
+		Availability:          api.Availability,
+		RecommendedVersion:    api.RecommendedVersion,
+		RecommendedDeployment: api.RecommendedDeployment,
+		Labels:                api.Labels,
+		Annotations:           api.Annotations,
+	}
+}
+
+// Name returns the resource name of the api revision.
+func (r *ApiRevision) Name() string {
+	return fmt.Sprintf("%s@%s", r.api().Name(), r.RevisionID)
+}
+
+// Message returns a message representing an api revision.
+// The update time of the message is the time the revision was recorded.
+func (r *ApiRevision) Message() (*rpc.Api, error) {
+	message, err := r.api().Message()
+	if err != nil {
+		return nil, err
+	}
+
+	message.Name = r.Name()
+	message.UpdateTime = timestamppb.New(r.RevisionCreateTime)
+	return message, nil
+}
+
+func (r *ApiRevision) api() *Api {
+	return &Api{
+		ProjectID:             r.ProjectID,
+		ApiID:                 r.ApiID,
+		DisplayName:           r.DisplayName,
+		Description:           r.Description,
+		CreateTime:            r.CreateTime,
+		UpdateTime:            r.RevisionCreateTime,
+		Availability:          r.Availability,
+		RecommendedVersion:    r.RecommendedVersion,
+		RecommendedDeployment: r.RecommendedDeployment,
+		Labels:                r.Labels,
+		Annotations:           r.Annotations,
+	}
+}
+
+// MetadataEqual reports whether two apis have the same metadata, ignoring timestamps.
+func (api *Api) MetadataEqual(other *Api) bool {
+	return api.DisplayName == other.DisplayName &&
+		api.Description == other.Description &&
+		api.Availability == other.Availability &&
+		api.RecommendedVersion == other.RecommendedVersion &&
+		api.RecommendedDeployment == other.RecommendedDeployment &&
+		bytes.Equal(api.Labels, other.Labels) &&
+		bytes.Equal(api.Annotations, other.Annotations)
+}
@@ -28,33 +28,37 @@ import (
 
 // Artifact is the storage-side representation of an artifact.
 type Artifact struct {
-	Key          string    `gorm:"primaryKey"`
-	ProjectID    string    // Project associated with artifact (required).
-	ApiID        string    // Api associated with artifact (if appropriate).
-	VersionID    string    // Version associated with artifact (if appropriate).
-	SpecID       string    // Spec associated with artifact (if appropriate).
-	DeploymentID string    // Deployment associated with artifact (if appropriate).
-	ArtifactID   string    // Artifact identifier (required).
-	CreateTime   time.Time // Creation time.
-	UpdateTime   time.Time // Time of last change.
-	MimeType     string    // MIME type of artifact
-	SizeInBytes  int32     // Size of the spec.
-	Hash         string    // A hash of the spec.
+	Key                string    `gorm:"primaryKey"`
+	ProjectID          string    // Project associated with artifact (required).
+	ApiID              string    // Api associated with artifact (if appropriate).
+	VersionID          string    // Version associated with artifact (if appropriate).
+	SpecID             string    // Spec associated with artifact (if appropriate).
+	DeploymentID       string    // Deployment associated with artifact (if appropriate).
+	ArtifactID         string    // Artifact identifier (required).
+	RevisionID         string    // Uniquely identifies a revision of an artifact.
+	CreateTime         time.Time // Creation time.
+	RevisionCreateTime time.Time // Revision creation time.
+	UpdateTime         time.Time // Time of last change.
+	MimeType           string    // MIME type of artifact
+	SizeInBytes        int32     // Size of the spec.
+	Hash               string    // A hash of the spec.
 }
 
 // NewArtifact initializes a new resource.
 func NewArtifact(name names.Artifact, body *rpc.Artifact) (artifact *Artifact, err error) {
 	now := time.Now().Round(time.Microsecond)
 	artifact = &Artifact{
-		ProjectID:    name.ProjectID(),
-		ApiID:        name.ApiID(),
-		VersionID:    name.VersionID(),
-		SpecID:       name.SpecID(),
-		DeploymentID: name.DeploymentID(),
-		ArtifactID:   name.ArtifactID(),
-		CreateTime:   now,
-		UpdateTime:   now,
-		MimeType:     body.GetMimeType(),
+		ProjectID:          name.ProjectID(),
+		ApiID:              name.ApiID(),
+		VersionID:          name.VersionID(),
+		SpecID:             name.SpecID(),
+		DeploymentID:       name.DeploymentID(),
+		ArtifactID:         name.ArtifactID(),
+		RevisionID:         newRevisionID(),
+		CreateTime:         now,
+		RevisionCreateTime: now,
+		UpdateTime:         now,
+		MimeType:           body.GetMimeType(),
 	}
 
 	if body.GetContents() != nil {
@@ -73,6 +77,26 @@ func NewArtifact(name names.Artifact, body *rpc.Artifact) (artifact *Artifact, e
 	return artifact, nil
 }
 
+// NewRevision returns a new revision based on the artifact.
+func (artifact *Artifact) NewRevision() *Artifact {
+	now := time.Now().Round(time.Microsecond)
+	return &Artifact{
+		ProjectID:          artifact.ProjectID,
+		ApiID:              artifact.ApiID,
+		VersionID:          artifact.VersionID,
+		SpecID:             artifact.SpecID,
+		DeploymentID:       artifact.DeploymentID,
+		ArtifactID:         artifact.ArtifactID,
+		RevisionID:         newRevisionID(),
+		CreateTime:         artifact.CreateTime,
+		RevisionCreateTime: now,
+		UpdateTime:         now,
+		MimeType:           artifact.MimeType,
+		SizeInBytes:        artifact.SizeInBytes,
+		Hash:               artifact.Hash,
+	}
+}
+
 // Name returns the resource name of the artifact.
 func (artifact *Artifact) Name() string {
 	switch {
@@ -96,14 +120,72 @@ func (artifact *Artifact) Name() string {
 	}
 }
 
+// RevisionName generates the resource name of the artifact revision.
+func (artifact *Artifact) RevisionName() string {
+	return fmt.Sprintf("%s@%s", artifact.Name(), artifact.RevisionID)
+}
+
 // Message returns an RPC message representing the artifact.
 func (artifact *Artifact) Message() *rpc.Artifact {
+	return artifact.BasicMessage(artifact.Name(), nil)
+}
+
+// BasicMessage returns an RPC message representing the artifact with the provided name and revision tags.
+func (artifact *Artifact) BasicMessage(name string, tags []string) *rpc.Artifact {
 	return &rpc.Artifact{
-		Name:       artifact.Name(),
-		MimeType:   artifact.MimeType,
-		SizeBytes:  artifact.SizeInBytes,
-		Hash:       artifact.Hash,
-		CreateTime: timestamppb.New(artifact.CreateTime),
-		UpdateTime: timestamppb.New(artifact.UpdateTime),
+		Name:               name,
+		MimeType:           artifact.MimeType,
+		SizeBytes:          artifact.SizeInBytes,
+		Hash:               artifact.Hash,
+		RevisionId:         artifact.RevisionID,
+		RevisionTags:       tags,
+		CreateTime:         timestamppb.New(artifact.CreateTime),
+		RevisionCreateTime: timestamppb.New(artifact.RevisionCreateTime),
+		UpdateTime:         timestamppb.New(artifact.UpdateTime),
 	}
 }
+
+// ArtifactRevisionTag is the storage-side representation of an artifact revision tag.
+type ArtifactRevisionTag struct {
+	Key          string    `gorm:"primaryKey"`
+	ProjectID    string    // Project associated with artifact (required).
+	ApiID        string    // Api associated with artifact (if appropriate).
+	VersionID    string    // Version associated with artifact (if appropriate).
+	SpecID       string    // Spec associated with artifact (if appropriate).
+	DeploymentID string    // Deployment associated with artifact (if appropriate).
+	ArtifactID   string    // Artifact identifier (required).
+	RevisionID   string    // Uniquely identifies a revision of an artifact.
+	Tag          string    // The tag to use for the revision.
+	CreateTime   time.Time // Creation time.
+	UpdateTime   time.Time // Time of last change.
+}
+
+// NewArtifactRevisionTag initializes a new revision tag from a given revision name and tag string.
+func NewArtifactRevisionTag(name names.ArtifactRevision, tag string) *ArtifactRevisionTag {
+	now := time.Now().Round(time.Microsecond)
+	artifact := name.Artifact().Normal()
+	return &ArtifactRevisionTag{
+		ProjectID:    artifact.ProjectID(),
+		ApiID:        artifact.ApiID(),
+		VersionID:    artifact.VersionID(),
+		SpecID:       artifact.SpecID(),
+		DeploymentID: artifact.DeploymentID(),
+		ArtifactID:   artifact.ArtifactID(),
+		RevisionID:   name.RevisionID,
+		Tag:          tag,
+		CreateTime:   now,
+		UpdateTime:   now,
+	}
+}
+
+func (t *ArtifactRevisionTag) String() string {
+	return (&Artifact{
+		ProjectID:    t.ProjectID,
+		ApiID:        t.ApiID,
+		VersionID:    t.VersionID,
+		SpecID:       t.SpecID,
+		DeploymentID: t.DeploymentID,
+		ArtifactID:   t.ArtifactID,
+		RevisionID:   t.Tag,
+	}).RevisionName()
+}
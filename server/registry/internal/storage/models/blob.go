@@ -14,7 +14,10 @@
 
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Blob is the storage-side representation of a blob.
 type Blob struct {
@@ -23,7 +26,7 @@ type Blob struct {
 	ApiID       string    // Uniquely identifies an api within a project.
 	VersionID   string    // Uniquely identifies a version within a api.
 	SpecID      string    // Uniquely identifies a spec within a version.
-	RevisionID  string    // Uniquely identifies a revision of a spec.
+	RevisionID  string    // Uniquely identifies a revision of a spec or artifact.
 	ArtifactID  string    // Uniquely identifies an artifact on a resource.
 	Hash        string    // Hash of the blob contents.
 	SizeInBytes int32     // Size of the blob contents.
@@ -57,6 +60,7 @@ func NewBlobForArtifact(artifact *Artifact, contents []byte) *Blob {
 		ApiID:       artifact.ApiID,
 		VersionID:   artifact.VersionID,
 		SpecID:      artifact.SpecID,
+		RevisionID:  artifact.RevisionID,
 		ArtifactID:  artifact.ArtifactID,
 		Hash:        artifact.Hash,
 		SizeInBytes: artifact.SizeInBytes,
@@ -65,3 +69,17 @@ func NewBlobForArtifact(artifact *Artifact, contents []byte) *Blob {
 		UpdateTime:  now,
 	}
 }
+
+// ContentsHash returns the size and hash of the blob's contents in the form stored with specs and artifacts,
+// which are computed after uncompressing contents with gzip MIME types.
+func (b *Blob) ContentsHash(mimeType string) (int32, string, error) {
+	contents := b.Contents
+	if strings.Contains(mimeType, "+gzip") && len(contents) > 0 {
+		var err error
+		contents, err = GUnzippedBytes(contents)
+		if err != nil {
+			return 0, "", err
+		}
+	}
+	return int32(len(contents)), hashForBytes(contents), nil
+}
@@ -198,6 +198,11 @@ func (s *Deployment) LabelsMap() (map[string]string, error) {
 	return mapForBytes(s.Labels)
 }
 
+// AnnotationsMap returns a map representation of stored annotations.
+func (s *Deployment) AnnotationsMap() (map[string]string, error) {
+	return mapForBytes(s.Annotations)
+}
+
 // DeploymentRevisionTag is the storage-side representation of a deployment revision tag.
 type DeploymentRevisionTag struct {
 	Key          string    `gorm:"primaryKey"`
@@ -206,6 +211,7 @@ type DeploymentRevisionTag struct {
 	DeploymentID string    // Uniquely identifies a deployment within an api.
 	RevisionID   string    // Uniquely identifies a revision of a deployment.
 	Tag          string    // The tag to use for the revision.
+	Protected    bool      // If true, the tag can't be moved or deleted without force.
 	CreateTime   time.Time // Creation time.
 	UpdateTime   time.Time // Time of last change.
 }
@@ -228,3 +234,15 @@ func (t *DeploymentRevisionTag) String() string {
 	return fmt.Sprintf("projects/%s/locations/%s/apis/%s/deployments/%s@%s",
 		t.ProjectID, names.Location, t.ApiID, t.DeploymentID, t.Tag)
 }
+
+// Message returns the RPC representation of the tag.
+func (t *DeploymentRevisionTag) Message() *rpc.RevisionTag {
+	return &rpc.RevisionTag{
+		Name:       t.String(),
+		Tag:        t.Tag,
+		RevisionId: t.RevisionID,
+		Protected:  t.Protected,
+		CreateTime: timestamppb.New(t.CreateTime),
+		UpdateTime: timestamppb.New(t.UpdateTime),
+	}
+}
@@ -19,6 +19,8 @@ import (
 
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -31,18 +33,36 @@ type Project struct {
 	Description string    // A detailed description.
 	CreateTime  time.Time // Creation time.
 	UpdateTime  time.Time // Time of last change.
+
+	RetentionMaxRevisions int32         // Maximum number of revisions kept per resource.
+	RetentionMaxAge       time.Duration // Maximum age of revisions kept per resource.
+	SpecValidation        int32         // Mode of spec contents validation.
+	ApiLifecycle          []byte        // Serialized lifecycle of API availability.
+	VersionLifecycle      []byte        // Serialized lifecycle of version state.
 }
 
 // NewProject initializes a new resource.
-func NewProject(name names.Project, body *rpc.Project) *Project {
+func NewProject(name names.Project, body *rpc.Project) (*Project, error) {
 	now := time.Now().Round(time.Microsecond)
-	return &Project{
+	project := &Project{
 		ProjectID:   name.ProjectID,
 		Description: body.GetDescription(),
 		DisplayName: body.GetDisplayName(),
 		CreateTime:  now,
 		UpdateTime:  now,
+
+		SpecValidation: int32(body.GetSpecValidation()),
+	}
+	project.setRetentionPolicy(body.GetRevisionRetentionPolicy())
+
+	var err error
+	if project.ApiLifecycle, err = bytesForLifecycle(body.GetApiLifecycle()); err != nil {
+		return nil, err
 	}
+	if project.VersionLifecycle, err = bytesForLifecycle(body.GetVersionLifecycle()); err != nil {
+		return nil, err
+	}
+	return project, nil
 }
 
 // Name returns the resource name of the project.
@@ -53,18 +73,30 @@ func (p *Project) Name() string {
 }
 
 // Message returns a message representing a project.
-func (p *Project) Message() *rpc.Project {
-	return &rpc.Project{
-		Name:        p.Name(),
-		DisplayName: p.DisplayName,
-		Description: p.Description,
-		CreateTime:  timestamppb.New(p.CreateTime),
-		UpdateTime:  timestamppb.New(p.UpdateTime),
+func (p *Project) Message() (*rpc.Project, error) {
+	apiLifecycle, err := p.ApiLifecycleMessage()
+	if err != nil {
+		return nil, err
+	}
+	versionLifecycle, err := p.VersionLifecycleMessage()
+	if err != nil {
+		return nil, err
 	}
+	return &rpc.Project{
+		Name:                    p.Name(),
+		DisplayName:             p.DisplayName,
+		Description:             p.Description,
+		CreateTime:              timestamppb.New(p.CreateTime),
+		UpdateTime:              timestamppb.New(p.UpdateTime),
+		RevisionRetentionPolicy: p.RetentionPolicy(),
+		SpecValidation:          rpc.Project_SpecValidation(p.SpecValidation),
+		ApiLifecycle:            apiLifecycle,
+		VersionLifecycle:        versionLifecycle,
+	}, nil
 }
 
 // Update modifies a project using the contents of a message.
-func (p *Project) Update(message *rpc.Project, mask *fieldmaskpb.FieldMask) {
+func (p *Project) Update(message *rpc.Project, mask *fieldmaskpb.FieldMask) error {
 	p.UpdateTime = time.Now().Round(time.Microsecond)
 	for _, field := range mask.GetPaths() {
 		switch field {
@@ -72,6 +104,70 @@ func (p *Project) Update(message *rpc.Project, mask *fieldmaskpb.FieldMask) {
 			p.DisplayName = message.GetDisplayName()
 		case "description":
 			p.Description = message.GetDescription()
+		case "revision_retention_policy":
+			p.setRetentionPolicy(message.GetRevisionRetentionPolicy())
+		case "spec_validation":
+			p.SpecValidation = int32(message.GetSpecValidation())
+		case "api_lifecycle":
+			var err error
+			if p.ApiLifecycle, err = bytesForLifecycle(message.GetApiLifecycle()); err != nil {
+				return err
+			}
+		case "version_lifecycle":
+			var err error
+			if p.VersionLifecycle, err = bytesForLifecycle(message.GetVersionLifecycle()); err != nil {
+				return err
+			}
 		}
 	}
+
+	return nil
+}
+
+// RetentionPolicy returns the revision retention policy of the project, or nil if none is set.
+func (p *Project) RetentionPolicy() *rpc.RevisionRetentionPolicy {
+	if p.RetentionMaxRevisions == 0 && p.RetentionMaxAge == 0 {
+		return nil
+	}
+
+	policy := &rpc.RevisionRetentionPolicy{
+		MaxRevisions: p.RetentionMaxRevisions,
+	}
+	if p.RetentionMaxAge > 0 {
+		policy.MaxAge = durationpb.New(p.RetentionMaxAge)
+	}
+	return policy
+}
+
+func (p *Project) setRetentionPolicy(policy *rpc.RevisionRetentionPolicy) {
+	p.RetentionMaxRevisions = policy.GetMaxRevisions()
+	p.RetentionMaxAge = policy.GetMaxAge().AsDuration()
+}
+
+// ApiLifecycleMessage returns the lifecycle of API availability in the project, or nil if none is set.
+func (p *Project) ApiLifecycleMessage() (*rpc.Lifecycle, error) {
+	return lifecycleForBytes(p.ApiLifecycle)
+}
+
+// VersionLifecycleMessage returns the lifecycle of version state in the project, or nil if none is set.
+func (p *Project) VersionLifecycleMessage() (*rpc.Lifecycle, error) {
+	return lifecycleForBytes(p.VersionLifecycle)
+}
+
+func bytesForLifecycle(lifecycle *rpc.Lifecycle) ([]byte, error) {
+	if len(lifecycle.GetStates()) == 0 {
+		return nil, nil
+	}
+	return proto.Marshal(lifecycle)
+}
+
+func lifecycleForBytes(b []byte) (*rpc.Lifecycle, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	lifecycle := new(rpc.Lifecycle)
+	if err := proto.Unmarshal(b, lifecycle); err != nil {
+		return nil, err
+	}
+	return lifecycle, nil
 }
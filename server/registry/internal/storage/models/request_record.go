@@ -0,0 +1,103 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RequestRecord is the storage-side record of a request that included a request ID.
+// Records are created when a request starts, so that concurrent retries can't both be handled,
+// and the response is added when the request completes.
+type RequestRecord struct {
+	Key         string    `gorm:"primaryKey"`
+	Scope       string    // Name of the project or parent resource that the request applies to.
+	RequestID   string    // Identifies the request, as provided by the caller.
+	Method      string    // Full name of the RPC method that was called.
+	RequestHash string    // Hash of the request, excluding its request ID.
+	Response    []byte    // Serialized response, stored as a google.protobuf.Any. Empty until the request completes.
+	CreateTime  time.Time // Time the request was started.
+}
+
+// NewRequestRecord records a request that has started but not completed.
+func NewRequestRecord(scope, requestID, method, requestHash string) *RequestRecord {
+	return &RequestRecord{
+		Scope:       scope,
+		RequestID:   requestID,
+		Method:      method,
+		RequestHash: requestHash,
+		CreateTime:  time.Now().Round(time.Microsecond),
+	}
+}
+
+// RequestRecordName returns the storage name of the record of a request ID within a scope.
+func RequestRecordName(scope, requestID string) string {
+	if scope == "" {
+		return "requests/" + requestID
+	}
+	return scope + "/requests/" + requestID
+}
+
+// Name returns the storage name of the record.
+func (r *RequestRecord) Name() string {
+	return RequestRecordName(r.Scope, r.RequestID)
+}
+
+// Completed returns true if the response to the request has been recorded.
+func (r *RequestRecord) Completed() bool {
+	return len(r.Response) > 0
+}
+
+// SetResponse records the response to the request.
+func (r *RequestRecord) SetResponse(response proto.Message) error {
+	a, err := anypb.New(response)
+	if err != nil {
+		return err
+	}
+	b, err := proto.Marshal(a)
+	if err != nil {
+		return err
+	}
+	r.Response = b
+	return nil
+}
+
+// Message returns the recorded response.
+func (r *RequestRecord) Message() (proto.Message, error) {
+	a := new(anypb.Any)
+	if err := proto.Unmarshal(r.Response, a); err != nil {
+		return nil, err
+	}
+	return a.UnmarshalNew()
+}
+
+// HashRequest returns a hash that identifies the contents of a request, excluding its request ID.
+func HashRequest(request proto.Message) (string, error) {
+	request = proto.Clone(request)
+	m := request.ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName("request_id"); fd != nil {
+		m.Clear(fd)
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
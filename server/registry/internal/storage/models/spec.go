@@ -218,6 +218,11 @@ func (s *Spec) LabelsMap() (map[string]string, error) {
 	return mapForBytes(s.Labels)
 }
 
+// AnnotationsMap returns a map representation of stored annotations.
+func (s *Spec) AnnotationsMap() (map[string]string, error) {
+	return mapForBytes(s.Annotations)
+}
+
 func newRevisionID() string {
 	s := uuid.New().String()
 	return s[len(s)-8:]
@@ -243,6 +248,7 @@ type SpecRevisionTag struct {
 	SpecID     string    // Uniquely identifies a spec within a version.
 	RevisionID string    // Uniquely identifies a revision of a spec.
 	Tag        string    // The tag to use for the revision.
+	Protected  bool      // If true, the tag can't be moved or deleted without force.
 	CreateTime time.Time // Creation time.
 	UpdateTime time.Time // Time of last change.
 }
@@ -266,3 +272,15 @@ func (t *SpecRevisionTag) String() string {
 	return fmt.Sprintf("projects/%s/locations/%s/apis/%s/versions/%s/specs/%s@%s",
 		t.ProjectID, names.Location, t.ApiID, t.VersionID, t.SpecID, t.Tag)
 }
+
+// Message returns the RPC representation of the tag.
+func (t *SpecRevisionTag) Message() *rpc.RevisionTag {
+	return &rpc.RevisionTag{
+		Name:       t.String(),
+		Tag:        t.Tag,
+		RevisionId: t.RevisionID,
+		Protected:  t.Protected,
+		CreateTime: timestamppb.New(t.CreateTime),
+		UpdateTime: timestamppb.New(t.UpdateTime),
+	}
+}
@@ -129,3 +129,8 @@ func (v *Version) Update(message *rpc.ApiVersion, mask *fieldmaskpb.FieldMask) e
 func (v *Version) LabelsMap() (map[string]string, error) {
 	return mapForBytes(v.Labels)
 }
+
+// AnnotationsMap returns a map representation of stored annotations.
+func (v *Version) AnnotationsMap() (map[string]string, error) {
+	return mapForBytes(v.Annotations)
+}
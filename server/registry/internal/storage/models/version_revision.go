@@ -0,0 +1,101 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VersionRevision is the storage-side representation of a recorded revision of a version's metadata.
+type VersionRevision struct {
+	Key                string    `gorm:"primaryKey"`
+	ProjectID          string    // Uniquely identifies a project.
+	ApiID              string    // Uniquely identifies an api within a project.
+	VersionID          string    // Uniquely identifies a version within a api.
+	RevisionID         string    // Uniquely identifies a revision of a version.
+	DisplayName        string    // A human-friendly name.
+	Description        string    // A detailed description.
+	CreateTime         time.Time // Creation time of the version.
+	RevisionCreateTime time.Time // Creation time of the revision.
+	State              string    // Lifecycle stage.
+	Labels             []byte    // Serialized labels.
+	Annotations        []byte    // Serialized annotations.
+}
+
+// NewVersionRevision records the current metadata of a version as a new revision.
+func NewVersionRevision(version *Version) *VersionRevision {
+	name := names.Version{ProjectID: version.ProjectID, ApiID: version.ApiID, VersionID: version.VersionID}.Normal()
+	return &VersionRevision{
+		ProjectID:          name.ProjectID,
+		ApiID:              name.ApiID,
+		VersionID:          name.VersionID,
+		RevisionID:         newRevisionID(),
+		DisplayName:        version.DisplayName,
+		Description:        version.Description,
+		CreateTime:         version.CreateTime,
+		RevisionCreateTime: version.UpdateTime,
+		State:              version.State,
+		Labels:             version.Labels,
+		Annotations:        version.Annotations,
+	}
+}
+
+// Name returns the resource name of the version revision.
+func (r *VersionRevision) Name() string {
+	return fmt.Sprintf("%s@%s", r.version().Name(), r.RevisionID)
+}
+
+// Message returns a message representing a version revision.
+// The update time of the message is the time the revision was recorded.
+func (r *VersionRevision) Message() (*rpc.ApiVersion, error) {
+	message, err := r.version().Message()
+	if err != nil {
+		return nil, err
+	}
+
+	message.Name = r.Name()
+	message.UpdateTime = timestamppb.New(r.RevisionCreateTime)
+	return message, nil
+}
+
+func (r *VersionRevision) version() *Version {
+	return &Version{
+		ProjectID:   r.ProjectID,
+		ApiID:       r.ApiID,
+		VersionID:   r.VersionID,
+		DisplayName: r.DisplayName,
+		Description: r.Description,
+		CreateTime:  r.CreateTime,
+		UpdateTime:  r.RevisionCreateTime,
+		State:       r.State,
+		Labels:      r.Labels,
+		Annotations: r.Annotations,
+	}
+}
+
+// MetadataEqual reports whether two versions have the same metadata, ignoring timestamps.
+func (v *Version) MetadataEqual(other *Version) bool {
+	return v.DisplayName == other.DisplayName &&
+		v.Description == other.Description &&
+		v.State == other.State &&
+		bytes.Equal(v.Labels, other.Labels) &&
+		bytes.Equal(v.Annotations, other.Annotations)
+}
@@ -62,6 +62,7 @@ func (d *Client) ListProjects(ctx context.Context, opts PageOptions) (ProjectLis
 		return ProjectList{}, err
 	}
 
+	q = q.Omit(omittedColumns(opts)...)
 	it := d.Run(ctx, q)
 	response := ProjectList{
 		Projects: make([]models.Project, 0, opts.Size),
@@ -132,10 +133,13 @@ func (d *Client) DeleteProject(ctx context.Context, name names.Project) error {
 	for _, entityName := range []string{
 		gorm.ProjectEntityName,
 		gorm.ApiEntityName,
+		gorm.ApiRevisionEntityName,
 		gorm.VersionEntityName,
+		gorm.VersionRevisionEntityName,
 		gorm.SpecEntityName,
 		gorm.SpecRevisionTagEntityName,
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 		gorm.BlobEntityName,
 	} {
 		q := d.NewQuery(entityName)
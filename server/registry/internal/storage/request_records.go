@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (d *Client) GetRequestRecord(ctx context.Context, scope, requestID string) (*models.RequestRecord, error) {
+	record := new(models.RequestRecord)
+	k := d.NewKey(gorm.RequestRecordEntityName, models.RequestRecordName(scope, requestID))
+	if err := d.Get(ctx, k, record); d.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "request %q not found in database", requestID)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return record, nil
+}
+
+// CreateRequestRecord saves a record unless one already exists for the same request ID and scope.
+// It returns true if the record was saved.
+func (d *Client) CreateRequestRecord(ctx context.Context, record *models.RequestRecord) (bool, error) {
+	k := d.NewKey(gorm.RequestRecordEntityName, record.Name())
+	created, err := d.Insert(ctx, k, record)
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+
+	return created, nil
+}
+
+func (d *Client) SaveRequestRecord(ctx context.Context, record *models.RequestRecord) error {
+	k := d.NewKey(gorm.RequestRecordEntityName, record.Name())
+	if _, err := d.Put(ctx, k, record); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (d *Client) DeleteRequestRecord(ctx context.Context, record *models.RequestRecord) error {
+	q := d.NewQuery(gorm.RequestRecordEntityName).Require("key", record.Name())
+	if err := d.Delete(ctx, q); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// DeleteRequestRecordsBefore deletes the records of requests completed before a time.
+func (d *Client) DeleteRequestRecordsBefore(ctx context.Context, t time.Time) error {
+	q := d.NewQuery(gorm.RequestRecordEntityName).Before("CreateTime", t)
+	if err := d.Delete(ctx, q); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -44,6 +44,7 @@ func (d *Client) ListSpecRevisions(ctx context.Context, parent names.Spec, opts
 
 	q = q.ApplyOffset(token.Offset)
 
+	q = q.Omit(omittedColumns(opts)...)
 	it := d.Run(ctx, q)
 	response := SpecList{
 		Specs: make([]models.Spec, 0, opts.Size),
@@ -159,6 +160,27 @@ func (d *Client) SaveSpecRevisionTag(ctx context.Context, tag *models.SpecRevisi
 	return nil
 }
 
+func (d *Client) GetSpecRevisionTag(ctx context.Context, name names.SpecRevision) (*models.SpecRevisionTag, error) {
+	tag := new(models.SpecRevisionTag)
+	k := d.NewKey(gorm.SpecRevisionTagEntityName, name.String())
+	if err := d.Get(ctx, k, tag); d.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "spec revision tag %q not found", name)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return tag, nil
+}
+
+func (d *Client) DeleteSpecRevisionTag(ctx context.Context, name names.SpecRevision) error {
+	q := d.NewQuery(gorm.SpecRevisionTagEntityName).Require("key", name.String())
+	if err := d.Delete(ctx, q); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
 func (d *Client) unwrapSpecRevisionTag(ctx context.Context, name names.SpecRevision) (names.SpecRevision, error) {
 	tag := new(models.SpecRevisionTag)
 	if err := d.Get(ctx, d.NewKey(gorm.SpecRevisionTagEntityName, name.String()), tag); d.IsNotFound(err) {
@@ -38,6 +38,7 @@ var specFields = []filtering.Field{
 	{Name: "api_id", Type: filtering.String},
 	{Name: "version_id", Type: filtering.String},
 	{Name: "spec_id", Type: filtering.String},
+	{Name: "revision_id", Type: filtering.String},
 	{Name: "filename", Type: filtering.String},
 	{Name: "description", Type: filtering.String},
 	{Name: "create_time", Type: filtering.Timestamp},
@@ -45,8 +46,14 @@ var specFields = []filtering.Field{
 	{Name: "revision_update_time", Type: filtering.Timestamp},
 	{Name: "mime_type", Type: filtering.String},
 	{Name: "size_bytes", Type: filtering.Int},
+	{Name: "hash", Type: filtering.String},
 	{Name: "source_uri", Type: filtering.String},
 	{Name: "labels", Type: filtering.StringMap},
+	{Name: "annotations", Type: filtering.StringMap},
+	{Name: "revision_tags", Type: filtering.StringList},
+	projectParentField,
+	apiParentField,
+	versionParentField,
 }
 
 func (d *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageOptions) (SpecList, error) {
@@ -80,17 +87,29 @@ func (d *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 		return SpecList{}, err
 	}
 
-	it := d.GetRecentSpecRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, parent.VersionID)
+	var tags map[string][]string
+	if filter.References("revision_tags") {
+		tags, err = d.specRevisionTags(ctx, parent.Spec("-"))
+		if err != nil {
+			return SpecList{}, err
+		}
+	}
+	parents := d.newParentBindings(filter)
+
+	it := d.GetRecentSpecRevisions(ctx, token.Offset, parent.ProjectID, parent.ApiID, parent.VersionID, omittedColumns(opts)...)
 	response := SpecList{
 		Specs: make([]models.Spec, 0, opts.Size),
 	}
 
 	spec := new(models.Spec)
 	for _, err = it.Next(spec); err == nil; _, err = it.Next(spec) {
-		specMap, err := specMap(*spec)
+		specMap, err := specMap(*spec, tags[spec.RevisionName()])
 		if err != nil {
 			return response, status.Error(codes.Internal, err.Error())
 		}
+		if err := parents.bind(ctx, specMap, spec.ProjectID, spec.ApiID, spec.VersionID); err != nil {
+			return response, err
+		}
 
 		match, err := filter.Matches(specMap)
 		if err != nil {
@@ -119,12 +138,17 @@ func (d *Client) ListSpecs(ctx context.Context, parent names.Version, opts PageO
 	return response, nil
 }
 
-func specMap(spec models.Spec) (map[string]interface{}, error) {
+func specMap(spec models.Spec, tags []string) (map[string]interface{}, error) {
 	labels, err := spec.LabelsMap()
 	if err != nil {
 		return nil, err
 	}
 
+	annotations, err := spec.AnnotationsMap()
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		"name":                 spec.Name(),
 		"project_id":           spec.ProjectID,
@@ -142,6 +166,8 @@ func specMap(spec models.Spec) (map[string]interface{}, error) {
 		"hash":                 spec.Hash,
 		"source_uri":           spec.SourceURI,
 		"labels":               labels,
+		"annotations":          annotations,
+		"revision_tags":        tags,
 	}, nil
 }
 
@@ -168,6 +194,7 @@ func (d *Client) DeleteSpec(ctx context.Context, name names.Spec) error {
 		gorm.SpecEntityName,
 		gorm.SpecRevisionTagEntityName,
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 		gorm.BlobEntityName,
 	} {
 		q := d.NewQuery(entityName)
@@ -185,9 +212,15 @@ func (d *Client) DeleteSpec(ctx context.Context, name names.Spec) error {
 
 func (d *Client) GetSpecTags(ctx context.Context, name names.Spec) ([]*models.SpecRevisionTag, error) {
 	q := d.NewQuery(gorm.SpecRevisionTagEntityName)
-	q = q.Require("ProjectID", name.ProjectID)
-	q = q.Require("ApiID", name.ApiID)
-	q = q.Require("VersionID", name.VersionID)
+	if name.ProjectID != "-" {
+		q = q.Require("ProjectID", name.ProjectID)
+	}
+	if name.ApiID != "-" {
+		q = q.Require("ApiID", name.ApiID)
+	}
+	if name.VersionID != "-" {
+		q = q.Require("VersionID", name.VersionID)
+	}
 	if name.SpecID != "-" {
 		q = q.Require("SpecID", name.SpecID)
 	}
@@ -201,6 +234,7 @@ func (d *Client) GetSpecTags(ctx context.Context, name names.Spec) ([]*models.Sp
 
 	for _, err = it.Next(tag); err == nil; _, err = it.Next(tag) {
 		tags = append(tags, tag)
+		tag = new(models.SpecRevisionTag)
 	}
 	if err != nil && err != iterator.Done {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -208,3 +242,24 @@ func (d *Client) GetSpecTags(ctx context.Context, name names.Spec) ([]*models.Sp
 
 	return tags, nil
 }
+
+// specRevisionTags returns the tags of matching spec revisions, keyed by revision name.
+func (d *Client) specRevisionTags(ctx context.Context, name names.Spec) (map[string][]string, error) {
+	tags, err := d.GetSpecTags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revTags := make(map[string][]string, len(tags))
+	for _, tag := range tags {
+		rev := names.SpecRevision{
+			ProjectID:  tag.ProjectID,
+			ApiID:      tag.ApiID,
+			VersionID:  tag.VersionID,
+			SpecID:     tag.SpecID,
+			RevisionID: tag.RevisionID,
+		}.String()
+		revTags[rev] = append(revTags[rev], tag.Tag)
+	}
+	return revTags, nil
+}
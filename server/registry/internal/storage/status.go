@@ -0,0 +1,132 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DatabaseStatus describes the database used by a client.
+type DatabaseStatus struct {
+	// Version is the version reported by the database.
+	Version string
+	// SchemaVersion is a digest of the tables and columns expected by the server.
+	SchemaVersion string
+	// SchemaCurrent is true if the database has all of the expected tables and columns.
+	SchemaCurrent bool
+	// Pool holds statistics about the connections of the client.
+	Pool sql.DBStats
+}
+
+func (d *Client) GetDatabaseStatus(ctx context.Context) (DatabaseStatus, error) {
+	var (
+		s   DatabaseStatus
+		err error
+	)
+	if s.Version, err = d.DatabaseVersion(ctx); err != nil {
+		return s, status.Error(codes.Internal, err.Error())
+	}
+	if s.SchemaVersion, s.SchemaCurrent, err = d.SchemaVersion(); err != nil {
+		return s, status.Error(codes.Internal, err.Error())
+	}
+	if s.Pool, err = d.PoolStats(); err != nil {
+		return s, status.Error(codes.Internal, err.Error())
+	}
+	return s, nil
+}
+
+// ProjectCounts holds the numbers of resources stored in a project.
+type ProjectCounts struct {
+	Project names.Project
+	// Apis is the number of APIs in the project.
+	Apis int64
+	// Specs is the number of specs in the project.
+	Specs int64
+	// SpecRevisions is the number of revisions of all specs in the project.
+	SpecRevisions int64
+	// BlobBytes is the total size of all spec and artifact contents in the project.
+	BlobBytes int64
+}
+
+// ListProjectCounts returns the resource counts of every project, ordered by project ID.
+func (d *Client) ListProjectCounts(ctx context.Context) ([]ProjectCounts, error) {
+	var (
+		counts []ProjectCounts
+		index  = make(map[string]*ProjectCounts)
+	)
+	projects, err := d.CountBy(ctx, d.NewQuery(gorm.ProjectEntityName), "project_id", "")
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, g := range projects {
+		counts = append(counts, ProjectCounts{Project: names.Project{ProjectID: g.Values[0]}})
+	}
+	for i := range counts {
+		index[counts[i].Project.ProjectID] = &counts[i]
+	}
+
+	// Each count is made with a single grouped query, and is added to its project.
+	for _, c := range []struct {
+		kind  string
+		group func(context.Context, *gorm.Query) ([]gorm.Group, error)
+		add   func(*ProjectCounts, gorm.Group)
+	}{
+		{
+			kind: gorm.ApiEntityName,
+			group: func(ctx context.Context, q *gorm.Query) ([]gorm.Group, error) {
+				return d.CountBy(ctx, q, "project_id", "")
+			},
+			add: func(p *ProjectCounts, g gorm.Group) { p.Apis = g.Count },
+		},
+		{
+			kind: gorm.SpecEntityName,
+			group: func(ctx context.Context, q *gorm.Query) ([]gorm.Group, error) {
+				return d.GroupCount(ctx, q, []string{"project_id"}, "")
+			},
+			add: func(p *ProjectCounts, g gorm.Group) { p.Specs = g.Count },
+		},
+		{
+			kind: gorm.SpecEntityName,
+			group: func(ctx context.Context, q *gorm.Query) ([]gorm.Group, error) {
+				return d.CountBy(ctx, q, "project_id", "")
+			},
+			add: func(p *ProjectCounts, g gorm.Group) { p.SpecRevisions = g.Count },
+		},
+		{
+			kind: gorm.BlobEntityName,
+			group: func(ctx context.Context, q *gorm.Query) ([]gorm.Group, error) {
+				return d.CountBy(ctx, q, "project_id", "size_in_bytes")
+			},
+			add: func(p *ProjectCounts, g gorm.Group) { p.BlobBytes = g.Sum },
+		},
+	} {
+		groups, err := c.group(ctx, d.NewQuery(c.kind))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		for _, g := range groups {
+			if p, ok := index[g.Values[0]]; ok {
+				c.add(p, g)
+			}
+		}
+	}
+	return counts, nil
+}
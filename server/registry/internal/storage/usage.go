@@ -0,0 +1,58 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProjectUsage describes the resources stored in a project.
+type ProjectUsage struct {
+	// Apis is the number of APIs in the project.
+	Apis int64
+	// SpecRevisions is the number of revisions of all specs in the project.
+	SpecRevisions int64
+	// BlobBytes is the total size of all spec and artifact contents in the project.
+	BlobBytes int64
+}
+
+func (d *Client) GetProjectUsage(ctx context.Context, name names.Project) (ProjectUsage, error) {
+	var (
+		usage ProjectUsage
+		err   error
+	)
+
+	q := d.NewQuery(gorm.ApiEntityName).Require("ProjectID", name.ProjectID)
+	if usage.Apis, err = d.Count(ctx, q); err != nil {
+		return usage, status.Error(codes.Internal, err.Error())
+	}
+
+	q = d.NewQuery(gorm.SpecEntityName).Require("ProjectID", name.ProjectID)
+	if usage.SpecRevisions, err = d.Count(ctx, q); err != nil {
+		return usage, status.Error(codes.Internal, err.Error())
+	}
+
+	q = d.NewQuery(gorm.BlobEntityName).Require("ProjectID", name.ProjectID)
+	if usage.BlobBytes, err = d.Sum(ctx, q, "size_in_bytes"); err != nil {
+		return usage, status.Error(codes.Internal, err.Error())
+	}
+
+	return usage, nil
+}
@@ -43,6 +43,9 @@ var versionFields = []filtering.Field{
 	{Name: "update_time", Type: filtering.Timestamp},
 	{Name: "state", Type: filtering.String},
 	{Name: "labels", Type: filtering.StringMap},
+	{Name: "annotations", Type: filtering.StringMap},
+	projectParentField,
+	apiParentField,
 }
 
 func (d *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOptions) (VersionList, error) {
@@ -82,7 +85,9 @@ func (d *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 		return VersionList{}, err
 	}
 
+	q = q.Omit(omittedColumns(opts)...)
 	it := d.Run(ctx, q)
+	parents := d.newParentBindings(filter)
 	response := VersionList{
 		Versions: make([]models.Version, 0, opts.Size),
 	}
@@ -93,6 +98,9 @@ func (d *Client) ListVersions(ctx context.Context, parent names.Api, opts PageOp
 		if err != nil {
 			return response, status.Error(codes.Internal, err.Error())
 		}
+		if err := parents.bind(ctx, versionMap, version.ProjectID, version.ApiID, ""); err != nil {
+			return response, err
+		}
 
 		match, err := filter.Matches(versionMap)
 		if err != nil {
@@ -127,9 +135,15 @@ func versionMap(version models.Version) (map[string]interface{}, error) {
 		return nil, err
 	}
 
+	annotations, err := version.AnnotationsMap()
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		"name":         version.Name(),
 		"project_id":   version.ProjectID,
+		"api_id":       version.ApiID,
 		"version_id":   version.VersionID,
 		"display_name": version.DisplayName,
 		"description":  version.Description,
@@ -137,6 +151,7 @@ func versionMap(version models.Version) (map[string]interface{}, error) {
 		"update_time":  version.UpdateTime,
 		"state":        version.State,
 		"labels":       labels,
+		"annotations":  annotations,
 	}, nil
 }
 
@@ -164,9 +179,11 @@ func (d *Client) SaveVersion(ctx context.Context, version *models.Version) error
 func (d *Client) DeleteVersion(ctx context.Context, name names.Version) error {
 	for _, entityName := range []string{
 		gorm.VersionEntityName,
+		gorm.VersionRevisionEntityName,
 		gorm.SpecEntityName,
 		gorm.SpecRevisionTagEntityName,
 		gorm.ArtifactEntityName,
+		gorm.ArtifactRevisionTagEntityName,
 		gorm.BlobEntityName,
 	} {
 		q := d.NewQuery(entityName)
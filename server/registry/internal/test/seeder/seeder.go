@@ -19,6 +19,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
@@ -48,8 +49,12 @@ type RegistryResource interface {
 func SeedRegistry(ctx context.Context, s RegistryImp, resources ...RegistryResource) error {
 	// All child resources are prefixed with their parent's name, so this sorts resources into hierarchical ordering.
 	// Using a stable sort keeps multiple resources of the same name in their original order.
+	// Deployments are sorted after versions so that the specs they serve are created first.
+	key := func(r RegistryResource) string {
+		return strings.Replace(r.GetName(), "/deployments/", "/~deployments/", 1)
+	}
 	sort.SliceStable(resources, func(i, j int) bool {
-		return resources[i].GetName() < resources[j].GetName()
+		return key(resources[i]) < key(resources[j])
 	})
 
 	// Maintain a history of created resources to skip redundant requests.
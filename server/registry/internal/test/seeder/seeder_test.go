@@ -98,10 +98,10 @@ func TestSeedRegistry(t *testing.T) {
 			want: []string{
 				"projects/p",
 				"projects/p/locations/global/apis/a",
-				"projects/p/locations/global/apis/a/deployments/d",
 				"projects/p/locations/global/apis/a/versions/v",
 				"projects/p/locations/global/apis/a/versions/v/specs/s",
 				"projects/p/locations/global/apis/a/versions/v/specs/s/artifacts/a",
+				"projects/p/locations/global/apis/a/deployments/d",
 			},
 		},
 		{
@@ -113,11 +113,11 @@ func TestSeedRegistry(t *testing.T) {
 			want: []string{
 				"projects/p",
 				"projects/p/locations/global/apis/a",
-				"projects/p/locations/global/apis/a/deployments/d",
-				"projects/p/locations/global/apis/a/deployments/d/artifacts/a",
 				"projects/p/locations/global/apis/a/versions/v",
 				"projects/p/locations/global/apis/a/versions/v/specs/s",
 				"projects/p/locations/global/apis/a/versions/v/specs/s/artifacts/a",
+				"projects/p/locations/global/apis/a/deployments/d",
+				"projects/p/locations/global/apis/a/deployments/d/artifacts/a",
 			},
 		},
 		{
@@ -134,11 +134,11 @@ func TestSeedRegistry(t *testing.T) {
 			want: []string{
 				"projects/p",
 				"projects/p/locations/global/apis/a",
-				"projects/p/locations/global/apis/a/deployments/d",
-				"projects/p/locations/global/apis/a/deployments/d/artifacts/a",
 				"projects/p/locations/global/apis/a/versions/v",
 				"projects/p/locations/global/apis/a/versions/v/specs/s",
 				"projects/p/locations/global/apis/a/versions/v/specs/s/artifacts/a",
+				"projects/p/locations/global/apis/a/deployments/d",
+				"projects/p/locations/global/apis/a/deployments/d/artifacts/a",
 			},
 		},
 		{
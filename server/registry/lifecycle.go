@@ -0,0 +1,244 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validateLifecycles returns an error if the lifecycles of a project are invalid.
+func validateLifecycles(project *rpc.Project) error {
+	if err := validateLifecycle("api_lifecycle", project.GetApiLifecycle(), &rpc.Api{}); err != nil {
+		return err
+	}
+	return validateLifecycle("version_lifecycle", project.GetVersionLifecycle(), &rpc.ApiVersion{})
+}
+
+// validateLifecycle returns an error if a lifecycle is inconsistent or requires fields that resources don't have.
+// The field names the project field that holds the lifecycle and resource is an example of the constrained resources.
+func validateLifecycle(field string, lifecycle *rpc.Lifecycle, resource proto.Message) error {
+	if lifecycle == nil {
+		return nil
+	}
+	if len(lifecycle.GetStates()) == 0 && lifecycle.GetInitialState() != "" {
+		return fmt.Errorf("invalid %s: initial_state %q must be one of the states", field, lifecycle.GetInitialState())
+	}
+
+	seen := make(map[string]bool, len(lifecycle.GetStates()))
+	for _, state := range lifecycle.GetStates() {
+		key := strings.ToLower(state.GetName())
+		if key == "" {
+			return fmt.Errorf("invalid %s: state names must not be empty", field)
+		} else if seen[key] {
+			return fmt.Errorf("invalid %s: state %q is defined more than once", field, state.GetName())
+		}
+		seen[key] = true
+	}
+
+	if initial := lifecycle.GetInitialState(); initial != "" && lifecycleState(lifecycle, initial) == nil {
+		return fmt.Errorf("invalid %s: initial_state %q must be one of the states", field, initial)
+	}
+	for _, state := range lifecycle.GetStates() {
+		for _, next := range state.GetTransitions() {
+			if lifecycleState(lifecycle, next) == nil {
+				return fmt.Errorf("invalid %s: state %q has a transition to undefined state %q", field, state.GetName(), next)
+			}
+		}
+		for _, required := range state.GetRequiredFields() {
+			if _, err := lifecycleFieldValue(resource, required); err != nil {
+				return fmt.Errorf("invalid %s: state %q requires unknown field: %s", field, state.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkApiLifecycle checks the availability of an API against the lifecycle of its project and saves it as it
+// is written in the lifecycle. The previous version of the API is nil when it is created.
+// It returns true if the API moves to another state of the lifecycle.
+func checkApiLifecycle(project *models.Project, api, previous *models.Api) (bool, error) {
+	lifecycle, err := project.ApiLifecycleMessage()
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	from := ""
+	if previous != nil {
+		from = previous.Availability
+	} else if api.Availability == "" {
+		api.Availability = lifecycle.GetInitialState()
+	}
+
+	if api.Availability, err = transition("availability", lifecycle, from, api.Availability); err != nil {
+		return false, err
+	}
+	message, err := api.Message()
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	if err := checkRequiredFields("availability", lifecycle, api.Availability, message); err != nil {
+		return false, err
+	}
+	return previous != nil && isTransition(lifecycle, from, api.Availability), nil
+}
+
+// checkVersionLifecycle checks the state of a version against the lifecycle of its project and saves it as it
+// is written in the lifecycle. The previous value of the version is nil when it is created.
+// It returns true if the version moves to another state of the lifecycle.
+func checkVersionLifecycle(project *models.Project, version, previous *models.Version) (bool, error) {
+	lifecycle, err := project.VersionLifecycleMessage()
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	from := ""
+	if previous != nil {
+		from = previous.State
+	} else if version.State == "" {
+		version.State = lifecycle.GetInitialState()
+	}
+
+	if version.State, err = transition("state", lifecycle, from, version.State); err != nil {
+		return false, err
+	}
+	message, err := version.Message()
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	if err := checkRequiredFields("state", lifecycle, version.State, message); err != nil {
+		return false, err
+	}
+	return previous != nil && isTransition(lifecycle, from, version.State), nil
+}
+
+// lifecycleState returns the state of a lifecycle with a name, ignoring case, or nil if there isn't one.
+func lifecycleState(lifecycle *rpc.Lifecycle, name string) *rpc.Lifecycle_State {
+	for _, state := range lifecycle.GetStates() {
+		if strings.EqualFold(state.GetName(), name) {
+			return state
+		}
+	}
+	return nil
+}
+
+// transition checks that a resource can move from one state of a lifecycle to another and returns the
+// name of the new state as it is written in the lifecycle. New resources move from an empty state, and
+// must start in the initial state of the lifecycle if it has one. States that aren't in the lifecycle,
+// such as ones saved before the lifecycle was set, can move to any state.
+func transition(field string, lifecycle *rpc.Lifecycle, from, to string) (string, error) {
+	if len(lifecycle.GetStates()) == 0 {
+		return to, nil
+	}
+
+	source, target := lifecycleState(lifecycle, from), lifecycleState(lifecycle, to)
+	if strings.EqualFold(from, to) {
+		if target != nil {
+			return target.GetName(), nil
+		}
+		return to, nil
+	} else if target == nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be one of %s", field, to, stateNames(lifecycle.GetStates()))
+	} else if initial := lifecycle.GetInitialState(); from == "" && initial != "" && !strings.EqualFold(initial, to) {
+		return "", status.Errorf(codes.FailedPrecondition, "can't set %s to %q, new resources start in %q", field, target.GetName(), lifecycleState(lifecycle, initial).GetName())
+	} else if source == nil {
+		return target.GetName(), nil
+	}
+
+	for _, next := range source.GetTransitions() {
+		if strings.EqualFold(next, target.GetName()) {
+			return target.GetName(), nil
+		}
+	}
+	return "", status.Errorf(codes.FailedPrecondition, "can't change %s from %q to %q, allowed changes are to %s",
+		field, source.GetName(), target.GetName(), stateNames(lifecycleStates(lifecycle, source.GetTransitions())))
+}
+
+// isTransition returns true if a resource moved between states of a lifecycle. Changes to the case
+// of a state don't move it, and resources don't have states to move between if there is no lifecycle.
+func isTransition(lifecycle *rpc.Lifecycle, from, to string) bool {
+	return len(lifecycle.GetStates()) > 0 && !strings.EqualFold(from, to)
+}
+
+// checkRequiredFields returns an error if a resource doesn't set the fields required by its lifecycle state.
+func checkRequiredFields(field string, lifecycle *rpc.Lifecycle, state string, resource proto.Message) error {
+	s := lifecycleState(lifecycle, state)
+	if s == nil {
+		return nil
+	}
+
+	missing := make([]string, 0)
+	for _, required := range s.GetRequiredFields() {
+		if value, err := lifecycleFieldValue(resource, required); err != nil || value == "" {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return status.Errorf(codes.InvalidArgument, "%s %q requires %s to be set", field, s.GetName(), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// lifecycleFieldValue returns the value of a string field, label or annotation of a resource.
+// Labels and annotations are named with their keys, such as "labels.owner".
+func lifecycleFieldValue(resource proto.Message, field string) (string, error) {
+	name, key := field, ""
+	if i := strings.Index(field, "."); i >= 0 {
+		name, key = field[:i], field[i+1:]
+	}
+
+	m := resource.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+	switch {
+	case fd == nil:
+		return "", fmt.Errorf("%q is not a field of %s", field, m.Descriptor().Name())
+	case fd.IsMap() && fd.MapValue().Kind() == protoreflect.StringKind && key != "":
+		entries, k := m.Get(fd).Map(), protoreflect.ValueOfString(key).MapKey()
+		if !entries.Has(k) {
+			return "", nil
+		}
+		return entries.Get(k).String(), nil
+	case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.StringKind && key == "":
+		return m.Get(fd).String(), nil
+	default:
+		return "", fmt.Errorf("%q must be a string field or a label or annotation key", field)
+	}
+}
+
+func lifecycleStates(lifecycle *rpc.Lifecycle, names []string) []*rpc.Lifecycle_State {
+	states := make([]*rpc.Lifecycle_State, 0, len(names))
+	for _, name := range names {
+		if state := lifecycleState(lifecycle, name); state != nil {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+func stateNames(states []*rpc.Lifecycle_State) string {
+	if len(states) == 0 {
+		return "none"
+	}
+	names := make([]string, len(states))
+	for i, state := range states {
+		names[i] = fmt.Sprintf("%q", state.GetName())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,358 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage/gorm"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var testLifecycle = &rpc.Lifecycle{
+	InitialState: "DESIGN",
+	States: []*rpc.Lifecycle_State{
+		{Name: "DESIGN", Transitions: []string{"PRODUCTION"}},
+		{Name: "PRODUCTION", Transitions: []string{"DEPRECATED"}},
+		{Name: "DEPRECATED", Transitions: []string{"PRODUCTION", "RETIRED"}, RequiredFields: []string{"annotations.deprecation-date"}},
+		{Name: "RETIRED"},
+	},
+}
+
+func TestTransition(t *testing.T) {
+	tests := []struct {
+		desc     string
+		from, to string
+		want     string
+		code     codes.Code
+	}{
+		{desc: "allowed", from: "DESIGN", to: "PRODUCTION", want: "PRODUCTION"},
+		{desc: "case insensitive", from: "design", to: "production", want: "PRODUCTION"},
+		{desc: "unchanged", from: "RETIRED", to: "retired", want: "RETIRED"},
+		{desc: "new resource", from: "", to: "design", want: "DESIGN"},
+		{desc: "new resource not in initial state", from: "", to: "DEPRECATED", code: codes.FailedPrecondition},
+		{desc: "unknown source", from: "legacy", to: "RETIRED", want: "RETIRED"},
+		{desc: "unchanged unknown source", from: "legacy", to: "legacy", want: "legacy"},
+		{desc: "disallowed", from: "PRODUCTION", to: "DESIGN", code: codes.FailedPrecondition},
+		{desc: "final state", from: "RETIRED", to: "PRODUCTION", code: codes.FailedPrecondition},
+		{desc: "unknown target", from: "DESIGN", to: "BETA", code: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := transition("availability", testLifecycle, test.from, test.to)
+			if status.Code(err) != test.code {
+				t.Fatalf("transition(%q, %q) returned status code %q, want %q: %v", test.from, test.to, status.Code(err), test.code, err)
+			}
+			if got != test.want {
+				t.Errorf("transition(%q, %q) returned %q, want %q", test.from, test.to, got, test.want)
+			}
+		})
+	}
+
+	if got, err := transition("availability", nil, "anything", "else"); err != nil || got != "else" {
+		t.Errorf("transition() without a lifecycle returned (%q, %v), want (%q, nil)", got, err, "else")
+	}
+
+	noInitial := &rpc.Lifecycle{States: testLifecycle.GetStates()}
+	if got, err := transition("availability", noInitial, "", "deprecated"); err != nil || got != "DEPRECATED" {
+		t.Errorf("transition() of a new resource without an initial state returned (%q, %v), want (%q, nil)", got, err, "DEPRECATED")
+	}
+}
+
+func TestIsTransition(t *testing.T) {
+	tests := []struct {
+		desc      string
+		lifecycle *rpc.Lifecycle
+		from, to  string
+		want      bool
+	}{
+		{desc: "changed", lifecycle: testLifecycle, from: "DESIGN", to: "PRODUCTION", want: true},
+		{desc: "new state", lifecycle: testLifecycle, from: "legacy", to: "RETIRED", want: true},
+		{desc: "unchanged", lifecycle: testLifecycle, from: "DESIGN", to: "DESIGN"},
+		{desc: "case changed", lifecycle: testLifecycle, from: "design", to: "DESIGN"},
+		{desc: "no lifecycle", from: "alpha", to: "beta"},
+		{desc: "no states", lifecycle: &rpc.Lifecycle{}, from: "alpha", to: "beta"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := isTransition(test.lifecycle, test.from, test.to); got != test.want {
+				t.Errorf("isTransition(%q, %q) returned %t, want %t", test.from, test.to, got, test.want)
+			}
+		})
+	}
+}
+
+func TestApiLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const api = "projects/my-project/locations/global/apis/a"
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project", ApiLifecycle: testLifecycle}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	created, err := server.CreateApi(ctx, &rpc.CreateApiRequest{
+		Parent: "projects/my-project/locations/global",
+		ApiId:  "a",
+		Api:    &rpc.Api{},
+	})
+	if err != nil {
+		t.Fatalf("CreateApi(%q) returned error: %s", api, err)
+	}
+	if created.GetAvailability() != "DESIGN" {
+		t.Errorf("CreateApi(%q) returned availability %q, want initial state %q", api, created.GetAvailability(), "DESIGN")
+	}
+
+	createReq := &rpc.CreateApiRequest{
+		Parent: "projects/my-project/locations/global",
+		ApiId:  "b",
+		Api:    &rpc.Api{Availability: "PRODUCTION"},
+	}
+	if _, err := server.CreateApi(ctx, createReq); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", createReq, status.Code(err), codes.FailedPrecondition, err)
+	}
+
+	tests := []struct {
+		desc string
+		api  *rpc.Api
+		want string
+		code codes.Code
+	}{
+		{desc: "allowed", api: &rpc.Api{Availability: "production"}, want: "PRODUCTION"},
+		{desc: "disallowed", api: &rpc.Api{Availability: "DESIGN"}, code: codes.FailedPrecondition},
+		{desc: "unknown", api: &rpc.Api{Availability: "BETA"}, code: codes.InvalidArgument},
+		{desc: "missing required field", api: &rpc.Api{Availability: "DEPRECATED"}, code: codes.InvalidArgument},
+		{
+			desc: "required field",
+			api:  &rpc.Api{Availability: "DEPRECATED", Annotations: map[string]string{"deprecation-date": "2022-01-01"}},
+			want: "DEPRECATED",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.api.Name = api
+			req := &rpc.UpdateApiRequest{
+				Api:        test.api,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"availability", "annotations"}},
+			}
+			got, err := server.UpdateApi(ctx, req)
+			if status.Code(err) != test.code {
+				t.Fatalf("UpdateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), test.code, err)
+			}
+			if err == nil && got.GetAvailability() != test.want {
+				t.Errorf("UpdateApi(%+v) returned availability %q, want %q", req, got.GetAvailability(), test.want)
+			}
+		})
+	}
+
+	// Required fields must stay set while a resource is in a state.
+	req := &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: api},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"annotations"}},
+	}
+	if _, err := server.UpdateApi(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+	}
+}
+
+func TestVersionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const version = "projects/my-project/locations/global/apis/a/versions/v1"
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.Project{Name: "projects/my-project", VersionLifecycle: testLifecycle},
+		&rpc.ApiVersion{Name: version},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	got, err := server.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: version})
+	if err != nil {
+		t.Fatalf("GetApiVersion(%q) returned error: %s", version, err)
+	}
+	if got.GetState() != "DESIGN" {
+		t.Errorf("GetApiVersion(%q) returned state %q, want initial state %q", version, got.GetState(), "DESIGN")
+	}
+
+	req := &rpc.UpdateApiVersionRequest{
+		ApiVersion: &rpc.ApiVersion{Name: version, State: "RETIRED"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"state"}},
+	}
+	if _, err := server.UpdateApiVersion(ctx, req); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("UpdateApiVersion(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.FailedPrecondition, err)
+	}
+
+	req.ApiVersion.State = "Production"
+	if got, err := server.UpdateApiVersion(ctx, req); err != nil {
+		t.Errorf("UpdateApiVersion(%+v) returned error: %s", req, err)
+	} else if got.GetState() != "PRODUCTION" {
+		t.Errorf("UpdateApiVersion(%+v) returned state %q, want %q", req, got.GetState(), "PRODUCTION")
+	}
+
+	create := &rpc.CreateApiVersionRequest{
+		Parent:       "projects/my-project/locations/global/apis/a",
+		ApiVersionId: "v2",
+		ApiVersion:   &rpc.ApiVersion{State: "BETA"},
+	}
+	if _, err := server.CreateApiVersion(ctx, create); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateApiVersion(%+v) returned status code %q, want %q: %v", create, status.Code(err), codes.InvalidArgument, err)
+	}
+}
+
+func TestInvalidLifecycle(t *testing.T) {
+	tests := []struct {
+		desc      string
+		lifecycle *rpc.Lifecycle
+	}{
+		{
+			desc:      "empty state name",
+			lifecycle: &rpc.Lifecycle{States: []*rpc.Lifecycle_State{{Name: ""}}},
+		},
+		{
+			desc:      "duplicate states",
+			lifecycle: &rpc.Lifecycle{States: []*rpc.Lifecycle_State{{Name: "DESIGN"}, {Name: "design"}}},
+		},
+		{
+			desc:      "undefined initial state",
+			lifecycle: &rpc.Lifecycle{InitialState: "BETA", States: []*rpc.Lifecycle_State{{Name: "DESIGN"}}},
+		},
+		{
+			desc:      "initial state without states",
+			lifecycle: &rpc.Lifecycle{InitialState: "DESIGN"},
+		},
+		{
+			desc:      "undefined transition",
+			lifecycle: &rpc.Lifecycle{States: []*rpc.Lifecycle_State{{Name: "DESIGN", Transitions: []string{"BETA"}}}},
+		},
+		{
+			desc:      "unknown required field",
+			lifecycle: &rpc.Lifecycle{States: []*rpc.Lifecycle_State{{Name: "DESIGN", RequiredFields: []string{"owner"}}}},
+		},
+		{
+			desc:      "required field that isn't a string",
+			lifecycle: &rpc.Lifecycle{States: []*rpc.Lifecycle_State{{Name: "DESIGN", RequiredFields: []string{"create_time"}}}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			req := &rpc.CreateProjectRequest{
+				ProjectId: "my-project",
+				Project:   &rpc.Project{ApiLifecycle: test.lifecycle},
+			}
+			if _, err := server.CreateProject(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("CreateProject(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+			}
+
+			update := &rpc.UpdateProjectRequest{
+				Project:      &rpc.Project{Name: "projects/my-project", VersionLifecycle: test.lifecycle},
+				UpdateMask:   &fieldmaskpb.FieldMask{Paths: []string{"version_lifecycle"}},
+				AllowMissing: true,
+			}
+			if _, err := server.UpdateProject(ctx, update); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("UpdateProject(%+v) returned status code %q, want %q: %v", update, status.Code(err), codes.InvalidArgument, err)
+			}
+		})
+	}
+}
+
+func TestProjectLifecycles(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.UpdateProjectRequest{
+		Project:    &rpc.Project{Name: "projects/my-project", ApiLifecycle: testLifecycle},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"api_lifecycle"}},
+	}
+	if _, err := server.UpdateProject(ctx, req); err != nil {
+		t.Fatalf("UpdateProject(%+v) returned error: %s", req, err)
+	}
+	got, err := server.GetProject(ctx, &rpc.GetProjectRequest{Name: "projects/my-project"})
+	if err != nil {
+		t.Fatalf("GetProject(%q) returned error: %s", "projects/my-project", err)
+	}
+	if len(got.GetApiLifecycle().GetStates()) != len(testLifecycle.GetStates()) || got.GetVersionLifecycle() != nil {
+		t.Errorf("GetProject(%q) returned lifecycles %v and %v, want only the API lifecycle", "projects/my-project", got.GetApiLifecycle(), got.GetVersionLifecycle())
+	}
+
+	// Clearing a lifecycle removes its constraints.
+	req.Project.ApiLifecycle = nil
+	if _, err := server.UpdateProject(ctx, req); err != nil {
+		t.Fatalf("UpdateProject(%+v) returned error: %s", req, err)
+	}
+	api := &rpc.CreateApiRequest{
+		Parent: "projects/my-project/locations/global",
+		ApiId:  "a",
+		Api:    &rpc.Api{Availability: "anything"},
+	}
+	if _, err := server.CreateApi(ctx, api); err != nil {
+		t.Errorf("CreateApi(%+v) returned error: %s", api, err)
+	}
+}
+
+func TestCorruptLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.Project{Name: "projects/my-project", ApiLifecycle: testLifecycle},
+		&rpc.Api{Name: "projects/my-project/locations/global/apis/a"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	db, err := server.getStorageClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to get storage client: %s", err)
+	}
+	defer db.Close()
+	project := new(models.Project)
+	k := db.NewKey(gorm.ProjectEntityName, "projects/my-project")
+	if err := db.Get(ctx, k, project); err != nil {
+		t.Fatalf("Setup: failed to get project record: %s", err)
+	}
+	project.ApiLifecycle = []byte("not a lifecycle")
+	if _, err := db.Put(ctx, k, project); err != nil {
+		t.Fatalf("Setup: failed to put project record: %s", err)
+	}
+
+	get := &rpc.GetProjectRequest{Name: "projects/my-project"}
+	if _, err := server.GetProject(ctx, get); status.Code(err) != codes.Internal {
+		t.Errorf("GetProject(%+v) returned status code %q, want %q: %v", get, status.Code(err), codes.Internal, err)
+	}
+	create := &rpc.CreateApiRequest{
+		Parent: "projects/my-project/locations/global",
+		ApiId:  "b",
+		Api:    &rpc.Api{Availability: "anything"},
+	}
+	if _, err := server.CreateApi(ctx, create); status.Code(err) != codes.Internal {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", create, status.Code(err), codes.Internal, err)
+	}
+	update := &rpc.UpdateApiRequest{
+		Api:        &rpc.Api{Name: "projects/my-project/locations/global/apis/a", Availability: "anything"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"availability"}},
+	}
+	if _, err := server.UpdateApi(ctx, update); status.Code(err) != codes.Internal {
+		t.Errorf("UpdateApi(%+v) returned status code %q, want %q: %v", update, status.Code(err), codes.Internal, err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultMaintenanceMessage is returned by rejected requests if no message is configured.
+const defaultMaintenanceMessage = "the registry is in read-only maintenance mode, please try again later"
+
+// maintenanceMode holds the read-only state of a server.
+type maintenanceMode struct {
+	mutex      sync.Mutex
+	readOnly   bool
+	message    string
+	updateTime time.Time
+}
+
+func (m *maintenanceMode) set(readOnly bool, message string) *rpc.MaintenanceMode {
+	if message == "" {
+		message = defaultMaintenanceMessage
+	}
+	m.mutex.Lock()
+	m.readOnly, m.message, m.updateTime = readOnly, message, time.Now()
+	m.mutex.Unlock()
+	return m.status()
+}
+
+func (m *maintenanceMode) status() *rpc.MaintenanceMode {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return &rpc.MaintenanceMode{
+		ReadOnly:   m.readOnly,
+		Message:    m.message,
+		UpdateTime: timestamppb.New(m.updateTime),
+	}
+}
+
+// check returns an UNAVAILABLE error if the server is read-only and a method would change stored resources.
+func (m *maintenanceMode) check(method string, req interface{}) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.readOnly || isReadOnlyMethod(method, req) {
+		return nil
+	}
+	return status.Error(codes.Unavailable, m.message)
+}
+
+// maintainedServices are the services whose requests are checked in read-only mode.
+// Other services, like health checks and reflection, don't use stored resources
+// and keep working so that a read-only registry isn't taken out of service.
+var maintainedServices = []string{
+	"/" + rpc.Registry_ServiceDesc.ServiceName + "/",
+	"/" + rpc.Admin_ServiceDesc.ServiceName + "/",
+}
+
+// isReadOnlyMethod returns true if a method doesn't change stored resources.
+// Registry and Admin methods that aren't known to be read-only are assumed to make changes.
+func isReadOnlyMethod(fullMethod string, req interface{}) bool {
+	maintained := false
+	for _, prefix := range maintainedServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			maintained = true
+		}
+	}
+	if !maintained {
+		return true
+	}
+
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "Download"} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	switch method {
+	case "AggregateResources", "CompareApiSpecRevisions", "ExportProject":
+		return true
+	case "SetMaintenanceMode":
+		// Servers must be able to leave maintenance mode.
+		return true
+	case "MigrateDatabase":
+		// Schema migrations are expected to be run during maintenance.
+		return true
+	case "CheckConsistency":
+		r, ok := req.(*rpc.CheckConsistencyRequest)
+		return ok && !r.GetRepair()
+	case "PruneRevisions":
+		r, ok := req.(*rpc.PruneRevisionsRequest)
+		return ok && r.GetValidateOnly()
+	}
+	return false
+}
+
+// ReadOnlyInterceptor returns a gRPC server interceptor that rejects requests that would change
+// stored resources while the server is in read-only maintenance mode.
+func (s *RegistryServer) ReadOnlyInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := s.maintenance.check(info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ReadOnlyStreamInterceptor is the streaming version of ReadOnlyInterceptor.
+// Streamed requests are checked before their first message is received.
+func (s *RegistryServer) ReadOnlyStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := s.maintenance.check(info.FullMethod, nil); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// callInMaintenance calls a server method through the read-only interceptor.
+func callInMaintenance(ctx context.Context, server *RegistryServer, service, method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.cloud.apigeeregistry.v1." + service + "/" + method}
+	return server.ReadOnlyInterceptor()(ctx, req, info, handler)
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	message := "Backup in progress"
+	mode, err := server.SetMaintenanceMode(ctx, &rpc.SetMaintenanceModeRequest{ReadOnly: true, Message: message})
+	if err != nil {
+		t.Fatalf("SetMaintenanceMode() returned error: %s", err)
+	}
+	if !mode.GetReadOnly() || mode.GetMessage() != message {
+		t.Errorf("SetMaintenanceMode() returned %+v, want read-only mode with message %q", mode, message)
+	}
+
+	create := &rpc.CreateApiRequest{Parent: "projects/my-project/locations/global", ApiId: "my-api", Api: &rpc.Api{}}
+	_, err = callInMaintenance(ctx, server, "Registry", "CreateApi", create, createApiHandler(server))
+	if status.Code(err) != codes.Unavailable || status.Convert(err).Message() != message {
+		t.Errorf("CreateApi(%+v) returned %v, want %q error with message %q", create, err, codes.Unavailable, message)
+	}
+
+	list := &rpc.ListApisRequest{Parent: "projects/my-project/locations/global"}
+	if _, err := callInMaintenance(ctx, server, "Registry", "ListApis", list, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.ListApis(ctx, req.(*rpc.ListApisRequest))
+	}); err != nil {
+		t.Errorf("ListApis(%+v) returned error in read-only mode: %s", list, err)
+	}
+
+	status, err := server.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		t.Fatalf("GetStatus() returned error: %s", err)
+	}
+	if !status.GetMaintenance().GetReadOnly() {
+		t.Errorf("GetStatus() returned maintenance %+v, want read-only", status.GetMaintenance())
+	}
+
+	if _, err := server.SetMaintenanceMode(ctx, &rpc.SetMaintenanceModeRequest{}); err != nil {
+		t.Fatalf("SetMaintenanceMode() returned error: %s", err)
+	}
+	if _, err := callInMaintenance(ctx, server, "Registry", "CreateApi", create, createApiHandler(server)); err != nil {
+		t.Errorf("CreateApi(%+v) returned error after leaving read-only mode: %s", create, err)
+	}
+}
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		req    interface{}
+		want   bool
+	}{
+		{method: "/google.cloud.apigeeregistry.v1.Registry/GetApi", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/ListApiSpecRevisions", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/DownloadApiSpecContents", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/AggregateResources", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/CompareApiSpecRevisions", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/ExportProject", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/SetMaintenanceMode", want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/CheckConsistency", req: &rpc.CheckConsistencyRequest{}, want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/CheckConsistency", req: &rpc.CheckConsistencyRequest{Repair: true}, want: false},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/PruneRevisions", req: &rpc.PruneRevisionsRequest{ValidateOnly: true}, want: true},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/PruneRevisions", req: &rpc.PruneRevisionsRequest{}, want: false},
+		{method: "/google.cloud.apigeeregistry.v1.Admin/ImportProject", want: false},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/UploadApiSpecContents", want: false},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/TagApiSpecRevision", want: false},
+		{method: "/google.cloud.apigeeregistry.v1.Registry/RollbackArtifact", want: false},
+		{method: "/grpc.health.v1.Health/Check", want: true},
+		{method: "/grpc.health.v1.Health/Watch", want: true},
+		{method: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", want: true},
+	}
+	for _, test := range tests {
+		if got := isReadOnlyMethod(test.method, test.req); got != test.want {
+			t.Errorf("isReadOnlyMethod(%q, %+v) returned %t, want %t", test.method, test.req, got, test.want)
+		}
+	}
+}
+
+func TestReadOnlyModeHealth(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if _, err := server.SetMaintenanceMode(ctx, &rpc.SetMaintenanceModeRequest{ReadOnly: true}); err != nil {
+		t.Fatalf("SetMaintenanceMode() returned error: %s", err)
+	}
+
+	hs := health.NewServer()
+	req := &healthpb.HealthCheckRequest{}
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	resp, err := server.ReadOnlyInterceptor()(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return hs.Check(ctx, req.(*healthpb.HealthCheckRequest))
+	})
+	if err != nil {
+		t.Fatalf("Check(%+v) returned error in read-only mode: %s", req, err)
+	}
+	if got := resp.(*healthpb.HealthCheckResponse).GetStatus(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Check(%+v) returned status %s in read-only mode, want %s", req, got, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	called := false
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch", IsServerStream: true}
+	if err := server.ReadOnlyStreamInterceptor()(nil, nil, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		called = true
+		return nil
+	}); err != nil || !called {
+		t.Errorf("Watch() returned error %v in read-only mode, want handler to be called", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"regexp"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"gopkg.in/yaml.v3"
+)
+
+var protoSyntax = regexp.MustCompile(`(?m)^\s*syntax\s*=\s*["']proto[23]["']`)
+
+// detectSpecMimeType returns a canonical MIME type for spec contents, or an empty string if the format isn't recognized.
+// It detects gzip compression, zip archives of protos, and OpenAPI, AsyncAPI, Discovery, and Protocol Buffers documents.
+func detectSpecMimeType(contents []byte) string {
+	if len(contents) == 0 {
+		return ""
+	}
+
+	if isZipArchive(contents) {
+		if zipContainsProtos(contents) {
+			return core.ProtobufMimeType("+zip")
+		}
+		return ""
+	}
+
+	compression := ""
+	if isGZipCompressed(contents) {
+		unzipped, err := models.GUnzippedBytes(contents)
+		if err != nil {
+			return ""
+		}
+		compression = "+gzip"
+		contents = unzipped
+	}
+
+	if protoSyntax.Match(contents) {
+		return core.ProtobufMimeType(compression)
+	}
+
+	// YAML is a superset of JSON, so this handles documents in either format.
+	// Decoding into strings preserves version numbers like "2.0" as written.
+	var doc struct {
+		OpenAPI          string `yaml:"openapi"`
+		Swagger          string `yaml:"swagger"`
+		AsyncAPI         string `yaml:"asyncapi"`
+		DiscoveryVersion string `yaml:"discoveryVersion"`
+		Kind             string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return ""
+	}
+
+	switch {
+	case doc.OpenAPI != "":
+		return core.OpenAPIMimeType(compression, doc.OpenAPI)
+	case doc.Swagger != "":
+		return core.OpenAPIMimeType(compression, doc.Swagger)
+	case doc.AsyncAPI != "":
+		return core.AsyncAPIMimeType(compression, doc.AsyncAPI)
+	case doc.DiscoveryVersion != "" || doc.Kind == "discovery#restDescription":
+		return core.DiscoveryMimeType(compression)
+	}
+
+	return ""
+}
+
+func isGZipCompressed(contents []byte) bool {
+	return bytes.HasPrefix(contents, []byte{0x1f, 0x8b})
+}
+
+func isZipArchive(contents []byte) bool {
+	return bytes.HasPrefix(contents, []byte("PK\x03\x04"))
+}
+
+func zipContainsProtos(contents []byte) bool {
+	r, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return false
+	}
+
+	for _, f := range r.File {
+		if filepath.Ext(f.Name) == ".proto" {
+			return true
+		}
+	}
+	return false
+}
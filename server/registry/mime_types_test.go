@@ -0,0 +1,158 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+)
+
+func TestDetectSpecMimeType(t *testing.T) {
+	tests := []struct {
+		desc     string
+		contents []byte
+		want     string
+	}{
+		{
+			desc: "empty contents",
+		},
+		{
+			desc:     "openapi v3 json",
+			contents: specContents,
+			want:     "application/x.openapi;version=3.0.0",
+		},
+		{
+			desc:     "openapi v3 yaml",
+			contents: []byte("openapi: 3.1.0\ninfo:\n  title: My API\n"),
+			want:     "application/x.openapi;version=3.1.0",
+		},
+		{
+			desc:     "swagger yaml",
+			contents: []byte("swagger: 2.0\ninfo:\n  title: My API\n"),
+			want:     "application/x.openapi;version=2.0",
+		},
+		{
+			desc:     "asyncapi",
+			contents: []byte(`{"asyncapi": "2.2.0", "info": {"title": "My API"}}`),
+			want:     "application/x.asyncapi;version=2.2.0",
+		},
+		{
+			desc:     "discovery",
+			contents: []byte(`{"kind": "discovery#restDescription", "discoveryVersion": "v1"}`),
+			want:     "application/x.discovery",
+		},
+		{
+			desc:     "gzipped openapi",
+			contents: gzipped(t, specContents),
+			want:     "application/x.openapi+gzip;version=3.0.0",
+		},
+		{
+			desc:     "proto file",
+			contents: []byte("// A comment.\nsyntax = \"proto3\";\n"),
+			want:     "application/x.protobuf",
+		},
+		{
+			desc:     "gzipped proto file",
+			contents: gzipped(t, []byte(`syntax = "proto3";`)),
+			want:     "application/x.protobuf+gzip",
+		},
+		{
+			desc:     "proto archive",
+			contents: zipped(t, map[string]string{"a/b.proto": `syntax = "proto3";`}),
+			want:     "application/x.protobuf+zip",
+		},
+		{
+			desc:     "archive without protos",
+			contents: zipped(t, map[string]string{"README.md": "hello"}),
+		},
+		{
+			desc:     "unrecognized yaml",
+			contents: []byte("name: my-api\n"),
+		},
+		{
+			desc:     "plain text",
+			contents: []byte("hello, world"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := detectSpecMimeType(test.contents); got != test.want {
+				t.Errorf("detectSpecMimeType(%q) returned %q, want %q", test.contents, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCreateApiSpecDetectsMimeType(t *testing.T) {
+	tests := []struct {
+		desc string
+		spec *rpc.ApiSpec
+		want string
+	}{
+		{
+			desc: "unset mime type",
+			spec: &rpc.ApiSpec{Contents: specContents},
+			want: "application/x.openapi;version=3.0.0",
+		},
+		{
+			desc: "explicit mime type",
+			spec: &rpc.ApiSpec{MimeType: "application/json", Contents: specContents},
+			want: "application/json",
+		},
+		{
+			desc: "unrecognized contents",
+			spec: &rpc.ApiSpec{Contents: []byte("hello, world")},
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedVersions(ctx, server, &rpc.ApiVersion{
+				Name: "projects/my-project/locations/global/apis/my-api/versions/v1",
+			}); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			req := &rpc.CreateApiSpecRequest{
+				Parent:    "projects/my-project/locations/global/apis/my-api/versions/v1",
+				ApiSpecId: "my-spec",
+				ApiSpec:   test.spec,
+			}
+
+			created, err := server.CreateApiSpec(ctx, req)
+			if err != nil {
+				t.Fatalf("CreateApiSpec(%+v) returned error: %s", req, err)
+			}
+			if created.GetMimeType() != test.want {
+				t.Errorf("CreateApiSpec(%+v) returned mime_type %q, want %q", req, created.GetMimeType(), test.want)
+			}
+
+			got, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: created.GetName()})
+			if err != nil {
+				t.Fatalf("GetApiSpec(%q) returned error: %s", created.GetName(), err)
+			}
+			if got.GetMimeType() != test.want {
+				t.Errorf("GetApiSpec(%q) returned mime_type %q, want %q", created.GetName(), got.GetMimeType(), test.want)
+			}
+		})
+	}
+}
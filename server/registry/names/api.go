@@ -72,6 +72,14 @@ func (a Api) Artifact(id string) Artifact {
 	}
 }
 
+// Normal returns the resource name with normalized identifiers.
+func (a Api) Normal() Api {
+	return Api{
+		ProjectID: normalize(a.ProjectID),
+		ApiID:     normalize(a.ApiID),
+	}
+}
+
 // Parent returns this resource's parent project resource name.
 func (a Api) Parent() string {
 	return fmt.Sprintf("projects/%s/locations/%s", a.ProjectID, Location)
@@ -149,6 +149,55 @@ func (a Artifact) Parent() string {
 	}
 }
 
+// Normal returns the resource name with normalized identifiers.
+func (a Artifact) Normal() Artifact {
+	switch name := a.name.(type) {
+	case projectArtifact:
+		return Artifact{name: projectArtifact{
+			ProjectID:  normalize(name.ProjectID),
+			ArtifactID: normalize(name.ArtifactID),
+		}}
+	case apiArtifact:
+		return Artifact{name: apiArtifact{
+			ProjectID:  normalize(name.ProjectID),
+			ApiID:      normalize(name.ApiID),
+			ArtifactID: normalize(name.ArtifactID),
+		}}
+	case versionArtifact:
+		return Artifact{name: versionArtifact{
+			ProjectID:  normalize(name.ProjectID),
+			ApiID:      normalize(name.ApiID),
+			VersionID:  normalize(name.VersionID),
+			ArtifactID: normalize(name.ArtifactID),
+		}}
+	case specArtifact:
+		return Artifact{name: specArtifact{
+			ProjectID:  normalize(name.ProjectID),
+			ApiID:      normalize(name.ApiID),
+			VersionID:  normalize(name.VersionID),
+			SpecID:     normalize(name.SpecID),
+			ArtifactID: normalize(name.ArtifactID),
+		}}
+	case deploymentArtifact:
+		return Artifact{name: deploymentArtifact{
+			ProjectID:    normalize(name.ProjectID),
+			ApiID:        normalize(name.ApiID),
+			DeploymentID: normalize(name.DeploymentID),
+			ArtifactID:   normalize(name.ArtifactID),
+		}}
+	default:
+		return a
+	}
+}
+
+// Revision returns an artifact revision with the provided ID and this resource as its parent.
+func (a Artifact) Revision(id string) ArtifactRevision {
+	return ArtifactRevision{
+		artifact:   a,
+		RevisionID: id,
+	}
+}
+
 func (a Artifact) String() string {
 	return normalize(a.name.String())
 }
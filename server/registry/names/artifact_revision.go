@@ -0,0 +1,52 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var artifactRevisionRegexp = regexp.MustCompile(fmt.Sprintf("^(.+)@%s$", revisionTag))
+
+// ArtifactRevision represents a resource name for an artifact revision.
+type ArtifactRevision struct {
+	artifact   Artifact
+	RevisionID string
+}
+
+// Artifact returns the parent artifact for this resource.
+func (a ArtifactRevision) Artifact() Artifact {
+	return a.artifact
+}
+
+func (a ArtifactRevision) String() string {
+	return normalize(fmt.Sprintf("%s@%s", a.artifact, a.RevisionID))
+}
+
+// ParseArtifactRevision parses the name of an artifact revision.
+func ParseArtifactRevision(name string) (ArtifactRevision, error) {
+	if !artifactRevisionRegexp.MatchString(name) {
+		return ArtifactRevision{}, fmt.Errorf("invalid artifact revision name %q: must match %q", name, artifactRevisionRegexp)
+	}
+
+	m := artifactRevisionRegexp.FindStringSubmatch(name)
+	artifact, err := ParseArtifact(m[1])
+	if err != nil {
+		return ArtifactRevision{}, fmt.Errorf("invalid artifact revision name %q: %s", name, err)
+	}
+
+	return artifact.Revision(m[2]), nil
+}
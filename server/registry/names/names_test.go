@@ -271,6 +271,28 @@ func TestResourceNames(t *testing.T) {
 			},
 			fail: []string{
 				"-",
+				"projects/google/locations/global/artifacts/test-artifact@1234567890abcdef",
+			},
+		},
+		{
+			name: "artifact revision",
+			check: func(name string) bool {
+				_, err := ParseArtifactRevision(name)
+				return err == nil
+			},
+			pass: []string{
+				"projects/google/locations/global/artifacts/test-artifact@1234567890abcdef",
+				"projects/google/locations/global/apis/sample/artifacts/test-artifact@1234567890abcdef",
+				"projects/google/locations/global/apis/sample/versions/v1/artifacts/test-artifact@1234567890abcdef",
+				"projects/google/locations/global/apis/sample/versions/v1/specs/openapi.yaml/artifacts/test-artifact@1234567890abcdef",
+				"projects/google/locations/global/apis/sample/deployments/prod/artifacts/test-artifact@1234567890abcdef",
+			},
+			fail: []string{
+				"-",
+				"invalid",
+				"projects/google/locations/global/artifacts/test-artifact",
+				"projects/google/locations/global/apis/sample/versions/v1/specs/openapi.yaml@1234567890abcdef",
+				"projects/google/locations/global/apis/sample@1234567890abcdef",
 			},
 		},
 	}
@@ -72,6 +72,15 @@ func (v Version) Spec(id string) Spec {
 	}
 }
 
+// Normal returns the resource name with normalized identifiers.
+func (v Version) Normal() Version {
+	return Version{
+		ProjectID: normalize(v.ProjectID),
+		ApiID:     normalize(v.ApiID),
+		VersionID: normalize(v.VersionID),
+	}
+}
+
 // Parent returns this resource's parent API resource name.
 func (v Version) Parent() string {
 	return v.Api().String()
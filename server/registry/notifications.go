@@ -16,10 +16,13 @@ package registry
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/pubsub"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/metrics"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -28,7 +31,62 @@ import (
 
 const TopicName = "registry-events"
 
+// notificationStats counts the notifications sent by a server.
+type notificationStats struct {
+	mutex         sync.Mutex
+	published     int64
+	failed        int64
+	lastError     string
+	lastErrorTime time.Time
+}
+
+func (n *notificationStats) succeeded() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.published++
+}
+
+func (n *notificationStats) fail(err error) {
+	metrics.NotificationFailed()
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.failed++
+	n.lastError = err.Error()
+	n.lastErrorTime = time.Now()
+}
+
+func (n *notificationStats) status() *rpc.Status_Notifications {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	status := &rpc.Status_Notifications{
+		Published: n.published,
+		Failed:    n.failed,
+		LastError: n.lastError,
+	}
+	if !n.lastErrorTime.IsZero() {
+		status.LastErrorTime = timestamppb.New(n.lastErrorTime)
+	}
+	return status
+}
+
 func (s *RegistryServer) notify(ctx context.Context, change rpc.Notification_Change, resource string) {
+	s.publish(ctx, &rpc.Notification{
+		Change:   change,
+		Resource: resource,
+	})
+}
+
+// notifyTransition publishes a notification that a resource moved to another state of its project's lifecycle.
+func (s *RegistryServer) notifyTransition(ctx context.Context, resource, from, to string) {
+	s.publish(ctx, &rpc.Notification{
+		Change:        rpc.Notification_TRANSITIONED,
+		Resource:      resource,
+		PreviousState: from,
+		State:         to,
+	})
+}
+
+func (s *RegistryServer) publish(ctx context.Context, notification *rpc.Notification) {
 	if !s.notifyEnabled {
 		return
 	}
@@ -42,24 +100,22 @@ func (s *RegistryServer) notify(ctx context.Context, change rpc.Notification_Cha
 	client, err := pubsub.NewClient(ctx, s.projectID)
 	if err != nil {
 		logger.WithError(err).Error("Failed to create PubSub client.")
+		s.notifications.fail(err)
 		return
 	}
 	defer client.Close()
 
 	if _, err := client.CreateTopic(ctx, TopicName); err != nil && status.Code(err) != codes.AlreadyExists {
 		logger.WithError(err).Error("Failed to create PubSub topic.")
+		s.notifications.fail(err)
 		return
 	}
 
-	notification := &rpc.Notification{
-		Change:     change,
-		Resource:   resource,
-		ChangeTime: timestamppb.Now(),
-	}
-
+	notification.ChangeTime = timestamppb.Now()
 	msg, err := protojson.Marshal(notification)
 	if err != nil {
 		logger.WithError(err).Errorf("Failed to serialize notification: %v", notification)
+		s.notifications.fail(err)
 		return
 	}
 
@@ -73,8 +129,10 @@ func (s *RegistryServer) notify(ctx context.Context, change rpc.Notification_Cha
 	id, err := result.Get(ctx)
 	if err != nil {
 		logger.WithError(err).Error("Failed to publish notification.")
+		s.notifications.fail(err)
 		return
 	}
+	s.notifications.succeeded()
 
 	logger.Infof("Published notification with message ID: %s", id)
 }
@@ -0,0 +1,99 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QuotaLimits limit the resources stored in a project. Zero values are unlimited.
+type QuotaLimits struct {
+	// MaxApis is the maximum number of APIs in a project.
+	MaxApis int64
+	// MaxSpecRevisions is the maximum number of revisions of all specs in a project.
+	MaxSpecRevisions int64
+	// MaxBlobBytes is the maximum total size of all spec and artifact contents in a project.
+	MaxBlobBytes int64
+}
+
+func (l QuotaLimits) unlimited() bool {
+	return l.MaxApis <= 0 && l.MaxSpecRevisions <= 0 && l.MaxBlobBytes <= 0
+}
+
+// Quotas configures the limits for each project.
+type Quotas struct {
+	// Default limits apply to projects that aren't listed in Projects.
+	Default QuotaLimits
+	// Projects replaces the default limits for specific projects, keyed by project ID.
+	Projects map[string]QuotaLimits
+}
+
+func (q Quotas) limits(projectID string) QuotaLimits {
+	if l, ok := q.Projects[projectID]; ok {
+		return l
+	}
+	return q.Default
+}
+
+// quotaRequest describes the resources that a request will add to a project.
+type quotaRequest struct {
+	apis          int64
+	specRevisions int64
+	blobBytes     int64
+}
+
+// checkQuota returns a RESOURCE_EXHAUSTED error if adding the requested resources
+// would exceed the limits of the project.
+func (s *RegistryServer) checkQuota(ctx context.Context, db *storage.Client, project names.Project, req quotaRequest) error {
+	limits := s.quotas.limits(project.ProjectID)
+	if limits.unlimited() {
+		return nil
+	}
+
+	usage, err := db.GetProjectUsage(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	var violations []*errdetails.QuotaFailure_Violation
+	check := func(requested, used, limit int64, resource string) {
+		if requested > 0 && limit > 0 && used+requested > limit {
+			violations = append(violations, &errdetails.QuotaFailure_Violation{
+				Subject:     project.String(),
+				Description: fmt.Sprintf("quota of %d %s exceeded (using %d, requested %d)", limit, resource, used, requested),
+			})
+		}
+	}
+	check(req.apis, usage.Apis, limits.MaxApis, "APIs")
+	check(req.specRevisions, usage.SpecRevisions, limits.MaxSpecRevisions, "spec revisions")
+	check(req.blobBytes, usage.BlobBytes, limits.MaxBlobBytes, "bytes of contents")
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("%s: %s", project, violations[0].GetDescription()))
+	if detailed, err := st.WithDetails(&errdetails.QuotaFailure{Violations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
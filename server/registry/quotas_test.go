@@ -0,0 +1,224 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func assertResourceExhausted(t *testing.T, err error) {
+	t.Helper()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected error code %s, got: %v", codes.ResourceExhausted, err)
+	}
+	for _, d := range status.Convert(err).Details() {
+		if _, ok := d.(*errdetails.QuotaFailure); ok {
+			return
+		}
+	}
+	t.Errorf("expected QuotaFailure details in error: %v", err)
+}
+
+func TestApiQuota(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.quotas = Quotas{
+		Default: QuotaLimits{MaxApis: 2},
+	}
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	for _, id := range []string{"a", "b"} {
+		req := &rpc.CreateApiRequest{
+			Parent: "projects/my-project/locations/global",
+			ApiId:  id,
+			Api:    &rpc.Api{},
+		}
+		if _, err := server.CreateApi(ctx, req); err != nil {
+			t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+		}
+	}
+
+	req := &rpc.CreateApiRequest{
+		Parent: "projects/my-project/locations/global",
+		ApiId:  "c",
+		Api:    &rpc.Api{},
+	}
+	_, err := server.CreateApi(ctx, req)
+	assertResourceExhausted(t, err)
+
+	// Deleting an API frees quota for another.
+	if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: "projects/my-project/locations/global/apis/a"}); err != nil {
+		t.Fatalf("DeleteApi returned error: %s", err)
+	}
+	if _, err := server.CreateApi(ctx, req); err != nil {
+		t.Errorf("CreateApi(%+v) returned error after delete: %s", req, err)
+	}
+}
+
+func TestSpecRevisionQuota(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.quotas = Quotas{
+		Projects: map[string]QuotaLimits{
+			"my-project": {MaxSpecRevisions: 2},
+		},
+	}
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: retentionTestSpec}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	addSpecRevisions(ctx, t, server, "contents", 1)
+
+	req := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     retentionTestSpec,
+			Contents: []byte("more contents"),
+		},
+	}
+	_, err := server.UpdateApiSpec(ctx, req)
+	assertResourceExhausted(t, err)
+
+	// Updates that don't create a revision are still allowed.
+	req = &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:        retentionTestSpec,
+			Description: "updated",
+		},
+	}
+	if _, err := server.UpdateApiSpec(ctx, req); err != nil {
+		t.Errorf("UpdateApiSpec(%+v) returned error: %s", req, err)
+	}
+}
+
+func TestBlobBytesQuota(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.quotas = Quotas{
+		Default: QuotaLimits{MaxBlobBytes: 10},
+	}
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CreateArtifactRequest{
+		Parent:     "projects/my-project/locations/global",
+		ArtifactId: "small",
+		Artifact:   &rpc.Artifact{Contents: []byte("12345678")},
+	}
+	if _, err := server.CreateArtifact(ctx, req); err != nil {
+		t.Fatalf("CreateArtifact(%+v) returned error: %s", req, err)
+	}
+
+	req = &rpc.CreateArtifactRequest{
+		Parent:     "projects/my-project/locations/global",
+		ArtifactId: "large",
+		Artifact:   &rpc.Artifact{Contents: []byte("12345")},
+	}
+	_, err := server.CreateArtifact(ctx, req)
+	assertResourceExhausted(t, err)
+
+	// Other projects have their own quota.
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/other-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	req.Parent = "projects/other-project/locations/global"
+	if _, err := server.CreateArtifact(ctx, req); err != nil {
+		t.Errorf("CreateArtifact(%+v) returned error: %s", req, err)
+	}
+}
+
+func TestBlobBytesQuotaUnchangedContents(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.quotas = Quotas{
+		Default: QuotaLimits{MaxBlobBytes: 10},
+	}
+	const spec = "projects/my-project/locations/global/apis/a/versions/v1/specs/s"
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: spec, Contents: []byte("12345678")}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// Contents that match the current revision don't use more of the quota.
+	req := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: spec, Contents: []byte("12345678")},
+	}
+	if _, err := server.UpdateApiSpec(ctx, req); err != nil {
+		t.Errorf("UpdateApiSpec(%+v) returned error: %s", req, err)
+	}
+
+	req.ApiSpec.Contents = []byte("87654321")
+	_, err := server.UpdateApiSpec(ctx, req)
+	assertResourceExhausted(t, err)
+}
+
+func TestBlobBytesQuotaGzippedContents(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.quotas = Quotas{
+		Default: QuotaLimits{MaxBlobBytes: 1000},
+	}
+	const spec = "projects/my-project/locations/global/apis/a/versions/v1/specs/s"
+	if err := seeder.SeedVersions(ctx, server, &rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/a/versions/v1"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	// Gzipped contents are charged at their uncompressed size, which is what is counted as used.
+	gzipped := func(b byte) []byte {
+		t.Helper()
+		contents, err := core.GZippedBytes(bytes.Repeat([]byte{b}, 600))
+		if err != nil {
+			t.Fatalf("Setup: GZippedBytes() returned error: %s", err)
+		}
+		return contents
+	}
+	create := &rpc.CreateApiSpecRequest{
+		Parent:    "projects/my-project/locations/global/apis/a/versions/v1",
+		ApiSpecId: "s",
+		ApiSpec:   &rpc.ApiSpec{MimeType: "text/plain+gzip", Contents: gzipped('a')},
+	}
+	if _, err := server.CreateApiSpec(ctx, create); err != nil {
+		t.Fatalf("CreateApiSpec(%+v) returned error: %s", create, err)
+	}
+
+	update := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{Name: spec, MimeType: "text/plain+gzip", Contents: gzipped('b')},
+	}
+	_, err := server.UpdateApiSpec(ctx, update)
+	assertResourceExhausted(t, err)
+
+	create.ApiSpecId = "t"
+	create.ApiSpec.Contents = gzipped('c')
+	_, err = server.CreateApiSpec(ctx, create)
+	assertResourceExhausted(t, err)
+
+	artifact := &rpc.CreateArtifactRequest{
+		Parent:     "projects/my-project/locations/global",
+		ArtifactId: "x",
+		Artifact:   &rpc.Artifact{MimeType: "text/plain+gzip", Contents: gzipped('d')},
+	}
+	_, err = server.CreateArtifact(ctx, artifact)
+	assertResourceExhausted(t, err)
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"strings"
+
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// validateReadMask returns an error if the mask names fields that don't exist on the message,
+// as described at https://google.aip.dev/157.
+func validateReadMask(m proto.Message, mask *fieldmaskpb.FieldMask) error {
+	if err := models.ValidateMask(m, mask); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid read_mask %v: %s", mask, err)
+	}
+	return nil
+}
+
+// readsAllFields returns true if the mask selects every field of a resource.
+func readsAllFields(mask *fieldmaskpb.FieldMask) bool {
+	paths := mask.GetPaths()
+	return len(paths) == 0 || (len(paths) == 1 && paths[0] == "*")
+}
+
+// readMaskFields returns the top-level fields selected by a mask, or nil if all fields are selected.
+func readMaskFields(mask *fieldmaskpb.FieldMask) []string {
+	if readsAllFields(mask) {
+		return nil
+	}
+	fields := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		fields = append(fields, strings.SplitN(path, ".", 2)[0])
+	}
+	return fields
+}
+
+// applyReadMask clears all fields of a message that aren't selected by a mask.
+// The mask is expected to have been checked with validateReadMask.
+func applyReadMask(m proto.Message, mask *fieldmaskpb.FieldMask) {
+	if readsAllFields(mask) {
+		return
+	}
+	tree := make(maskTree)
+	for _, path := range mask.GetPaths() {
+		tree.add(strings.Split(path, "."))
+	}
+	tree.prune(m.ProtoReflect())
+}
+
+// maskTree is a tree of field names built from the paths of a field mask.
+// Leaf nodes select the entire value of a field.
+type maskTree map[string]maskTree
+
+func (t maskTree) add(path []string) {
+	child, ok := t[path[0]]
+	if ok && len(child) == 0 {
+		return // The field is already selected entirely.
+	}
+	if len(path) == 1 {
+		t[path[0]] = maskTree{}
+		return
+	}
+	if !ok {
+		child = make(maskTree)
+		t[path[0]] = child
+	}
+	child.add(path[1:])
+}
+
+func (t maskTree) prune(m protoreflect.Message) {
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		child, ok := t[string(field.Name())]
+		switch {
+		case !ok:
+			m.Clear(field)
+		case len(child) > 0 && field.Message() != nil && !field.IsList() && !field.IsMap():
+			child.prune(value.Message())
+		}
+		return true // Continue iterating.
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func seedReadMaskApis(ctx context.Context, t *testing.T, server *RegistryServer) {
+	t.Helper()
+	err := seeder.SeedApis(ctx, server,
+		&rpc.Api{
+			Name:        "projects/my-project/locations/global/apis/a",
+			DisplayName: "A",
+			Description: "The first API",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"owner": "alice"},
+		},
+		&rpc.Api{
+			Name:        "projects/my-project/locations/global/apis/b",
+			DisplayName: "B",
+			Description: "The second API",
+			Labels:      map[string]string{"team": "b"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+}
+
+func TestGetApiReadMask(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedReadMaskApis(ctx, t, server)
+
+	tests := []struct {
+		desc string
+		mask *fieldmaskpb.FieldMask
+		want *rpc.Api
+	}{
+		{
+			desc: "selected fields",
+			mask: &fieldmaskpb.FieldMask{Paths: []string{"name", "labels"}},
+			want: &rpc.Api{
+				Name:   "projects/my-project/locations/global/apis/a",
+				Labels: map[string]string{"team": "a"},
+			},
+		},
+		{
+			desc: "wildcard",
+			mask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			want: &rpc.Api{
+				Name:        "projects/my-project/locations/global/apis/a",
+				DisplayName: "A",
+				Description: "The first API",
+				Labels:      map[string]string{"team": "a"},
+				Annotations: map[string]string{"owner": "alice"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &rpc.GetApiRequest{
+				Name:     "projects/my-project/locations/global/apis/a",
+				ReadMask: test.mask,
+			}
+			got, err := server.GetApi(ctx, req)
+			if err != nil {
+				t.Fatalf("GetApi(%+v) returned error: %s", req, err)
+			}
+			opts := protocmp.IgnoreFields(new(rpc.Api), "create_time", "update_time")
+			if diff := cmp.Diff(test.want, got, protocmp.Transform(), opts); diff != "" {
+				t.Errorf("GetApi(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+			}
+		})
+	}
+}
+
+func TestListApisReadMask(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedReadMaskApis(ctx, t, server)
+
+	tests := []struct {
+		desc   string
+		filter string
+	}{
+		{desc: "unfiltered"},
+		{desc: "filtered", filter: "description.contains('API')"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &rpc.ListApisRequest{
+				Parent:   "projects/my-project/locations/global",
+				Filter:   test.filter,
+				ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "display_name"}},
+			}
+			got, err := server.ListApis(ctx, req)
+			if err != nil {
+				t.Fatalf("ListApis(%+v) returned error: %s", req, err)
+			}
+			want := &rpc.ListApisResponse{
+				Apis: []*rpc.Api{
+					{Name: "projects/my-project/locations/global/apis/a", DisplayName: "A"},
+					{Name: "projects/my-project/locations/global/apis/b", DisplayName: "B"},
+				},
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ListApis(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+			}
+		})
+	}
+}
+
+func TestListApiSpecsReadMask(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{
+		Name:        "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+		Description: "A spec",
+		MimeType:    "text/plain",
+		Contents:    []byte("contents"),
+		Labels:      map[string]string{"team": "a"},
+	}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.ListApiSpecsRequest{
+		Parent:   "projects/my-project/locations/global/apis/a/versions/v1",
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "mime_type", "labels"}},
+	}
+	got, err := server.ListApiSpecs(ctx, req)
+	if err != nil {
+		t.Fatalf("ListApiSpecs(%+v) returned error: %s", req, err)
+	}
+	want := &rpc.ListApiSpecsResponse{
+		ApiSpecs: []*rpc.ApiSpec{
+			{
+				Name:     "projects/my-project/locations/global/apis/a/versions/v1/specs/s",
+				MimeType: "text/plain",
+				Labels:   map[string]string{"team": "a"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ListApiSpecs(%+v) returned unexpected diff (-want +got):\n%s", req, diff)
+	}
+}
+
+func TestInvalidReadMask(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seedReadMaskApis(ctx, t, server)
+
+	for _, paths := range [][]string{
+		{"unknown"},
+		{"name", "*"},
+		{"labels.team.value"},
+	} {
+		mask := &fieldmaskpb.FieldMask{Paths: paths}
+		get := &rpc.GetApiRequest{
+			Name:     "projects/my-project/locations/global/apis/a",
+			ReadMask: mask,
+		}
+		if _, err := server.GetApi(ctx, get); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetApi(%+v) returned status code %q, want %q: %v", get, status.Code(err), codes.InvalidArgument, err)
+		}
+		list := &rpc.ListApisRequest{
+			Parent:   "projects/my-project/locations/global",
+			ReadMask: mask,
+		}
+		if _, err := server.ListApis(ctx, list); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ListApis(%+v) returned status code %q, want %q: %v", list, status.Code(err), codes.InvalidArgument, err)
+		}
+	}
+}
+
+func TestApplyReadMaskNestedFields(t *testing.T) {
+	api := &rpc.Api{
+		Name:       "projects/my-project/locations/global/apis/a",
+		CreateTime: &timestamppb.Timestamp{Seconds: 100, Nanos: 5},
+		UpdateTime: &timestamppb.Timestamp{Seconds: 200},
+	}
+	applyReadMask(api, &fieldmaskpb.FieldMask{Paths: []string{"create_time.seconds"}})
+	want := &rpc.Api{CreateTime: &timestamppb.Timestamp{Seconds: 100}}
+	if diff := cmp.Diff(want, api, protocmp.Transform()); diff != "" {
+		t.Errorf("applyReadMask returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Fields that refer to other resources.
+const (
+	recommendedVersionField    = "recommended_version"
+	recommendedDeploymentField = "recommended_deployment"
+	apiSpecRevisionField       = "api_spec_revision"
+)
+
+// reference is a field of a stored resource that names another resource.
+type reference struct {
+	resource string // The name of the resource that contains the field.
+	field    string // The name of the field.
+	value    string // The stored value of the field.
+	target   string // The full name of the referenced resource.
+}
+
+// ListReferences handles the corresponding API request.
+func (s *RegistryServer) ListReferences(ctx context.Context, req *rpc.ListReferencesRequest) (*rpc.ListReferencesResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	var (
+		project names.Project
+		target  string
+		current string // The current revision of a spec, for references to the spec without a revision.
+	)
+	if name, nameErr := names.ParseVersion(req.GetName()); nameErr == nil {
+		if _, err := db.GetVersion(ctx, name); err != nil {
+			return nil, err
+		}
+		project, target = name.Project(), name.String()
+	} else if name, nameErr := names.ParseSpec(req.GetName()); nameErr == nil {
+		if _, err := db.GetSpec(ctx, name); err != nil {
+			return nil, err
+		}
+		project, target = name.Project(), name.String()
+	} else if name, nameErr := names.ParseSpecRevision(req.GetName()); nameErr == nil {
+		revision, err := db.GetSpecRevision(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		spec, err := db.GetSpec(ctx, name.Spec())
+		if err != nil {
+			return nil, err
+		}
+		if spec.RevisionID == revision.RevisionID {
+			current = spec.Name()
+		}
+		project, target = name.Spec().Project(), revision.RevisionName()
+	} else if name, nameErr := names.ParseDeployment(req.GetName()); nameErr == nil {
+		if _, err := db.GetDeployment(ctx, name); err != nil {
+			return nil, err
+		}
+		project, target = name.Project(), name.String()
+	} else if name, nameErr := names.ParseDeploymentRevision(req.GetName()); nameErr == nil {
+		revision, err := db.GetDeploymentRevision(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		project, target = name.Deployment().Project(), revision.RevisionName()
+	} else {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, must be a version, spec, deployment or revision", req.GetName())
+	}
+
+	refs, err := listReferences(ctx, db, project)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.ListReferencesResponse{
+		References: make([]*rpc.ListReferencesResponse_Reference, 0),
+	}
+	for _, ref := range refs {
+		if refersTo(ref.target, target) || (current != "" && ref.target == current) {
+			response.References = append(response.References, &rpc.ListReferencesResponse_Reference{
+				Resource: ref.resource,
+				Field:    ref.field,
+				Value:    ref.value,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// listReferences returns the references stored in the apis and deployments of a project, ordered by resource name.
+// Revision tags in the referenced names are replaced by the revision IDs that they name.
+func listReferences(ctx context.Context, db *storage.Client, project names.Project) ([]reference, error) {
+	refs := make([]reference, 0)
+	add := func(resource, field, value string) error {
+		if value == "" {
+			return nil
+		}
+		target, err := resolveRevisionTag(ctx, db, qualifiedName(project, value))
+		if err != nil {
+			return err
+		}
+		refs = append(refs, reference{resource: resource, field: field, value: value, target: target})
+		return nil
+	}
+
+	apis, err := listAllApis(ctx, db, project)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, api := range apis {
+		if err := add(api.Name(), recommendedVersionField, api.RecommendedVersion); err != nil {
+			return nil, err
+		}
+		if err := add(api.Name(), recommendedDeploymentField, api.RecommendedDeployment); err != nil {
+			return nil, err
+		}
+	}
+
+	deployments, err := listAllDeployments(ctx, db, project.Api("-"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deployments {
+		if err := add(deployment.Name(), apiSpecRevisionField, deployment.ApiSpecRevision); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].resource < refs[j].resource
+	})
+	return refs, nil
+}
+
+// checkReferences returns a FAILED_PRECONDITION error if a resource that is being deleted is
+// referenced by a resource that isn't also being deleted. References to resources contained by
+// the target, such as the specs of a version, are included unless the target is a revision.
+func checkReferences(ctx context.Context, db *storage.Client, project names.Project, target string) error {
+	refs, err := listReferences(ctx, db, project)
+	if err != nil {
+		return err
+	}
+
+	var violations []*errdetails.PreconditionFailure_Violation
+	for _, ref := range refs {
+		if !refersTo(ref.target, target) || refersTo(ref.resource, target) {
+			continue
+		}
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "REFERENCE",
+			Subject:     ref.resource,
+			Description: fmt.Sprintf("%s of %s refers to %s", ref.field, ref.resource, ref.value),
+		})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.FailedPrecondition, fmt.Sprintf("%s can't be deleted: %s", target, violations[0].GetDescription()))
+	if detailed, err := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// addReferencedRevisions adds the IDs of the revisions of a spec or deployment that other resources refer to.
+func addReferencedRevisions(ctx context.Context, db *storage.Client, project names.Project, resource string, revisions map[string]bool) error {
+	refs, err := listReferences(ctx, db, project)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if id := strings.TrimPrefix(ref.target, resource+"@"); id != ref.target {
+			revisions[id] = true
+		}
+	}
+	return nil
+}
+
+// refersTo reports whether a name is the target or the name of a resource that the target contains.
+// A revision is contained by its resource, but a resource isn't contained by one of its revisions.
+func refersTo(name, target string) bool {
+	return name == target || strings.HasPrefix(name, target+"/") || strings.HasPrefix(name, target+"@")
+}
+
+// qualifiedName returns a name that is relative to a project as a full resource name.
+func qualifiedName(project names.Project, name string) string {
+	if strings.HasPrefix(name, "apis/") {
+		return fmt.Sprintf("%s/locations/%s/%s", project, names.Location, name)
+	}
+	return name
+}
+
+// resolveRevisionTag returns a spec or deployment revision name with a tag replaced by the revision ID
+// that it names. Other names, and revisions that don't exist, are returned unchanged.
+func resolveRevisionTag(ctx context.Context, db *storage.Client, name string) (string, error) {
+	if spec, err := names.ParseSpecRevision(name); err == nil {
+		revision, err := db.GetSpecRevision(ctx, spec)
+		if isNotFound(err) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+		return revision.RevisionName(), nil
+	}
+	if deployment, err := names.ParseDeploymentRevision(name); err == nil {
+		revision, err := db.GetDeploymentRevision(ctx, deployment)
+		if isNotFound(err) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+		return revision.RevisionName(), nil
+	}
+	return name, nil
+}
+
+// validateVersionReference checks that a recommended version names an existing version of an api.
+func validateVersionReference(ctx context.Context, db *storage.Client, api names.Api, value string) error {
+	name, err := names.ParseVersion(qualifiedName(api.Project(), value))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a version name", recommendedVersionField, value)
+	} else if name.Api() != api {
+		return status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a version of %s", recommendedVersionField, value, api)
+	}
+
+	if _, err := db.GetVersion(ctx, name); isNotFound(err) {
+		return status.Errorf(codes.InvalidArgument, "invalid %s %q: version does not exist", recommendedVersionField, value)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// resolveDeploymentReference checks that a recommended deployment names an existing deployment or
+// deployment revision of an api, and returns the value with a revision tag replaced by its revision ID.
+func resolveDeploymentReference(ctx context.Context, db *storage.Client, api names.Api, value string) (string, error) {
+	full := qualifiedName(api.Project(), value)
+	if name, err := names.ParseDeployment(full); err == nil {
+		if name.Api() != api {
+			return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a deployment of %s", recommendedDeploymentField, value, api)
+		}
+		if _, err := db.GetDeployment(ctx, name); isNotFound(err) {
+			return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: deployment does not exist", recommendedDeploymentField, value)
+		} else if err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	name, err := names.ParseDeploymentRevision(full)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a deployment or deployment revision name", recommendedDeploymentField, value)
+	} else if name.Deployment().Api() != api {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a deployment of %s", recommendedDeploymentField, value, api)
+	}
+
+	revision, err := db.GetDeploymentRevision(ctx, name)
+	if isNotFound(err) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: deployment revision does not exist", recommendedDeploymentField, value)
+	} else if err != nil {
+		return "", err
+	}
+	return withRevisionID(value, revision.RevisionID), nil
+}
+
+// resolveSpecReference checks that the spec served by a deployment names an existing spec or spec
+// revision in the same project, and returns the value with a revision tag replaced by its revision ID.
+func resolveSpecReference(ctx context.Context, db *storage.Client, project names.Project, value string) (string, error) {
+	full := qualifiedName(project, value)
+	if name, err := names.ParseSpec(full); err == nil {
+		if name.Project() != project {
+			return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a spec in %s", apiSpecRevisionField, value, project)
+		}
+		if _, err := db.GetSpec(ctx, name); isNotFound(err) {
+			return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: spec does not exist", apiSpecRevisionField, value)
+		} else if err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+
+	name, err := names.ParseSpecRevision(full)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a spec or spec revision name", apiSpecRevisionField, value)
+	} else if name.Spec().Project() != project {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: must be a spec in %s", apiSpecRevisionField, value, project)
+	}
+
+	revision, err := db.GetSpecRevision(ctx, name)
+	if isNotFound(err) {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s %q: spec revision does not exist", apiSpecRevisionField, value)
+	} else if err != nil {
+		return "", err
+	}
+	return withRevisionID(value, revision.RevisionID), nil
+}
+
+// withRevisionID replaces the revision ID or tag at the end of a revision name.
+func withRevisionID(name, revisionID string) string {
+	return name[:strings.LastIndex(name, "@")+1] + revisionID
+}
+
+// validateApiReferences checks the references of an api that differ from a previous value,
+// replacing revision tags with revision IDs. References that haven't changed aren't checked,
+// so that updates of other fields succeed even if an existing reference has become invalid.
+func validateApiReferences(ctx context.Context, db *storage.Client, api, previous *models.Api) error {
+	name := names.Api{ProjectID: api.ProjectID, ApiID: api.ApiID}
+	if api.RecommendedVersion != "" && api.RecommendedVersion != previous.RecommendedVersion {
+		if err := validateVersionReference(ctx, db, name, api.RecommendedVersion); err != nil {
+			return err
+		}
+	}
+	if api.RecommendedDeployment != "" && api.RecommendedDeployment != previous.RecommendedDeployment {
+		value, err := resolveDeploymentReference(ctx, db, name, api.RecommendedDeployment)
+		if err != nil {
+			return err
+		}
+		api.RecommendedDeployment = value
+	}
+	return nil
+}
+
+// validateDeploymentReferences checks the spec of a deployment if it differs from a previous value,
+// replacing a revision tag with its revision ID.
+func validateDeploymentReferences(ctx context.Context, db *storage.Client, deployment *models.Deployment, previous string) error {
+	if deployment.ApiSpecRevision == "" || deployment.ApiSpecRevision == previous {
+		return nil
+	}
+	value, err := resolveSpecReference(ctx, db, names.Project{ProjectID: deployment.ProjectID}, deployment.ApiSpecRevision)
+	if err != nil {
+		return err
+	}
+	deployment.ApiSpecRevision = value
+	return nil
+}
+
+func listAllApis(ctx context.Context, db *storage.Client, parent names.Project) ([]models.Api, error) {
+	apis := make([]models.Api, 0)
+	for token := ""; ; {
+		listing, err := db.ListApis(ctx, parent, storage.PageOptions{Size: 1000, Token: token})
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, listing.Apis...)
+		if token = listing.Token; token == "" {
+			return apis, nil
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2021 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestReferences(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	const (
+		api     = "projects/my-project/locations/global/apis/a"
+		version = api + "/versions/v1"
+		spec    = version + "/specs/s"
+	)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: spec, Contents: []byte("first"), RevisionTags: []string{"stable"}},
+		&rpc.ApiSpec{Name: spec, Contents: []byte("second")},
+		&rpc.ApiVersion{Name: api + "/versions/v2"},
+		&rpc.ApiDeployment{Name: api + "/deployments/d"},
+		&rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/b/versions/v1"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	stable, err := server.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec + "@stable"})
+	if err != nil {
+		t.Fatalf("Setup: GetApiSpec(%q) returned error: %s", spec+"@stable", err)
+	}
+
+	t.Run("invalid references", func(t *testing.T) {
+		for _, body := range []*rpc.Api{
+			{Name: api, RecommendedVersion: "apis/a/versions/missing"},
+			{Name: api, RecommendedVersion: "projects/my-project/locations/global/apis/b/versions/v1"},
+			{Name: api, RecommendedVersion: "v1"},
+			{Name: api, RecommendedDeployment: "apis/a/deployments/missing"},
+			{Name: api, RecommendedDeployment: "apis/a/deployments/d@missing"},
+		} {
+			req := &rpc.UpdateApiRequest{Api: body}
+			if _, err := server.UpdateApi(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("UpdateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+			}
+		}
+
+		for _, ref := range []string{
+			"apis/a/versions/v1/specs/missing",
+			"apis/a/versions/v1/specs/s@missing",
+			"projects/other-project/locations/global/apis/a/versions/v1/specs/s",
+		} {
+			req := &rpc.UpdateApiDeploymentRequest{ApiDeployment: &rpc.ApiDeployment{Name: api + "/deployments/d", ApiSpecRevision: ref}}
+			if _, err := server.UpdateApiDeployment(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("UpdateApiDeployment(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+			}
+		}
+	})
+
+	deployment, err := server.UpdateApiDeployment(ctx, &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{Name: api + "/deployments/d", ApiSpecRevision: "apis/a/versions/v1/specs/s@stable"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateApiDeployment() returned error: %s", err)
+	}
+	if want := "apis/a/versions/v1/specs/s@" + stable.GetRevisionId(); deployment.GetApiSpecRevision() != want {
+		t.Errorf("UpdateApiDeployment() returned api_spec_revision %q, want tag resolved as %q", deployment.GetApiSpecRevision(), want)
+	}
+
+	// Setting the same tag again resolves to the stored revision, which isn't a change.
+	unchanged, err := server.UpdateApiDeployment(ctx, &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{Name: api + "/deployments/d", ApiSpecRevision: "apis/a/versions/v1/specs/s@stable"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateApiDeployment() returned error: %s", err)
+	}
+	if unchanged.GetRevisionId() != deployment.GetRevisionId() {
+		t.Errorf("UpdateApiDeployment() with an unchanged tagged spec created revision %q, want %q", unchanged.GetRevisionId(), deployment.GetRevisionId())
+	}
+
+	if _, err := server.UpdateApi(ctx, &rpc.UpdateApiRequest{
+		Api: &rpc.Api{
+			Name:                  api,
+			RecommendedVersion:    "apis/a/versions/v1",
+			RecommendedDeployment: api + "/deployments/d",
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"recommended_version", "recommended_deployment"}},
+	}); err != nil {
+		t.Fatalf("UpdateApi() returned error: %s", err)
+	}
+
+	t.Run("list references", func(t *testing.T) {
+		specRef := &rpc.ListReferencesResponse_Reference{
+			Resource: api + "/deployments/d",
+			Field:    "api_spec_revision",
+			Value:    deployment.GetApiSpecRevision(),
+		}
+		tests := []struct {
+			name string
+			want []*rpc.ListReferencesResponse_Reference
+		}{
+			{
+				name: version,
+				want: []*rpc.ListReferencesResponse_Reference{
+					{Resource: api, Field: "recommended_version", Value: "apis/a/versions/v1"},
+					specRef,
+				},
+			},
+			{
+				name: spec,
+				want: []*rpc.ListReferencesResponse_Reference{specRef},
+			},
+			{
+				name: spec + "@stable",
+				want: []*rpc.ListReferencesResponse_Reference{specRef},
+			},
+			{
+				name: api + "/versions/v2",
+				want: []*rpc.ListReferencesResponse_Reference{},
+			},
+			{
+				name: api + "/deployments/d",
+				want: []*rpc.ListReferencesResponse_Reference{
+					{Resource: api, Field: "recommended_deployment", Value: api + "/deployments/d"},
+				},
+			},
+		}
+
+		for _, test := range tests {
+			got, err := server.ListReferences(ctx, &rpc.ListReferencesRequest{Name: test.name})
+			if err != nil {
+				t.Fatalf("ListReferences(%q) returned error: %s", test.name, err)
+			}
+			if !cmp.Equal(test.want, got.GetReferences(), protocmp.Transform()) {
+				t.Errorf("ListReferences(%q) returned unexpected diff (-want +got):\n%s", test.name, cmp.Diff(test.want, got.GetReferences(), protocmp.Transform()))
+			}
+		}
+
+		if _, err := server.ListReferences(ctx, &rpc.ListReferencesRequest{Name: api + "/versions/missing"}); status.Code(err) != codes.NotFound {
+			t.Errorf("ListReferences() of a missing version returned status code %q, want %q: %v", status.Code(err), codes.NotFound, err)
+		}
+	})
+
+	t.Run("delete referenced resources", func(t *testing.T) {
+		for desc, del := range map[string]func() error{
+			"version": func() error {
+				_, err := server.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: version})
+				return err
+			},
+			"spec": func() error {
+				_, err := server.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: spec})
+				return err
+			},
+			"spec revision": func() error {
+				_, err := server.DeleteApiSpecRevision(ctx, &rpc.DeleteApiSpecRevisionRequest{Name: spec + "@stable"})
+				return err
+			},
+			"deployment": func() error {
+				_, err := server.DeleteApiDeployment(ctx, &rpc.DeleteApiDeploymentRequest{Name: api + "/deployments/d"})
+				return err
+			},
+		} {
+			err := del()
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("Delete %s returned status code %q, want %q: %v", desc, status.Code(err), codes.FailedPrecondition, err)
+				continue
+			}
+			var violations int
+			for _, detail := range status.Convert(err).Details() {
+				if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+					violations += len(failure.GetViolations())
+				}
+			}
+			if violations == 0 {
+				t.Errorf("Delete %s returned error without precondition violations: %v", desc, err)
+			}
+		}
+
+		if _, err := server.DeleteApiVersion(ctx, &rpc.DeleteApiVersionRequest{Name: api + "/versions/v2"}); err != nil {
+			t.Errorf("DeleteApiVersion() of an unreferenced version returned error: %s", err)
+		}
+
+		// References from resources that are deleted with the api don't prevent its deletion.
+		if _, err := server.DeleteApi(ctx, &rpc.DeleteApiRequest{Name: api}); err != nil {
+			t.Errorf("DeleteApi() returned error: %s", err)
+		}
+	})
+}
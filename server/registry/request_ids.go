@@ -0,0 +1,167 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultRequestIDWindow is how long request IDs are remembered if no window is configured.
+const defaultRequestIDWindow = time.Hour
+
+// identifiedRequest is implemented by requests that have a request_id field.
+type identifiedRequest interface {
+	proto.Message
+	GetRequestId() string
+}
+
+// RequestIDInterceptor returns a gRPC server interceptor that handles each request ID at most once per project.
+// If a request has the same ID as a request that completed within the request ID window,
+// the original response is returned instead of handling the request again. Requests with the
+// same ID as a request that is still being handled fail with ABORTED and can be retried.
+func (s *RegistryServer) RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		r, ok := req.(identifiedRequest)
+		if !ok || r.GetRequestId() == "" {
+			return handler(ctx, req)
+		}
+		return s.handleIdentifiedRequest(ctx, r, info.FullMethod, func() (interface{}, error) {
+			return handler(ctx, req)
+		})
+	}
+}
+
+func (s *RegistryServer) handleIdentifiedRequest(ctx context.Context, req identifiedRequest, method string, handler func() (interface{}, error)) (interface{}, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	id := req.GetRequestId()
+	hash, err := models.HashRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Expired records are deleted first so that their request IDs can be used again.
+	logger := log.FromContext(ctx)
+	if err := db.DeleteRequestRecordsBefore(ctx, time.Now().Add(-s.requestIDWindow)); err != nil {
+		logger.WithError(err).Error("Failed to delete expired request records.")
+	}
+
+	// Reserve the request ID before handling the request, so that concurrent retries aren't both handled.
+	record := models.NewRequestRecord(requestScope(req), id, method, hash)
+	if created, err := db.CreateRequestRecord(ctx, record); err != nil {
+		return nil, err
+	} else if !created {
+		return replayRequest(ctx, db, record)
+	}
+
+	response, err := handler()
+
+	// The reservation is updated even if the request was canceled, so cleanup doesn't use its context.
+	cleanupCtx := log.NewContext(context.Background(), logger)
+	if err != nil {
+		// Failed requests weren't applied, so they can be retried with the same ID.
+		if err := db.DeleteRequestRecord(cleanupCtx, record); err != nil {
+			logger.WithError(err).Errorf("Failed to release request %q.", id)
+		}
+		return nil, err
+	}
+
+	// The request has been handled, so failures to record it are logged instead of returned.
+	message, ok := response.(proto.Message)
+	if !ok {
+		return response, nil
+	}
+	if err := record.SetResponse(message); err != nil {
+		logger.WithError(err).Errorf("Failed to record request %q.", id)
+		return response, nil
+	}
+	if err := db.SaveRequestRecord(cleanupCtx, record); err != nil {
+		logger.WithError(err).Errorf("Failed to record request %q.", id)
+	}
+	return response, nil
+}
+
+// replayRequest returns the recorded response to a request with the same ID as a reserved request.
+func replayRequest(ctx context.Context, db *storage.Client, reserved *models.RequestRecord) (interface{}, error) {
+	record, err := db.GetRequestRecord(ctx, reserved.Scope, reserved.RequestID)
+	if isNotFound(err) {
+		// The request that held the ID failed and released it.
+		return nil, status.Errorf(codes.Aborted, "request_id %q was used by a concurrent request, retry the request", reserved.RequestID)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if record.Method != reserved.Method || record.RequestHash != reserved.RequestHash {
+		return nil, status.Errorf(codes.InvalidArgument, "request_id %q was already used for a different request", reserved.RequestID)
+	} else if !record.Completed() {
+		return nil, status.Errorf(codes.Aborted, "request_id %q is used by a request that hasn't completed, retry the request", reserved.RequestID)
+	}
+
+	response, err := record.Message()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return response, nil
+}
+
+// requestScope returns the name of the project that a request applies to, so that request IDs
+// used in different projects don't conflict. Requests are matched to projects by their parent,
+// their name, the name of the resource that they update or the ID of the project that they create.
+func requestScope(req proto.Message) string {
+	m := req.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	name := ""
+	for _, field := range []protoreflect.Name{"parent", "name"} {
+		if fd := fields.ByName(field); fd != nil && fd.Kind() == protoreflect.StringKind && m.Get(fd).String() != "" {
+			name = m.Get(fd).String()
+			break
+		}
+	}
+	for i := 0; name == "" && i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		resource := m.Get(fd).Message()
+		if nd := resource.Descriptor().Fields().ByName("name"); nd != nil && nd.Kind() == protoreflect.StringKind {
+			name = resource.Get(nd).String()
+		}
+	}
+	if name == "" {
+		if fd := fields.ByName("project_id"); fd != nil && fd.Kind() == protoreflect.StringKind && m.Get(fd).String() != "" {
+			name = "projects/" + m.Get(fd).String()
+		}
+	}
+
+	if parts := strings.SplitN(name, "/", 3); len(parts) >= 2 && parts[0] == "projects" {
+		return parts[0] + "/" + parts[1]
+	}
+	return name
+}
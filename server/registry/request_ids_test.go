@@ -0,0 +1,283 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// callWithRequestIDs calls a server method through the request ID interceptor.
+func callWithRequestIDs(ctx context.Context, server *RegistryServer, method string, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/google.cloud.apigeeregistry.v1.Registry/" + method}
+	return server.RequestIDInterceptor()(ctx, req, info, handler)
+}
+
+func createApiHandler(server *RegistryServer) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.CreateApi(ctx, req.(*rpc.CreateApiRequest))
+	}
+}
+
+func TestRequestIDReplay(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CreateApiRequest{
+		Parent:    "projects/my-project/locations/global",
+		ApiId:     "my-api",
+		Api:       &rpc.Api{DisplayName: "My API"},
+		RequestId: "a1b2c3",
+	}
+	first, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server))
+	if err != nil {
+		t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+	}
+
+	// A retry with the same request ID returns the original response instead of ALREADY_EXISTS.
+	second, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server))
+	if err != nil {
+		t.Fatalf("CreateApi(%+v) retry returned error: %s", req, err)
+	}
+	if diff := cmp.Diff(first, second, protocmp.Transform()); diff != "" {
+		t.Errorf("CreateApi(%+v) retry returned unexpected diff (-want +got):\n%s", req, diff)
+	}
+
+	// Without a request ID, the request is handled again.
+	req.RequestId = ""
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
+func TestRequestIDReplayDoesNotCreateRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: retentionTestSpec}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     retentionTestSpec,
+			Contents: []byte("new contents"),
+		},
+		RequestId: "d4e5f6",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.UpdateApiSpec(ctx, req.(*rpc.UpdateApiSpecRequest))
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := callWithRequestIDs(ctx, server, "UpdateApiSpec", req, handler); err != nil {
+			t.Fatalf("UpdateApiSpec(%+v) returned error: %s", req, err)
+		}
+	}
+
+	if got := listSpecRevisionIDs(ctx, t, server); len(got) != 2 {
+		t.Errorf("UpdateApiSpec(%+v) created %d revisions, want 2", req, len(got))
+	}
+}
+
+func TestRequestIDReuse(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CreateApiRequest{
+		Parent:    "projects/my-project/locations/global",
+		ApiId:     "my-api",
+		Api:       &rpc.Api{},
+		RequestId: "a1b2c3",
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); err != nil {
+		t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+	}
+
+	req.ApiId = "other-api"
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+	}
+}
+
+func TestRequestIDExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	server.requestIDWindow = time.Millisecond
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CreateApiRequest{
+		Parent:    "projects/my-project/locations/global",
+		ApiId:     "my-api",
+		Api:       &rpc.Api{},
+		RequestId: "a1b2c3",
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); err != nil {
+		t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+	}
+
+	// Once the window has passed, the request is handled again.
+	time.Sleep(10 * time.Millisecond)
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.AlreadyExists, err)
+	}
+}
+
+func TestRequestIDConcurrentRetry(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.CreateApiRequest{
+		Parent:    "projects/my-project/locations/global",
+		ApiId:     "my-api",
+		Api:       &rpc.Api{},
+		RequestId: "a1b2c3",
+	}
+	started, release := make(chan struct{}), make(chan struct{})
+	blocked := func(ctx context.Context, req interface{}) (interface{}, error) {
+		close(started)
+		<-release
+		return server.CreateApi(ctx, req.(*rpc.CreateApiRequest))
+	}
+	errs := make(chan error, 1)
+	go func() {
+		_, err := callWithRequestIDs(ctx, server, "CreateApi", req, blocked)
+		errs <- err
+	}()
+	<-started
+
+	// A retry while the first request is being handled isn't handled again.
+	handled := false
+	retry := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return server.CreateApi(ctx, req.(*rpc.CreateApiRequest))
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, retry); status.Code(err) != codes.Aborted {
+		t.Errorf("CreateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.Aborted, err)
+	}
+	if handled {
+		t.Errorf("CreateApi(%+v) handled a retry of a request in progress", req)
+	}
+
+	close(release)
+	if err := <-errs; err != nil {
+		t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, retry); err != nil || handled {
+		t.Errorf("CreateApi(%+v) retry returned error %v and handled %t, want the original response", req, err, handled)
+	}
+}
+
+func TestRequestIDReleasedOnFailure(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	req := &rpc.CreateApiRequest{
+		Parent:    "projects/my-project/locations/global",
+		ApiId:     "my-api",
+		Api:       &rpc.Api{},
+		RequestId: "a1b2c3",
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); status.Code(err) != codes.NotFound {
+		t.Fatalf("CreateApi(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+	}
+
+	// The failed request wasn't applied, so a retry is handled once the project exists.
+	if err := seeder.SeedProjects(ctx, server, &rpc.Project{Name: "projects/my-project"}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	if _, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server)); err != nil {
+		t.Errorf("CreateApi(%+v) retry returned error: %s", req, err)
+	}
+}
+
+func TestRequestIDScopedToProject(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedProjects(ctx, server,
+		&rpc.Project{Name: "projects/my-project"},
+		&rpc.Project{Name: "projects/other-project"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	for _, parent := range []string{"projects/my-project/locations/global", "projects/other-project/locations/global"} {
+		req := &rpc.CreateApiRequest{
+			Parent:    parent,
+			ApiId:     "my-api",
+			Api:       &rpc.Api{},
+			RequestId: "a1b2c3",
+		}
+		got, err := callWithRequestIDs(ctx, server, "CreateApi", req, createApiHandler(server))
+		if err != nil {
+			t.Fatalf("CreateApi(%+v) returned error: %s", req, err)
+		}
+		if want := parent + "/apis/my-api"; got.(*rpc.Api).GetName() != want {
+			t.Errorf("CreateApi(%+v) returned %q, want %q", req, got.(*rpc.Api).GetName(), want)
+		}
+	}
+}
+
+func TestRequestScope(t *testing.T) {
+	tests := []struct {
+		req  proto.Message
+		want string
+	}{
+		{
+			req:  &rpc.CreateApiRequest{Parent: "projects/p/locations/global"},
+			want: "projects/p",
+		},
+		{
+			req:  &rpc.UpdateApiSpecRequest{ApiSpec: &rpc.ApiSpec{Name: "projects/p/locations/global/apis/a/versions/v/specs/s"}},
+			want: "projects/p",
+		},
+		{
+			req:  &rpc.TagApiSpecRevisionRequest{Name: "projects/p/locations/global/apis/a/versions/v/specs/s@r"},
+			want: "projects/p",
+		},
+		{
+			req:  &rpc.CreateProjectRequest{ProjectId: "p"},
+			want: "projects/p",
+		},
+		{
+			req:  &rpc.CreateProjectRequest{},
+			want: "",
+		},
+	}
+	for _, test := range tests {
+		if got := requestScope(test.req); got != test.want {
+			t.Errorf("requestScope(%+v) returned %q, want %q", test.req, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PruneRevisions handles the corresponding API request.
+func (s *RegistryServer) PruneRevisions(ctx context.Context, req *rpc.PruneRevisionsRequest) (*rpc.PruneRevisionsResponse, error) {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	defer db.Close()
+
+	name, err := names.ParseProject(req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	project, err := db.GetProject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &rpc.PruneRevisionsResponse{
+		PrunedRevisions: make([]string, 0),
+	}
+
+	policy := project.RetentionPolicy()
+	if policy == nil {
+		return response, nil
+	}
+
+	specs, err := listAllSpecs(ctx, db, names.Version{ProjectID: name.ProjectID, ApiID: "-", VersionID: "-"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spec := range specs {
+		pruned, err := s.pruneSpecRevisions(ctx, db, spec.Name(), policy, req.GetValidateOnly())
+		if err != nil {
+			return nil, err
+		}
+		response.PrunedRevisions = append(response.PrunedRevisions, pruned...)
+	}
+
+	deployments, err := listAllDeployments(ctx, db, names.Api{ProjectID: name.ProjectID, ApiID: "-"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deployments {
+		pruned, err := s.pruneDeploymentRevisions(ctx, db, deployment.Name(), policy, req.GetValidateOnly())
+		if err != nil {
+			return nil, err
+		}
+		response.PrunedRevisions = append(response.PrunedRevisions, pruned...)
+	}
+
+	return response, nil
+}
+
+// validateRetentionPolicy returns an error if a retention policy has negative limits.
+func validateRetentionPolicy(policy *rpc.RevisionRetentionPolicy) error {
+	if policy.GetMaxRevisions() < 0 {
+		return fmt.Errorf("invalid max_revisions %d: must not be negative", policy.GetMaxRevisions())
+	}
+	if policy.GetMaxAge().AsDuration() < 0 {
+		return fmt.Errorf("invalid max_age %s: must not be negative", policy.GetMaxAge().AsDuration())
+	}
+	return nil
+}
+
+// outsideRetention reports whether a revision falls outside a retention policy.
+// The index is the position of the revision when ordered from newest to oldest.
+func outsideRetention(policy *rpc.RevisionRetentionPolicy, index int, created, now time.Time) bool {
+	if limit := policy.GetMaxRevisions(); limit > 0 && index >= int(limit) {
+		return true
+	}
+	if age := policy.GetMaxAge().AsDuration(); age > 0 && now.Sub(created) > age {
+		return true
+	}
+	return false
+}
+
+// enforceSpecRetention prunes revisions of a spec according to the retention policy of its project.
+func (s *RegistryServer) enforceSpecRetention(ctx context.Context, db *storage.Client, name names.Spec) error {
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return err
+	}
+
+	if policy := project.RetentionPolicy(); policy != nil {
+		_, err = s.pruneSpecRevisions(ctx, db, name.String(), policy, false)
+	}
+	return err
+}
+
+// enforceDeploymentRetention prunes revisions of a deployment according to the retention policy of its project.
+func (s *RegistryServer) enforceDeploymentRetention(ctx context.Context, db *storage.Client, name names.Deployment) error {
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return err
+	}
+
+	if policy := project.RetentionPolicy(); policy != nil {
+		_, err = s.pruneDeploymentRevisions(ctx, db, name.String(), policy, false)
+	}
+	return err
+}
+
+// pruneSpecRevisions deletes the revisions of a spec that fall outside a retention policy.
+// The current revision, tagged revisions and revisions that other resources refer to are always kept.
+func (s *RegistryServer) pruneSpecRevisions(ctx context.Context, db *storage.Client, specName string, policy *rpc.RevisionRetentionPolicy, validateOnly bool) ([]string, error) {
+	name, err := names.ParseSpec(specName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	tags, err := db.GetSpecTags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		kept[tag.RevisionID] = true
+	}
+
+	if err := addReferencedRevisions(ctx, db, name.Project(), name.String(), kept); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]models.Spec, 0)
+	for token := ""; ; {
+		listing, err := db.ListSpecRevisions(ctx, name, storage.PageOptions{Size: 1000, Token: token})
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, listing.Specs...)
+		if token = listing.Token; token == "" {
+			break
+		}
+	}
+
+	now := time.Now()
+	pruned := make([]string, 0)
+	for i, revision := range revisions {
+		if i == 0 || kept[revision.RevisionID] || !outsideRetention(policy, i, revision.RevisionCreateTime, now) {
+			continue
+		}
+
+		if !validateOnly {
+			if err := db.DeleteSpecRevision(ctx, name.Revision(revision.RevisionID)); err != nil {
+				return nil, err
+			}
+			s.notify(ctx, rpc.Notification_DELETED, revision.RevisionName())
+		}
+		pruned = append(pruned, revision.RevisionName())
+	}
+
+	return pruned, nil
+}
+
+// pruneDeploymentRevisions deletes the revisions of a deployment that fall outside a retention policy.
+// The current revision, tagged revisions and revisions that other resources refer to are always kept.
+func (s *RegistryServer) pruneDeploymentRevisions(ctx context.Context, db *storage.Client, deploymentName string, policy *rpc.RevisionRetentionPolicy, validateOnly bool) ([]string, error) {
+	name, err := names.ParseDeployment(deploymentName)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	tags, err := db.GetDeploymentTags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		kept[tag.RevisionID] = true
+	}
+
+	if err := addReferencedRevisions(ctx, db, name.Project(), name.String(), kept); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]models.Deployment, 0)
+	for token := ""; ; {
+		listing, err := db.ListDeploymentRevisions(ctx, name, storage.PageOptions{Size: 1000, Token: token})
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, listing.Deployments...)
+		if token = listing.Token; token == "" {
+			break
+		}
+	}
+
+	now := time.Now()
+	pruned := make([]string, 0)
+	for i, revision := range revisions {
+		if i == 0 || kept[revision.RevisionID] || !outsideRetention(policy, i, revision.RevisionCreateTime, now) {
+			continue
+		}
+
+		if !validateOnly {
+			if err := db.DeleteDeploymentRevision(ctx, name.Revision(revision.RevisionID)); err != nil {
+				return nil, err
+			}
+			s.notify(ctx, rpc.Notification_DELETED, revision.RevisionName())
+		}
+		pruned = append(pruned, revision.RevisionName())
+	}
+
+	return pruned, nil
+}
+
+func listAllSpecs(ctx context.Context, db *storage.Client, parent names.Version) ([]models.Spec, error) {
+	specs := make([]models.Spec, 0)
+	for token := ""; ; {
+		listing, err := db.ListSpecs(ctx, parent, storage.PageOptions{Size: 1000, Token: token})
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, listing.Specs...)
+		if token = listing.Token; token == "" {
+			return specs, nil
+		}
+	}
+}
+
+func listAllDeployments(ctx context.Context, db *storage.Client, parent names.Api) ([]models.Deployment, error) {
+	deployments := make([]models.Deployment, 0)
+	for token := ""; ; {
+		listing, err := db.ListDeployments(ctx, parent, storage.PageOptions{Size: 1000, Token: token})
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, listing.Deployments...)
+		if token = listing.Token; token == "" {
+			return deployments, nil
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const retentionTestSpec = "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"
+
+func setRetentionPolicy(ctx context.Context, t *testing.T, server *RegistryServer, policy *rpc.RevisionRetentionPolicy) {
+	t.Helper()
+	req := &rpc.UpdateProjectRequest{
+		Project: &rpc.Project{
+			Name:                    "projects/my-project",
+			RevisionRetentionPolicy: policy,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"revision_retention_policy"}},
+	}
+	if _, err := server.UpdateProject(ctx, req); err != nil {
+		t.Fatalf("Setup: UpdateProject(%+v) returned error: %s", req, err)
+	}
+}
+
+func addSpecRevisions(ctx context.Context, t *testing.T, server *RegistryServer, prefix string, count int) []*rpc.ApiSpec {
+	t.Helper()
+	revisions := make([]*rpc.ApiSpec, 0, count)
+	for i := 0; i < count; i++ {
+		req := &rpc.UpdateApiSpecRequest{
+			ApiSpec: &rpc.ApiSpec{
+				Name:     retentionTestSpec,
+				Contents: []byte(fmt.Sprintf("%s %d", prefix, i)),
+			},
+		}
+		revision, err := server.UpdateApiSpec(ctx, req)
+		if err != nil {
+			t.Fatalf("Setup: UpdateApiSpec(%+v) returned error: %s", req, err)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+func listSpecRevisionIDs(ctx context.Context, t *testing.T, server *RegistryServer) []string {
+	t.Helper()
+	req := &rpc.ListApiSpecRevisionsRequest{
+		Name: retentionTestSpec,
+	}
+	got, err := server.ListApiSpecRevisions(ctx, req)
+	if err != nil {
+		t.Fatalf("ListApiSpecRevisions(%+v) returned error: %s", req, err)
+	}
+	ids := make([]string, 0, len(got.GetApiSpecs()))
+	for _, spec := range got.GetApiSpecs() {
+		ids = append(ids, spec.GetRevisionId())
+	}
+	return ids
+}
+
+func TestRetentionEnforcedOnWrite(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedSpecs(ctx, server, &rpc.ApiSpec{Name: retentionTestSpec}); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	setRetentionPolicy(ctx, t, server, &rpc.RevisionRetentionPolicy{MaxRevisions: 2})
+	revisions := addSpecRevisions(ctx, t, server, "first", 1)
+
+	// Tagged revisions are kept even when they fall outside the policy.
+	tagReq := &rpc.TagApiSpecRevisionRequest{
+		Name: fmt.Sprintf("%s@%s", retentionTestSpec, revisions[0].GetRevisionId()),
+		Tag:  "keep",
+	}
+	if _, err := server.TagApiSpecRevision(ctx, tagReq); err != nil {
+		t.Fatalf("Setup: TagApiSpecRevision(%+v) returned error: %s", tagReq, err)
+	}
+
+	revisions = append(revisions, addSpecRevisions(ctx, t, server, "second", 3)...)
+
+	got := listSpecRevisionIDs(ctx, t, server)
+	want := []string{revisions[3].GetRevisionId(), revisions[2].GetRevisionId(), revisions[0].GetRevisionId()}
+	if len(got) != len(want) {
+		t.Fatalf("ListApiSpecRevisions() returned revisions %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListApiSpecRevisions() returned revisions %v, want %v", got, want)
+			break
+		}
+	}
+
+	t.Run("pruned contents", func(t *testing.T) {
+		req := &rpc.GetApiSpecContentsRequest{
+			Name: fmt.Sprintf("%s@%s", retentionTestSpec, revisions[1].GetRevisionId()),
+		}
+		if _, err := server.GetApiSpecContents(ctx, req); status.Code(err) != codes.NotFound {
+			t.Errorf("GetApiSpecContents(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+}
+
+func TestRetentionKeepsReferencedRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	deployment := "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: retentionTestSpec},
+		&rpc.ApiDeployment{Name: deployment},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	setRetentionPolicy(ctx, t, server, &rpc.RevisionRetentionPolicy{MaxRevisions: 1})
+	revisions := addSpecRevisions(ctx, t, server, "served", 1)
+
+	// Revisions that are served by deployments are kept even when they fall outside the policy.
+	req := &rpc.UpdateApiDeploymentRequest{
+		ApiDeployment: &rpc.ApiDeployment{
+			Name:            deployment,
+			ApiSpecRevision: fmt.Sprintf("%s@%s", retentionTestSpec, revisions[0].GetRevisionId()),
+		},
+	}
+	if _, err := server.UpdateApiDeployment(ctx, req); err != nil {
+		t.Fatalf("Setup: UpdateApiDeployment(%+v) returned error: %s", req, err)
+	}
+
+	revisions = append(revisions, addSpecRevisions(ctx, t, server, "later", 2)...)
+
+	got := listSpecRevisionIDs(ctx, t, server)
+	want := []string{revisions[2].GetRevisionId(), revisions[0].GetRevisionId()}
+	if !cmp.Equal(want, got) {
+		t.Errorf("ListApiSpecRevisions() returned revisions %v, want %v", got, want)
+	}
+}
+
+func TestPruneRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.ApiSpec{Name: retentionTestSpec},
+		&rpc.ApiDeployment{Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	addSpecRevisions(ctx, t, server, "revision", 3)
+	for _, uri := range []string{"https://a.example.com", "https://b.example.com"} {
+		req := &rpc.UpdateApiDeploymentRequest{
+			ApiDeployment: &rpc.ApiDeployment{
+				Name:        "projects/my-project/locations/global/apis/my-api/deployments/my-deployment",
+				EndpointUri: uri,
+			},
+		}
+		if _, err := server.UpdateApiDeployment(ctx, req); err != nil {
+			t.Fatalf("Setup: UpdateApiDeployment(%+v) returned error: %s", req, err)
+		}
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		req := &rpc.PruneRevisionsRequest{Name: "projects/my-project"}
+		got, err := server.PruneRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("PruneRevisions(%+v) returned error: %s", req, err)
+		}
+		if len(got.GetPrunedRevisions()) != 0 {
+			t.Errorf("PruneRevisions(%+v) pruned %v, expected nothing without a policy", req, got.GetPrunedRevisions())
+		}
+	})
+
+	setRetentionPolicy(ctx, t, server, &rpc.RevisionRetentionPolicy{MaxAge: durationpb.New(time.Nanosecond)})
+
+	t.Run("validate only", func(t *testing.T) {
+		req := &rpc.PruneRevisionsRequest{Name: "projects/my-project", ValidateOnly: true}
+		got, err := server.PruneRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("PruneRevisions(%+v) returned error: %s", req, err)
+		}
+		// Three of the four spec revisions and two of the three deployment revisions are outside the policy.
+		if count := len(got.GetPrunedRevisions()); count != 5 {
+			t.Errorf("PruneRevisions(%+v) returned %d pruned revisions, want 5: %v", req, count, got.GetPrunedRevisions())
+		}
+		if count := len(listSpecRevisionIDs(ctx, t, server)); count != 4 {
+			t.Errorf("PruneRevisions(%+v) left %d spec revisions, want 4", req, count)
+		}
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		req := &rpc.PruneRevisionsRequest{Name: "projects/my-project"}
+		got, err := server.PruneRevisions(ctx, req)
+		if err != nil {
+			t.Fatalf("PruneRevisions(%+v) returned error: %s", req, err)
+		}
+		if count := len(got.GetPrunedRevisions()); count != 5 {
+			t.Errorf("PruneRevisions(%+v) returned %d pruned revisions, want 5: %v", req, count, got.GetPrunedRevisions())
+		}
+		if count := len(listSpecRevisionIDs(ctx, t, server)); count != 1 {
+			t.Errorf("PruneRevisions(%+v) left %d spec revisions, want 1", req, count)
+		}
+
+		listReq := &rpc.ListApiDeploymentRevisionsRequest{
+			Name: "projects/my-project/locations/global/apis/my-api/deployments/my-deployment",
+		}
+		deployments, err := server.ListApiDeploymentRevisions(ctx, listReq)
+		if err != nil {
+			t.Fatalf("ListApiDeploymentRevisions(%+v) returned error: %s", listReq, err)
+		}
+		if count := len(deployments.GetApiDeployments()); count != 1 {
+			t.Errorf("PruneRevisions(%+v) left %d deployment revisions, want 1", req, count)
+		}
+	})
+
+	t.Run("missing project", func(t *testing.T) {
+		req := &rpc.PruneRevisionsRequest{Name: "projects/doesnt-exist"}
+		if _, err := server.PruneRevisions(ctx, req); status.Code(err) != codes.NotFound {
+			t.Errorf("PruneRevisions(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.NotFound, err)
+		}
+	})
+}
+
+func TestInvalidRetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+
+	req := &rpc.CreateProjectRequest{
+		ProjectId: "my-project",
+		Project: &rpc.Project{
+			RevisionRetentionPolicy: &rpc.RevisionRetentionPolicy{MaxRevisions: -1},
+		},
+	}
+	if _, err := server.CreateProject(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateProject(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+	}
+}
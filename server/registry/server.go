@@ -16,6 +16,7 @@ package registry
 
 import (
 	"context"
+	"time"
 
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/internal/storage"
@@ -32,14 +33,29 @@ type Config struct {
 	LogFormat string
 	Notify    bool
 	ProjectID string
+	Quotas    Quotas
+	// RequestIDWindow is how long request IDs are remembered.
+	// If unset or zero, a default of one hour is used.
+	RequestIDWindow time.Duration
+	// ReadOnly starts the server in read-only maintenance mode.
+	ReadOnly bool
+	// MaintenanceMessage is returned by requests that are rejected in read-only mode.
+	// If unset, a default message is used.
+	MaintenanceMessage string
 }
 
 // RegistryServer implements a Registry server.
 type RegistryServer struct {
-	database      string
-	dbConfig      string
-	notifyEnabled bool
-	projectID     string
+	database        string
+	dbConfig        string
+	notifyEnabled   bool
+	projectID       string
+	quotas          Quotas
+	requestIDWindow time.Duration
+	startTime       time.Time
+	notifications   notificationStats
+	maintenance     maintenanceMode
+	comparisons     comparisonCache
 
 	rpc.UnimplementedRegistryServer
 	rpc.UnimplementedAdminServer
@@ -47,10 +63,13 @@ type RegistryServer struct {
 
 func New(config Config) (*RegistryServer, error) {
 	s := &RegistryServer{
-		database:      config.Database,
-		dbConfig:      config.DBConfig,
-		notifyEnabled: config.Notify,
-		projectID:     config.ProjectID,
+		database:        config.Database,
+		dbConfig:        config.DBConfig,
+		notifyEnabled:   config.Notify,
+		projectID:       config.ProjectID,
+		quotas:          config.Quotas,
+		requestIDWindow: config.RequestIDWindow,
+		startTime:       time.Now(),
 	}
 
 	if s.database == "" {
@@ -58,6 +77,12 @@ func New(config Config) (*RegistryServer, error) {
 		s.dbConfig = "/tmp/registry.db"
 	}
 
+	if s.requestIDWindow <= 0 {
+		s.requestIDWindow = defaultRequestIDWindow
+	}
+
+	s.maintenance.set(config.ReadOnly, config.MaintenanceMessage)
+
 	db, err := storage.NewClient(context.Background(), s.database, s.dbConfig)
 	if err != nil {
 		return nil, err
@@ -73,6 +98,16 @@ func (s *RegistryServer) getStorageClient(ctx context.Context) (*storage.Client,
 	return storage.NewClient(ctx, s.database, s.dbConfig)
 }
 
+// CheckHealth returns an error if the server's database is unreachable.
+func (s *RegistryServer) CheckHealth(ctx context.Context) error {
+	db, err := s.getStorageClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Ping(ctx)
+}
+
 func isNotFound(err error) bool {
 	return status.Code(err) == codes.NotFound
 }
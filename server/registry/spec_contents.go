@@ -0,0 +1,176 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// formatSpecContents returns uncompressed spec contents in the requested format.
+// If path is set, contents must be a zip archive and only the named file is returned.
+func formatSpecContents(contents []byte, mimeType string, format rpc.GetApiSpecContentsRequest_ContentsFormat, path string) (*httpbody.HttpBody, error) {
+	if path != "" {
+		if !isZipArchive(contents) {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid path %q: contents with MIME type %q are not a zip archive", path, mimeType)
+		}
+
+		file, err := extractFromZip(contents, path)
+		if err != nil {
+			return nil, err
+		}
+
+		contents = file
+		if mimeType = detectSpecMimeType(contents); mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+	}
+
+	switch format {
+	case rpc.GetApiSpecContentsRequest_CONTENTS_FORMAT_UNSPECIFIED, rpc.GetApiSpecContentsRequest_DECOMPRESSED:
+		return &httpbody.HttpBody{
+			ContentType: mimeType,
+			Data:        contents,
+		}, nil
+	case rpc.GetApiSpecContentsRequest_JSON:
+		data, err := convertToJSON(contents)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to convert contents with MIME type %q to JSON: %s", mimeType, err)
+		}
+		return &httpbody.HttpBody{
+			ContentType: "application/json",
+			Data:        data,
+		}, nil
+	case rpc.GetApiSpecContentsRequest_YAML:
+		data, err := convertToYAML(contents)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to convert contents with MIME type %q to YAML: %s", mimeType, err)
+		}
+		return &httpbody.HttpBody{
+			ContentType: "application/yaml",
+			Data:        data,
+		}, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid format %d: unknown contents format", format)
+	}
+}
+
+func extractFromZip(contents []byte, path string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to read zip archive: %s", err)
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	for _, f := range r.File {
+		if f.Name != path {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to open %q in zip archive: %s", path, err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "failed to read %q in zip archive: %s", path, err)
+		}
+		return data, nil
+	}
+
+	return nil, status.Errorf(codes.NotFound, "file %q not found in zip archive", path)
+}
+
+func convertToJSON(contents []byte) ([]byte, error) {
+	if json.Valid(contents) {
+		return contents, nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	// Documents that aren't YAML mappings or sequences (like proto files) parse as plain scalars.
+	switch doc.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+	default:
+		return nil, fmt.Errorf("contents are not a JSON or YAML document")
+	}
+
+	return json.MarshalIndent(jsonCompatible(doc), "", "  ")
+}
+
+// jsonCompatible replaces maps with non-string keys, which YAML allows and JSON does not.
+func jsonCompatible(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(value)
+		}
+		return m
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = jsonCompatible(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = jsonCompatible(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func convertToYAML(contents []byte) ([]byte, error) {
+	// Decoding into a node preserves the order of keys in the document.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind == yaml.ScalarNode {
+		return nil, fmt.Errorf("contents are not a JSON or YAML document")
+	}
+
+	// Nodes decoded from JSON keep its flow style and quoting unless they are cleared.
+	// Strings that would otherwise be misread are still quoted when encoded.
+	if json.Valid(contents) {
+		clearStyle(&doc)
+	}
+	return yaml.Marshal(&doc)
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
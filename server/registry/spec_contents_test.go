@@ -0,0 +1,257 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestGetApiSpecContentsFormats(t *testing.T) {
+	const specName = "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"
+	yamlContents := []byte("openapi: 3.0.0\ninfo:\n    title: My API\n    version: v1\npaths: {}\n")
+	archive := zipped(t, map[string]string{
+		"a/b.proto": `syntax = "proto3";`,
+		"c.yaml":    "name: c\n",
+	})
+
+	tests := []struct {
+		desc string
+		seed *rpc.ApiSpec
+		req  *rpc.GetApiSpecContentsRequest
+		want *httpbody.HttpBody
+	}{
+		{
+			desc: "decompressed",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.openapi+gzip;version=3.0.0",
+				Contents: gzipped(t, specContents),
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_DECOMPRESSED,
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/x.openapi;version=3.0.0",
+				Data:        specContents,
+			},
+		},
+		{
+			desc: "yaml to json",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.openapi+gzip;version=3.0.0",
+				Contents: gzipped(t, yamlContents),
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_JSON,
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/json",
+				Data: []byte(`{
+  "info": {
+    "title": "My API",
+    "version": "v1"
+  },
+  "openapi": "3.0.0",
+  "paths": {}
+}`),
+			},
+		},
+		{
+			desc: "json to json",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.openapi;version=3.0.0",
+				Contents: specContents,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_JSON,
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/json",
+				Data:        specContents,
+			},
+		},
+		{
+			desc: "json to yaml",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.openapi;version=3.0.0",
+				Contents: specContents,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_YAML,
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/yaml",
+				Data:        yamlContents,
+			},
+		},
+		{
+			desc: "file from archive",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.protobuf+zip",
+				Contents: archive,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name: specName,
+				Path: "a/b.proto",
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/x.protobuf",
+				Data:        []byte(`syntax = "proto3";`),
+			},
+		},
+		{
+			desc: "converted file from archive",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.protobuf+zip",
+				Contents: archive,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_JSON,
+				Path:   "c.yaml",
+			},
+			want: &httpbody.HttpBody{
+				ContentType: "application/json",
+				Data:        []byte("{\n  \"name\": \"c\"\n}"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedSpecs(ctx, server, test.seed); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			got, err := server.GetApiSpecContents(ctx, test.req)
+			if err != nil {
+				t.Fatalf("GetApiSpecContents(%+v) returned error: %s", test.req, err)
+			}
+
+			if !cmp.Equal(test.want, got, protocmp.Transform()) {
+				t.Errorf("GetApiSpecContents(%+v) returned unexpected diff (-want +got):\n%s", test.req, cmp.Diff(test.want, got, protocmp.Transform()))
+			}
+		})
+	}
+}
+
+func TestGetApiSpecContentsFormatErrors(t *testing.T) {
+	const specName = "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec"
+	tests := []struct {
+		desc string
+		seed *rpc.ApiSpec
+		req  *rpc.GetApiSpecContentsRequest
+		want codes.Code
+	}{
+		{
+			desc: "proto to json",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.protobuf",
+				Contents: []byte(`syntax = "proto3";`),
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_JSON,
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "archive to yaml",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.protobuf+zip",
+				Contents: zipped(t, map[string]string{"a.proto": `syntax = "proto3";`}),
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_YAML,
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "path in single file",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.openapi;version=3.0.0",
+				Contents: specContents,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name: specName,
+				Path: "openapi.yaml",
+			},
+			want: codes.FailedPrecondition,
+		},
+		{
+			desc: "missing path in archive",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				MimeType: "application/x.protobuf+zip",
+				Contents: zipped(t, map[string]string{"a.proto": `syntax = "proto3";`}),
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name: specName,
+				Path: "b.proto",
+			},
+			want: codes.NotFound,
+		},
+		{
+			desc: "unknown format",
+			seed: &rpc.ApiSpec{
+				Name:     specName,
+				Contents: specContents,
+			},
+			req: &rpc.GetApiSpecContentsRequest{
+				Name:   specName,
+				Format: rpc.GetApiSpecContentsRequest_ContentsFormat(100),
+			},
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedSpecs(ctx, server, test.seed); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			if _, err := server.GetApiSpecContents(ctx, test.req); status.Code(err) != test.want {
+				t.Errorf("GetApiSpecContents(%+v) returned status code %q, want %q: %v", test.req, status.Code(err), test.want, err)
+			}
+		})
+	}
+}
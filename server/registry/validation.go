@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/storage"
+	"github.com/apigee/registry/server/registry/internal/storage/models"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/gnostic/compiler"
+	discovery "github.com/google/gnostic/discovery"
+	oas2 "github.com/google/gnostic/openapiv2"
+	oas3 "github.com/google/gnostic/openapiv3"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkSpecContents validates spec contents according to the spec validation mode of the project.
+// In enforce mode, invalid contents are rejected with an InvalidArgument error that lists each problem.
+// In warn mode, problems are logged and the contents are accepted.
+func (s *RegistryServer) checkSpecContents(ctx context.Context, db *storage.Client, name names.Spec, mimeType string, contents []byte) error {
+	project, err := db.GetProject(ctx, name.Project())
+	if err != nil {
+		return err
+	}
+
+	mode := rpc.Project_SpecValidation(project.SpecValidation)
+	if mode != rpc.Project_WARN && mode != rpc.Project_ENFORCE {
+		return nil
+	}
+
+	problems := validateSpecContents(mimeType, contents)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if mode == rpc.Project_WARN {
+		for _, p := range problems {
+			log.FromContext(ctx).WithField("spec", name.String()).Warnf("Invalid spec contents: %s", p)
+		}
+		return nil
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(problems))
+	for i, p := range problems {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       "api_spec.contents",
+			Description: p.Error(),
+		}
+	}
+
+	st := status.Newf(codes.InvalidArgument, "invalid contents for mime_type %q: %s", mimeType, problems[0])
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// validateSpecContents checks that contents can be parsed as the format described by a MIME type.
+// Contents with unrecognized MIME types are not checked.
+func validateSpecContents(mimeType string, contents []byte) (problems []error) {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	// Malformed documents can cause parsers to panic, which should be reported as invalid contents.
+	defer func() {
+		if r := recover(); r != nil {
+			problems = []error{fmt.Errorf("failed to parse contents: %v", r)}
+		}
+	}()
+
+	if core.IsGZipCompressed(mimeType) {
+		var err error
+		if contents, err = models.GUnzippedBytes(contents); err != nil {
+			return []error{fmt.Errorf("invalid gzip encoding: %s", err)}
+		}
+	}
+
+	var err error
+	switch {
+	case core.IsZipArchive(mimeType):
+		err = validateZipArchive(contents)
+	case core.IsOpenAPIv2(mimeType):
+		_, err = oas2.ParseDocument(contents)
+	case core.IsOpenAPIv3(mimeType):
+		_, err = oas3.ParseDocument(contents)
+	case core.IsDiscovery(mimeType):
+		_, err = discovery.ParseDocument(contents)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	var group *compiler.ErrorGroup
+	if errors.As(err, &group) {
+		return group.Errors
+	}
+	return []error{err}
+}
+
+// validateZipArchive checks that an archive can be read and that the checksums of all files match.
+func validateZipArchive(contents []byte) error {
+	r, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return fmt.Errorf("invalid zip archive: %s", err)
+	}
+
+	for _, f := range r.File {
+		if err := readZipFile(f); err != nil {
+			return fmt.Errorf("invalid zip archive entry %q: %s", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func readZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	// Reading to the end verifies the entry checksum.
+	_, err = io.Copy(ioutil.Discard, rc)
+	return err
+}
@@ -0,0 +1,257 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/internal/test/seeder"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func gzipped(t *testing.T, b []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		t.Fatalf("Setup: failed to compress contents: %s", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Setup: failed to compress contents: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func zipped(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, contents := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Setup: failed to create archive: %s", err)
+		}
+		if _, err := w.Write([]byte(contents)); err != nil {
+			t.Fatalf("Setup: failed to create archive: %s", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Setup: failed to create archive: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateSpecContents(t *testing.T) {
+	invalidOpenAPI := []byte(`{"openapi": "3.0.0", "info": {"title": "My API"}, "paths": {}, "bogus": true}`)
+	tests := []struct {
+		desc     string
+		mimeType string
+		contents []byte
+		valid    bool
+	}{
+		{
+			desc:     "empty contents",
+			mimeType: "application/x.openapi;version=3",
+			valid:    true,
+		},
+		{
+			desc:     "unrecognized mime type",
+			mimeType: "text/plain",
+			contents: []byte("anything"),
+			valid:    true,
+		},
+		{
+			desc:     "valid openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: specContents,
+			valid:    true,
+		},
+		{
+			desc:     "invalid openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: invalidOpenAPI,
+		},
+		{
+			desc:     "valid openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: []byte(`{"swagger": "2.0", "info": {"title": "My API", "version": "v1"}, "paths": {}}`),
+			valid:    true,
+		},
+		{
+			desc:     "unparseable openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: []byte(`{"swagger": `),
+		},
+		{
+			desc:     "valid discovery",
+			mimeType: "application/x.discovery",
+			contents: []byte(`{"kind": "discovery#restDescription", "discoveryVersion": "v1", "name": "my-api", "version": "v1"}`),
+			valid:    true,
+		},
+		{
+			desc:     "gzipped openapi v3",
+			mimeType: "application/x.openapi+gzip;version=3",
+			contents: gzipped(t, specContents),
+			valid:    true,
+		},
+		{
+			desc:     "gzipped invalid openapi v3",
+			mimeType: "application/x.openapi+gzip;version=3",
+			contents: gzipped(t, invalidOpenAPI),
+		},
+		{
+			desc:     "mislabeled gzip",
+			mimeType: "application/x.openapi+gzip;version=3",
+			contents: specContents,
+		},
+		{
+			desc:     "valid proto archive",
+			mimeType: "application/x.protobuf+zip",
+			contents: zipped(t, map[string]string{"a.proto": `syntax = "proto3";`}),
+			valid:    true,
+		},
+		{
+			desc:     "mislabeled proto archive",
+			mimeType: "application/x.protobuf+zip",
+			contents: []byte("not a zip archive"),
+		},
+		{
+			desc:     "truncated proto archive",
+			mimeType: "application/x.protobuf+zip",
+			contents: zipped(t, map[string]string{"a.proto": `syntax = "proto3";`})[:40],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			problems := validateSpecContents(test.mimeType, test.contents)
+			if test.valid && len(problems) > 0 {
+				t.Errorf("validateSpecContents(%q) returned unexpected problems: %v", test.mimeType, problems)
+			} else if !test.valid && len(problems) == 0 {
+				t.Errorf("validateSpecContents(%q) returned no problems, expected invalid contents", test.mimeType)
+			}
+		})
+	}
+}
+
+func TestCreateApiSpecValidation(t *testing.T) {
+	invalid := &rpc.ApiSpec{
+		MimeType: "application/x.openapi;version=3",
+		Contents: []byte(`{"openapi": "3.0.0", "bogus": true}`),
+	}
+
+	tests := []struct {
+		desc string
+		mode rpc.Project_SpecValidation
+		want codes.Code
+	}{
+		{
+			desc: "unspecified",
+			mode: rpc.Project_SPEC_VALIDATION_UNSPECIFIED,
+			want: codes.OK,
+		},
+		{
+			desc: "off",
+			mode: rpc.Project_OFF,
+			want: codes.OK,
+		},
+		{
+			desc: "warn",
+			mode: rpc.Project_WARN,
+			want: codes.OK,
+		},
+		{
+			desc: "enforce",
+			mode: rpc.Project_ENFORCE,
+			want: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			server := defaultTestServer(t)
+			if err := seeder.SeedRegistry(ctx, server,
+				&rpc.Project{Name: "projects/my-project", SpecValidation: test.mode},
+				&rpc.ApiVersion{Name: "projects/my-project/locations/global/apis/my-api/versions/v1"},
+			); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+
+			req := &rpc.CreateApiSpecRequest{
+				Parent:    "projects/my-project/locations/global/apis/my-api/versions/v1",
+				ApiSpecId: "my-spec",
+				ApiSpec:   invalid,
+			}
+
+			_, err := server.CreateApiSpec(ctx, req)
+			if status.Code(err) != test.want {
+				t.Fatalf("CreateApiSpec(%+v) returned status code %q, want %q: %v", req, status.Code(err), test.want, err)
+			}
+
+			if test.want != codes.InvalidArgument {
+				return
+			}
+
+			var violations []*errdetails.BadRequest_FieldViolation
+			for _, d := range status.Convert(err).Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					violations = append(violations, br.GetFieldViolations()...)
+				}
+			}
+			if len(violations) == 0 {
+				t.Errorf("CreateApiSpec(%+v) returned no field violations in error details", req)
+			}
+			for _, v := range violations {
+				if v.GetField() != "api_spec.contents" {
+					t.Errorf("CreateApiSpec(%+v) returned violation for field %q, want %q", req, v.GetField(), "api_spec.contents")
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateApiSpecValidation(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	if err := seeder.SeedRegistry(ctx, server,
+		&rpc.Project{Name: "projects/my-project", SpecValidation: rpc.Project_ENFORCE},
+		&rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+			MimeType: "application/x.openapi;version=3",
+			Contents: specContents,
+		},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     "projects/my-project/locations/global/apis/my-api/versions/v1/specs/my-spec",
+			Contents: []byte("not: [valid"),
+		},
+	}
+
+	if _, err := server.UpdateApiSpec(ctx, req); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateApiSpec(%+v) returned status code %q, want %q: %v", req, status.Code(err), codes.InvalidArgument, err)
+	}
+}
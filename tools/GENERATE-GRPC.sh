@@ -21,6 +21,10 @@ source tools/PROTOS.sh
 clone_common_protos
 
 go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@v2.5.0
 
 echo "Generating Go gRPC client/server for ${SERVICE_PROTOS[@]}"
 protoc ${SERVICE_PROTOS[*]} --proto_path='.' --proto_path=$COMMON_PROTOS_PATH --go-grpc_opt='module=github.com/apigee/registry' --go-grpc_out='.'
+
+echo "Generating Go HTTP/JSON gateway for ${SERVICE_PROTOS[@]}"
+protoc ${SERVICE_PROTOS[*]} --proto_path='.' --proto_path=$COMMON_PROTOS_PATH --grpc-gateway_opt='module=github.com/apigee/registry' --grpc-gateway_out='.'
@@ -0,0 +1,102 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FileExporter writes spans to a local file as JSON lines.
+// It allows traces to be inspected without running a collector.
+type FileExporter struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewFileExporter returns an exporter that appends spans to the named file.
+func NewFileExporter(name string) (*FileExporter, error) {
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// fileSpan is the representation of a span written by FileExporter.
+type fileSpan struct {
+	Name       string                 `json:"name"`
+	Service    string                 `json:"service,omitempty"`
+	TraceID    string                 `json:"trace_id"`
+	SpanID     string                 `json:"span_id"`
+	ParentID   string                 `json:"parent_id,omitempty"`
+	Kind       string                 `json:"kind"`
+	StartTime  time.Time              `json:"start_time"`
+	EndTime    time.Time              `json:"end_time"`
+	Duration   string                 `json:"duration"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Status     string                 `json:"status,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// ExportSpans writes spans to the file.
+func (e *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		v := fileSpan{
+			Name:      s.Name(),
+			TraceID:   s.SpanContext().TraceID().String(),
+			SpanID:    s.SpanContext().SpanID().String(),
+			Kind:      s.SpanKind().String(),
+			StartTime: s.StartTime(),
+			EndTime:   s.EndTime(),
+			Duration:  s.EndTime().Sub(s.StartTime()).String(),
+			Status:    s.Status().Code.String(),
+			Error:     s.Status().Description,
+		}
+		if s.Parent().IsValid() {
+			v.ParentID = s.Parent().SpanID().String()
+		}
+		if r := s.Resource(); r != nil {
+			if name, ok := r.Set().Value("service.name"); ok {
+				v.Service = name.AsString()
+			}
+		}
+		if attrs := s.Attributes(); len(attrs) > 0 {
+			v.Attributes = make(map[string]interface{}, len(attrs))
+			for _, a := range attrs {
+				v.Attributes[string(a.Key)] = a.Value.AsInterface()
+			}
+		}
+		if err := e.enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the file.
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.file.Close()
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures OpenTelemetry tracing for registry servers and clients.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// instrumentationName identifies spans created by this module.
+const instrumentationName = "github.com/apigee/registry"
+
+// Config configures the export of traces.
+type Config struct {
+	// Exporter determines where spans are sent.
+	// Values: [ none, otlp, file ]
+	// If unset, tracing is disabled.
+	Exporter string
+	// Endpoint is the address of an OTLP collector, used with the otlp exporter.
+	// If unset, the standard OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used.
+	Endpoint string
+	// Insecure disables transport security for connections to the OTLP collector.
+	Insecure bool
+	// File is the path where spans are written as JSON lines, used with the file exporter.
+	File string
+	// SampleRatio is the fraction of new traces that are recorded.
+	// Traces continued from a sampled parent are always recorded.
+	// If unset or zero, all traces are recorded.
+	SampleRatio float64
+	// ServiceName identifies the process that produced each span.
+	ServiceName string
+}
+
+// ConfigFromEnv returns a tracing configuration read from environment variables,
+// for use by command-line tools.
+func ConfigFromEnv(serviceName string) Config {
+	c := Config{
+		Exporter:    os.Getenv("APG_REGISTRY_TRACE_EXPORTER"),
+		Endpoint:    os.Getenv("APG_REGISTRY_TRACE_ENDPOINT"),
+		File:        os.Getenv("APG_REGISTRY_TRACE_FILE"),
+		ServiceName: serviceName,
+	}
+	c.Insecure, _ = strconv.ParseBool(os.Getenv("APG_REGISTRY_TRACE_INSECURE"))
+	c.SampleRatio, _ = strconv.ParseFloat(os.Getenv("APG_REGISTRY_TRACE_SAMPLE_RATIO"), 64)
+	return c
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case "", "none", "otlp":
+	case "file":
+		if c.File == "" {
+			return fmt.Errorf("a file must be specified for the file exporter")
+		}
+	default:
+		return fmt.Errorf("unsupported exporter %q: must be one of [none, otlp, file]", c.Exporter)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("invalid sample ratio %v: must be between 0 and 1", c.SampleRatio)
+	}
+	return nil
+}
+
+// Setup installs a global tracer provider and propagator using the provided configuration.
+// The returned function flushes any buffered spans and should be called before exiting.
+func Setup(ctx context.Context, c Config) (func(context.Context) error, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Trace context is always propagated so that traces started by clients
+	// are continued by servers, even if this process doesn't export spans.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch c.Exporter {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		opts := []otlptracegrpc.Option{}
+		if c.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(c.Endpoint))
+		}
+		if c.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		e, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		exporter = e
+	case "file":
+		e, err := NewFileExporter(c.File)
+		if err != nil {
+			return nil, err
+		}
+		exporter = e
+	}
+
+	sampler := sdktrace.ParentBased(sdktrace.AlwaysSample())
+	if c.SampleRatio > 0 && c.SampleRatio < 1 {
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(c.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer used to create spans in this module.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// DialOptions returns gRPC dial options that propagate trace context to servers
+// and create a span for each outgoing call.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config Config
+		valid  bool
+	}{
+		{
+			desc:  "disabled",
+			valid: true,
+		},
+		{
+			desc:   "otlp",
+			config: Config{Exporter: "otlp", Endpoint: "localhost:4317"},
+			valid:  true,
+		},
+		{
+			desc:   "file",
+			config: Config{Exporter: "file", File: "/tmp/spans.json"},
+			valid:  true,
+		},
+		{
+			desc:   "file without path",
+			config: Config{Exporter: "file"},
+		},
+		{
+			desc:   "unknown exporter",
+			config: Config{Exporter: "zipkin"},
+		},
+		{
+			desc:   "sample ratio out of range",
+			config: Config{SampleRatio: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.valid && err != nil {
+				t.Errorf("Validate(%+v) returned error: %s", test.config, err)
+			} else if !test.valid && err == nil {
+				t.Errorf("Validate(%+v) returned no error, expected invalid configuration", test.config)
+			}
+		})
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Setup(ctx, Config{Exporter: "file", File: path, ServiceName: "test"})
+	if err != nil {
+		t.Fatalf("Setup returned error: %s", err)
+	}
+
+	parentCtx, parent := Tracer().Start(ctx, "parent")
+	_, child := Tracer().Start(parentCtx, "child")
+	child.End()
+	parent.End()
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown returned error: %s", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open span file: %s", err)
+	}
+	defer f.Close()
+
+	spans := make(map[string]fileSpan)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s fileSpan
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("Failed to parse span %q: %s", scanner.Text(), err)
+		}
+		spans[s.Name] = s
+	}
+
+	if len(spans) != 2 {
+		t.Fatalf("Exported %d spans, want 2: %+v", len(spans), spans)
+	}
+	p, c := spans["parent"], spans["child"]
+	if c.TraceID != p.TraceID {
+		t.Errorf("Child span has trace ID %q, want %q", c.TraceID, p.TraceID)
+	}
+	if c.ParentID != p.SpanID {
+		t.Errorf("Child span has parent ID %q, want %q", c.ParentID, p.SpanID)
+	}
+	if p.Service != "test" {
+		t.Errorf("Parent span has service %q, want %q", p.Service, "test")
+	}
+}